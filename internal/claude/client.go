@@ -6,8 +6,13 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"time"
 )
 
+// DefaultGracePeriod is how long Claude is given to exit after SIGTERM before
+// it is killed, when [ExecutorConfig.GracePeriod] is not set.
+const DefaultGracePeriod = 10 * time.Second
+
 // Executor runs Claude CLI and returns streaming events.
 //
 // Executor provides two execution modes:
@@ -67,10 +72,20 @@ type ExecutorConfig struct {
 	// Provide a custom parser only if you need to adjust buffer sizes.
 	Parser Parser
 
+	// WorkDir is the working directory Claude runs in.
+	// If empty, Claude inherits the current working directory.
+	// Parallel runs set this to a per-story git worktree.
+	WorkDir string
+
 	// StderrHandler is called for each line written to stderr by Claude.
 	// If nil, stderr output is silently discarded.
 	// Set this to capture error messages or debug output from Claude.
 	StderrHandler func(line string)
+
+	// GracePeriod is how long Claude and the processes it started are given
+	// to exit after SIGTERM when the context is canceled, before they are
+	// sent SIGKILL. If zero, defaults to [DefaultGracePeriod].
+	GracePeriod time.Duration
 }
 
 // DefaultExecutor implements [Executor] by spawning Claude as a subprocess.
@@ -91,6 +106,7 @@ type DefaultExecutor struct {
 //   - BinaryPath defaults to "claude"
 //   - OutputFormat defaults to "stream-json"
 //   - Parser defaults to a new [DefaultParser]
+//   - GracePeriod defaults to [DefaultGracePeriod]
 //
 // Pass an empty [ExecutorConfig] to use all defaults.
 func NewExecutor(config ExecutorConfig) *DefaultExecutor {
@@ -100,6 +116,9 @@ func NewExecutor(config ExecutorConfig) *DefaultExecutor {
 	if config.OutputFormat == "" {
 		config.OutputFormat = "stream-json"
 	}
+	if config.GracePeriod <= 0 {
+		config.GracePeriod = DefaultGracePeriod
+	}
 
 	parser := config.Parser
 	if parser == nil {
@@ -112,6 +131,12 @@ func NewExecutor(config ExecutorConfig) *DefaultExecutor {
 	}
 }
 
+// SetWorkDir sets the directory Claude runs in, see [ExecutorConfig.WorkDir].
+// It must not be called while Claude is running.
+func (e *DefaultExecutor) SetWorkDir(dir string) {
+	e.config.WorkDir = dir
+}
+
 // Execute runs Claude with the given prompt and returns a channel of [Event] objects.
 //
 // The returned channel emits events as they are parsed from Claude's streaming output.
@@ -124,12 +149,7 @@ func NewExecutor(config ExecutorConfig) *DefaultExecutor {
 // intentionally not propagated. Use [DefaultExecutor.ExecuteWithResult] if you need
 // to check whether Claude completed successfully.
 func (e *DefaultExecutor) Execute(ctx context.Context, prompt string) (<-chan Event, error) {
-	cmd := exec.CommandContext(ctx, e.config.BinaryPath,
-		"--dangerously-skip-permissions",
-		"--verbose",
-		"-p", prompt,
-		"--output-format", e.config.OutputFormat,
-	)
+	cmd := e.command(ctx, prompt)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -146,7 +166,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context, prompt string) (<-chan Ev
 	}
 
 	// Handle stderr in background
-	go e.handleStderr(stderr)
+	go e.handleStderr(stderr, stderrHandlerFrom(ctx))
 
 	// Parse stdout and return events channel
 	events := e.parser.Parse(stdout)
@@ -172,13 +192,12 @@ func (e *DefaultExecutor) Execute(ctx context.Context, prompt string) (<-chan Ev
 // The handler may be nil if you only need the exit code without processing events.
 // If the handler is provided, it is called synchronously for each event before
 // this method returns.
+//
+// When ctx is canceled, Claude and every process it started receive SIGTERM,
+// followed by SIGKILL if they are still running after the grace period. The
+// exit code is then -1; callers use the context to tell why Claude stopped.
 func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler) (int, error) {
-	cmd := exec.CommandContext(ctx, e.config.BinaryPath,
-		"--dangerously-skip-permissions",
-		"--verbose",
-		"-p", prompt,
-		"--output-format", e.config.OutputFormat,
-	)
+	cmd := e.command(ctx, prompt)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -195,7 +214,11 @@ func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string,
 	}
 
 	// Handle stderr in background
-	go e.handleStderr(stderr)
+	stderrDone := make(chan struct{})
+	go func() {
+		defer close(stderrDone)
+		e.handleStderr(stderr, stderrHandlerFrom(ctx))
+	}()
 
 	// Process events
 	events := e.parser.Parse(stdout)
@@ -205,6 +228,9 @@ func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string,
 		}
 	}
 
+	// All stderr must be read before Wait closes the pipe
+	<-stderrDone
+
 	// Wait for command completion
 	err = cmd.Wait()
 
@@ -212,6 +238,9 @@ func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string,
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			exitCode = exitErr.ExitCode()
+		} else if ctx.Err() != nil {
+			// Claude exited cleanly after being told to stop
+			exitCode = -1
 		} else {
 			return 1, err
 		}
@@ -220,15 +249,51 @@ func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string,
 	return exitCode, nil
 }
 
-func (e *DefaultExecutor) handleStderr(stderr io.ReadCloser) {
-	if e.config.StderrHandler == nil {
+// command builds the Claude CLI invocation for the given prompt. If ctx carries a
+// session from [WithResumeSession], Claude is asked to resume it.
+//
+// Claude runs in its own process group. Canceling ctx terminates the whole group
+// gracefully instead of killing only the Claude process, so tools such as test
+// runners do not outlive it.
+func (e *DefaultExecutor) command(ctx context.Context, prompt string) *exec.Cmd {
+	args := []string{
+		"--dangerously-skip-permissions",
+		"--verbose",
+		"-p", prompt,
+		"--output-format", e.config.OutputFormat,
+	}
+	if sessionID := resumeSessionFrom(ctx); sessionID != "" {
+		args = append(args, "--resume", sessionID)
+	}
+	cmd := exec.CommandContext(ctx, e.config.BinaryPath, args...)
+	cmd.Dir = e.config.WorkDir
+
+	startProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return terminateProcessGroup(cmd, e.config.GracePeriod)
+	}
+	// Stop waiting for output pipes held open by stray processes once the
+	// group has had time to be killed.
+	cmd.WaitDelay = 2 * e.config.GracePeriod
+	return cmd
+}
+
+// handleStderr passes each stderr line to the configured handler and to the
+// per-execution handler from [WithStderrHandler], if any.
+func (e *DefaultExecutor) handleStderr(stderr io.ReadCloser, ctxHandler func(line string)) {
+	if e.config.StderrHandler == nil && ctxHandler == nil {
 		_, _ = io.Copy(io.Discard, stderr) //nolint:errcheck // Intentionally discarding stderr
 		return
 	}
 
 	scanner := bufio.NewScanner(stderr)
 	for scanner.Scan() {
-		e.config.StderrHandler(scanner.Text())
+		if e.config.StderrHandler != nil {
+			e.config.StderrHandler(scanner.Text())
+		}
+		if ctxHandler != nil {
+			ctxHandler(scanner.Text())
+		}
 	}
 }
 
@@ -261,12 +326,25 @@ type MockExecutor struct {
 	Error error
 
 	// ExitCode is the value returned from [MockExecutor.ExecuteWithResult].
-	// Ignored if Error is set.
+	// Ignored if Error or ExitCodes is set.
 	ExitCode int
 
+	// ExitCodes, if set, supplies the exit code for each successive call to
+	// [MockExecutor.ExecuteWithResult]. The last value repeats once exhausted.
+	// Use this to simulate a failure followed by a successful retry.
+	ExitCodes []int
+
+	// Stderr lines are passed to the handler from [WithStderrHandler], if any,
+	// before [MockExecutor.ExecuteWithResult] returns.
+	Stderr []string
+
 	// RecordedPrompts accumulates all prompts passed to Execute/ExecuteWithResult.
 	// Use this in tests to verify the correct prompts were sent.
 	RecordedPrompts []string
+
+	// RecordedSessions accumulates the session passed with [WithResumeSession]
+	// for each call to Execute/ExecuteWithResult, or "" for a new session.
+	RecordedSessions []string
 }
 
 // Execute returns the pre-configured [MockExecutor.Events] via a channel.
@@ -277,6 +355,7 @@ type MockExecutor struct {
 // closed when all events have been sent or the context is canceled.
 func (m *MockExecutor) Execute(ctx context.Context, prompt string) (<-chan Event, error) {
 	m.RecordedPrompts = append(m.RecordedPrompts, prompt)
+	m.RecordedSessions = append(m.RecordedSessions, resumeSessionFrom(ctx))
 
 	if m.Error != nil {
 		return nil, m.Error
@@ -302,19 +381,39 @@ func (m *MockExecutor) Execute(ctx context.Context, prompt string) (<-chan Event
 // The prompt is recorded in [MockExecutor.RecordedPrompts] for later verification.
 // If [MockExecutor.Error] is set, it returns 1 and the error immediately.
 // Otherwise, all [MockExecutor.Events] are passed to the handler synchronously,
-// then the configured exit code is returned.
+// then the configured exit code is returned. If ctx is canceled while events are
+// being passed, the remaining events are dropped and -1 is returned, as for a
+// killed process.
 func (m *MockExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler) (int, error) {
 	m.RecordedPrompts = append(m.RecordedPrompts, prompt)
+	m.RecordedSessions = append(m.RecordedSessions, resumeSessionFrom(ctx))
 
 	if m.Error != nil {
 		return 1, m.Error
 	}
 
 	for _, event := range m.Events {
+		if ctx.Err() != nil {
+			return -1, nil
+		}
 		if handler != nil {
 			handler(event)
 		}
 	}
 
+	if stderrHandler := stderrHandlerFrom(ctx); stderrHandler != nil {
+		for _, line := range m.Stderr {
+			stderrHandler(line)
+		}
+	}
+
+	if len(m.ExitCodes) > 0 {
+		call := len(m.RecordedPrompts) - 1
+		if call >= len(m.ExitCodes) {
+			call = len(m.ExitCodes) - 1
+		}
+		return m.ExitCodes[call], nil
+	}
+
 	return m.ExitCode, nil
 }
@@ -3,7 +3,11 @@ package claude
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -113,14 +117,17 @@ func TestNewExecutor(t *testing.T) {
 	assert.NotNil(t, exec)
 	assert.Equal(t, "claude", exec.config.BinaryPath)
 	assert.Equal(t, "stream-json", exec.config.OutputFormat)
+	assert.Equal(t, DefaultGracePeriod, exec.config.GracePeriod)
 
 	// Test custom config
 	exec = NewExecutor(ExecutorConfig{
 		BinaryPath:   "/custom/claude",
 		OutputFormat: "json",
+		GracePeriod:  time.Second,
 	})
 	assert.Equal(t, "/custom/claude", exec.config.BinaryPath)
 	assert.Equal(t, "json", exec.config.OutputFormat)
+	assert.Equal(t, time.Second, exec.config.GracePeriod)
 }
 
 func TestNewExecutor_WithCustomParser(t *testing.T) {
@@ -227,3 +234,84 @@ func TestNewExecutor_UsesProvidedParser(t *testing.T) {
 
 	assert.Equal(t, customParser, exec.parser)
 }
+
+func TestDefaultExecutor_Command(t *testing.T) {
+	exec := NewExecutor(ExecutorConfig{
+		BinaryPath: "/custom/claude",
+		WorkDir:    "/work/tree",
+	})
+
+	cmd := exec.command(context.Background(), "do things")
+
+	assert.Equal(t, "/work/tree", cmd.Dir)
+	assert.Equal(t, []string{
+		"/custom/claude",
+		"--dangerously-skip-permissions",
+		"--verbose",
+		"-p", "do things",
+		"--output-format", "stream-json",
+	}, cmd.Args)
+}
+
+func TestDefaultExecutor_Command_ResumeSession(t *testing.T) {
+	exec := NewExecutor(ExecutorConfig{BinaryPath: "claude"})
+
+	cmd := exec.command(WithResumeSession(context.Background(), "abc-123"), "keep going")
+
+	assert.Equal(t, []string{
+		"claude",
+		"--dangerously-skip-permissions",
+		"--verbose",
+		"-p", "keep going",
+		"--output-format", "stream-json",
+		"--resume", "abc-123",
+	}, cmd.Args)
+}
+
+func TestMockExecutor_ExecuteWithResult_ExitCodesAndStderr(t *testing.T) {
+	mock := &MockExecutor{
+		ExitCodes: []int{2, 0},
+		Stderr:    []string{"rate limit exceeded"},
+	}
+
+	var lines []string
+	ctx := WithStderrHandler(context.Background(), func(line string) {
+		lines = append(lines, line)
+	})
+
+	first, err := mock.ExecuteWithResult(ctx, "prompt", nil)
+	require.NoError(t, err)
+	second, _ := mock.ExecuteWithResult(ctx, "prompt", nil)
+	third, _ := mock.ExecuteWithResult(context.Background(), "prompt", nil)
+
+	assert.Equal(t, []int{2, 0, 0}, []int{first, second, third}, "last exit code should repeat")
+	assert.Equal(t, []string{"rate limit exceeded", "rate limit exceeded"}, lines)
+}
+
+func TestDefaultExecutor_ExecuteWithResult_ContextStderrHandler(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	script := filepath.Join(t.TempDir(), "claude")
+	content := "#!/bin/sh\necho 'first line' >&2\necho 'rate limit exceeded' >&2\nexit 3\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	var configured, perCall []string
+	exec := NewExecutor(ExecutorConfig{
+		BinaryPath: script,
+		StderrHandler: func(line string) {
+			configured = append(configured, line)
+		},
+	})
+
+	ctx := WithStderrHandler(context.Background(), func(line string) {
+		perCall = append(perCall, line)
+	})
+	exitCode, err := exec.ExecuteWithResult(ctx, "prompt", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, []string{"first line", "rate limit exceeded"}, perCall)
+	assert.Equal(t, perCall, configured, "configured handler should still receive stderr")
+}
@@ -0,0 +1,43 @@
+package claude
+
+import "context"
+
+// contextKey is the type for values stored in a context by this package.
+type contextKey int
+
+const (
+	stderrHandlerKey contextKey = iota
+	resumeSessionKey
+)
+
+// WithStderrHandler returns a copy of ctx that carries an additional stderr handler.
+//
+// Executors call the handler for each line Claude writes to stderr during a single
+// execution, in addition to any [ExecutorConfig.StderrHandler]. This lets callers
+// inspect stderr for one run, for example to decide whether a failure is retryable,
+// without changing the shared executor.
+func WithStderrHandler(ctx context.Context, handler func(line string)) context.Context {
+	return context.WithValue(ctx, stderrHandlerKey, handler)
+}
+
+// stderrHandlerFrom returns the stderr handler stored in ctx, or nil.
+func stderrHandlerFrom(ctx context.Context) func(line string) {
+	handler, _ := ctx.Value(stderrHandlerKey).(func(line string))
+	return handler
+}
+
+// WithResumeSession returns a copy of ctx that makes executors continue the given
+// Claude session instead of starting a new one.
+//
+// The session ID comes from [Event.SessionID]. Claude is started with its resume
+// flag, so it keeps the conversation of the earlier session and receives the
+// prompt as the next message.
+func WithResumeSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, resumeSessionKey, sessionID)
+}
+
+// resumeSessionFrom returns the session ID stored in ctx, or "".
+func resumeSessionFrom(ctx context.Context) string {
+	sessionID, _ := ctx.Value(resumeSessionKey).(string)
+	return sessionID
+}
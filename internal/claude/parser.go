@@ -93,6 +93,7 @@ func (p *DefaultParser) Parse(reader io.Reader) <-chan Event {
 			}
 
 			event := NewEventFromStream(&streamEvent)
+			event.JSON = json.RawMessage(line)
 			events <- event
 		}
 
@@ -124,5 +125,7 @@ func ParseSingle(line string) (Event, error) {
 	if err := json.Unmarshal([]byte(line), &streamEvent); err != nil {
 		return Event{}, err
 	}
-	return NewEventFromStream(&streamEvent), nil
+	event := NewEventFromStream(&streamEvent)
+	event.JSON = json.RawMessage(line)
+	return event, nil
 }
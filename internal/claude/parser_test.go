@@ -110,6 +110,37 @@ func TestDefaultParser_Parse_ToolResult(t *testing.T) {
 	assert.True(t, event.IsToolResult())
 }
 
+func TestDefaultParser_Parse_ResultUsage(t *testing.T) {
+	input := `{"type":"result","subtype":"success","is_error":false,"duration_ms":65432,"duration_api_ms":60000,"num_turns":12,"result":"Done","session_id":"abc","total_cost_usd":1.2345,"usage":{"input_tokens":42,"cache_creation_input_tokens":1000,"cache_read_input_tokens":20000,"output_tokens":3000,"service_tier":"standard"}}`
+
+	parser := NewParser()
+	events := parser.Parse(strings.NewReader(input))
+
+	event := <-events
+	assert.True(t, event.SessionComplete)
+	assert.False(t, event.IsError)
+	assert.Equal(t, 12, event.NumTurns)
+	assert.Equal(t, int64(65432), event.Duration.Milliseconds())
+	assert.InDelta(t, 1.2345, event.CostUSD, 1e-9)
+	assert.Equal(t, int64(42), event.Usage.InputTokens)
+	assert.Equal(t, int64(3000), event.Usage.OutputTokens)
+	assert.Equal(t, int64(1000), event.Usage.CacheCreationInputTokens)
+	assert.Equal(t, int64(20000), event.Usage.CacheReadInputTokens)
+	assert.Equal(t, "abc", event.SessionID)
+}
+
+func TestDefaultParser_Parse_SessionID(t *testing.T) {
+	input := `{"type":"system","subtype":"init","session_id":"8f14e45f-ceea-467f-a0e6-ab5b9b1c9c2e","tools":["Bash"],"model":"claude-sonnet"}`
+
+	parser := NewParser()
+	events := parser.Parse(strings.NewReader(input))
+
+	event := <-events
+	assert.True(t, event.SessionStarted)
+	assert.Equal(t, "8f14e45f-ceea-467f-a0e6-ab5b9b1c9c2e", event.SessionID)
+	assert.JSONEq(t, input, string(event.JSON), "the original line should be kept")
+}
+
 func TestParseSingle(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package claude
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// startProcessGroup makes cmd the leader of a new process group, so that the
+// tools Claude spawns can be signaled together with it.
+func startProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// terminateProcessGroup sends SIGTERM to the process group of cmd and, if the
+// group is still running after grace, SIGKILL.
+func terminateProcessGroup(cmd *exec.Cmd, grace time.Duration) error {
+	pgid := -cmd.Process.Pid
+	if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil {
+		return err
+	}
+	time.AfterFunc(grace, func() {
+		_ = syscall.Kill(pgid, syscall.SIGKILL) //nolint:errcheck // The group may already have exited
+	})
+	return nil
+}
@@ -0,0 +1,78 @@
+//go:build !windows
+
+package claude
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startScript runs script through ExecuteWithResult and cancels the context
+// once the script writes "started" to stderr.
+func startScript(t *testing.T, script string, grace time.Duration) (exitCode int, stderr []string, elapsed time.Duration) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "claude")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+
+	exec := NewExecutor(ExecutorConfig{BinaryPath: path, GracePeriod: grace})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	ctx = WithStderrHandler(ctx, func(line string) {
+		stderr = append(stderr, line)
+		if line == "started" {
+			close(started)
+		}
+	})
+
+	done := make(chan struct{})
+	var cancelAt time.Time
+	go func() {
+		defer close(done)
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+		}
+		cancelAt = time.Now()
+		cancel()
+	}()
+
+	exitCode, err := exec.ExecuteWithResult(ctx, "prompt", nil)
+	<-done
+	require.NoError(t, err)
+	return exitCode, stderr, time.Since(cancelAt)
+}
+
+func TestDefaultExecutor_Cancel_SendsSIGTERM(t *testing.T) {
+	script := "#!/bin/sh\ntrap 'echo terminated >&2; exit 0' TERM\necho started >&2\nwhile true; do sleep 0.05; done\n"
+
+	exitCode, stderr, elapsed := startScript(t, script, 5*time.Second)
+
+	assert.Equal(t, -1, exitCode, "a canceled run is not a success even if Claude exits cleanly")
+	assert.Contains(t, stderr, "terminated")
+	assert.Less(t, elapsed, 5*time.Second, "should not wait for the grace period")
+}
+
+func TestDefaultExecutor_Cancel_KillsProcessTreeAfterGracePeriod(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "survived")
+	script := "#!/bin/sh\ntrap '' TERM\n(sleep 1; touch " + marker + ") &\necho started >&2\nwait\n"
+
+	exitCode, _, elapsed := startScript(t, script, 200*time.Millisecond)
+
+	assert.Equal(t, -1, exitCode)
+	assert.GreaterOrEqual(t, elapsed, 200*time.Millisecond, "SIGKILL should wait for the grace period")
+	assert.Less(t, elapsed, time.Second)
+
+	// The child would create the marker one second after starting
+	time.Sleep(time.Second)
+	assert.NoFileExists(t, marker, "child process should be killed with Claude")
+}
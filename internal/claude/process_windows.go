@@ -0,0 +1,17 @@
+//go:build windows
+
+package claude
+
+import (
+	"os/exec"
+	"time"
+)
+
+// startProcessGroup is a no-op on Windows.
+func startProcessGroup(cmd *exec.Cmd) {}
+
+// terminateProcessGroup kills the Claude process. Windows has no SIGTERM, so
+// there is no grace period.
+func terminateProcessGroup(cmd *exec.Cmd, grace time.Duration) error {
+	return cmd.Process.Kill()
+}
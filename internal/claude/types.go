@@ -12,6 +12,11 @@
 // real processes.
 package claude
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // StreamEvent represents a raw JSON event from Claude's streaming output.
 //
 // This is the low-level structure that maps directly to Claude's stream-json format.
@@ -22,8 +27,27 @@ package claude
 type StreamEvent struct {
 	Type          string          `json:"type"`
 	Subtype       string          `json:"subtype,omitempty"`
+	SessionID     string          `json:"session_id,omitempty"`
 	Message       *MessageContent `json:"message,omitempty"`
 	ToolUseResult *ToolResult     `json:"tool_use_result,omitempty"`
+
+	// The following fields are only present on result events.
+	IsError      bool    `json:"is_error,omitempty"`
+	DurationMS   int64   `json:"duration_ms,omitempty"`
+	NumTurns     int     `json:"num_turns,omitempty"`
+	TotalCostUSD float64 `json:"total_cost_usd,omitempty"`
+	Usage        *Usage  `json:"usage,omitempty"`
+}
+
+// Usage reports the tokens consumed by a Claude session.
+//
+// It appears in result events within [StreamEvent.Usage]. Cache token counts
+// are reported separately from regular input tokens.
+type Usage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
 }
 
 // MessageContent represents the content of a message in Claude's streaming output.
@@ -32,6 +56,9 @@ type StreamEvent struct {
 // and/or tool invocations. This structure appears in assistant-type events
 // within [StreamEvent.Message].
 type MessageContent struct {
+	// ID identifies the message. Claude emits one event per content block,
+	// so the events of one message share the same ID.
+	ID      string         `json:"id,omitempty"`
 	Content []ContentBlock `json:"content,omitempty"`
 }
 
@@ -123,6 +150,11 @@ type Event struct {
 	// the parsed fields are insufficient.
 	Raw *StreamEvent
 
+	// JSON is the line of Claude's output the event was parsed from, including
+	// fields that [StreamEvent] does not map. Empty for events that were not
+	// parsed from output, such as those of [MockExecutor].
+	JSON json.RawMessage
+
 	// Type is the parsed event type (system, assistant, user, or result).
 	Type EventType
 
@@ -130,10 +162,18 @@ type Event struct {
 	// For system events, this may be "init" (see [SubtypeInit]).
 	Subtype string
 
+	// SessionID identifies the Claude session. It is always set on the init
+	// event and can be passed to [WithResumeSession] to continue the session.
+	SessionID string
+
 	// Text contains the text content when Type is [EventTypeAssistant]
 	// and the content block is of type "text". Empty otherwise.
 	Text string
 
+	// MessageID identifies the message an [EventTypeAssistant] event is part
+	// of. One message, and so one turn, can span several events.
+	MessageID string
+
 	// ToolName is the name of the tool being invoked when Type is
 	// [EventTypeAssistant] and the content block is of type "tool_use".
 	ToolName string
@@ -165,6 +205,26 @@ type Event struct {
 	// SessionComplete is true for result events, indicating the
 	// Claude session has finished.
 	SessionComplete bool
+
+	// IsError is true when the result event reports that the session
+	// ended in an error. Only set for result events.
+	IsError bool
+
+	// Duration is the session duration reported by Claude.
+	// Only set for result events.
+	Duration time.Duration
+
+	// NumTurns is the number of conversation turns in the session.
+	// Only set for result events.
+	NumTurns int
+
+	// CostUSD is the total cost of the session in US dollars.
+	// Only set for result events.
+	CostUSD float64
+
+	// Usage holds the tokens consumed by the session.
+	// Only set for result events; zero if Claude did not report usage.
+	Usage Usage
 }
 
 // NewEventFromStream creates an [Event] from a raw [StreamEvent].
@@ -174,9 +234,10 @@ type Event struct {
 // types (system, assistant, user, result) and populates the appropriate fields.
 func NewEventFromStream(raw *StreamEvent) Event {
 	e := Event{
-		Raw:     raw,
-		Type:    EventType(raw.Type),
-		Subtype: raw.Subtype,
+		Raw:       raw,
+		Type:      EventType(raw.Type),
+		Subtype:   raw.Subtype,
+		SessionID: raw.SessionID,
 	}
 
 	switch e.Type {
@@ -187,6 +248,7 @@ func NewEventFromStream(raw *StreamEvent) Event {
 
 	case EventTypeAssistant:
 		if raw.Message != nil {
+			e.MessageID = raw.Message.ID
 			for _, block := range raw.Message.Content {
 				switch block.Type {
 				case "text":
@@ -211,6 +273,13 @@ func NewEventFromStream(raw *StreamEvent) Event {
 
 	case EventTypeResult:
 		e.SessionComplete = true
+		e.IsError = raw.IsError
+		e.Duration = time.Duration(raw.DurationMS) * time.Millisecond
+		e.NumTurns = raw.NumTurns
+		e.CostUSD = raw.TotalCostUSD
+		if raw.Usage != nil {
+			e.Usage = *raw.Usage
+		}
 	}
 
 	return e
@@ -2,20 +2,23 @@ package claude
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewEventFromStream_SystemInit(t *testing.T) {
 	raw := &StreamEvent{
-		Type:    "system",
-		Subtype: "init",
+		Type:      "system",
+		Subtype:   "init",
+		SessionID: "0b1c2d3e-session",
 	}
 
 	event := NewEventFromStream(raw)
 
 	assert.Equal(t, EventTypeSystem, event.Type)
 	assert.Equal(t, "init", event.Subtype)
+	assert.Equal(t, "0b1c2d3e-session", event.SessionID)
 	assert.True(t, event.SessionStarted)
 	assert.False(t, event.SessionComplete)
 }
@@ -24,6 +27,7 @@ func TestNewEventFromStream_AssistantText(t *testing.T) {
 	raw := &StreamEvent{
 		Type: "assistant",
 		Message: &MessageContent{
+			ID: "msg_01",
 			Content: []ContentBlock{
 				{
 					Type: "text",
@@ -37,6 +41,7 @@ func TestNewEventFromStream_AssistantText(t *testing.T) {
 
 	assert.Equal(t, EventTypeAssistant, event.Type)
 	assert.Equal(t, "Hello, I'm Claude!", event.Text)
+	assert.Equal(t, "msg_01", event.MessageID)
 	assert.True(t, event.IsText())
 	assert.False(t, event.IsToolUse())
 }
@@ -95,6 +100,32 @@ func TestNewEventFromStream_Result(t *testing.T) {
 	assert.True(t, event.SessionComplete)
 }
 
+func TestNewEventFromStream_ResultUsage(t *testing.T) {
+	raw := &StreamEvent{
+		Type:         "result",
+		Subtype:      "success",
+		IsError:      true,
+		DurationMS:   12500,
+		NumTurns:     7,
+		TotalCostUSD: 0.4231,
+		Usage: &Usage{
+			InputTokens:              1200,
+			OutputTokens:             3400,
+			CacheCreationInputTokens: 500,
+			CacheReadInputTokens:     9000,
+		},
+	}
+
+	event := NewEventFromStream(raw)
+
+	assert.True(t, event.SessionComplete)
+	assert.True(t, event.IsError)
+	assert.Equal(t, 12500*time.Millisecond, event.Duration)
+	assert.Equal(t, 7, event.NumTurns)
+	assert.InDelta(t, 0.4231, event.CostUSD, 1e-9)
+	assert.Equal(t, Usage{InputTokens: 1200, OutputTokens: 3400, CacheCreationInputTokens: 500, CacheReadInputTokens: 9000}, event.Usage)
+}
+
 func TestEvent_IsText(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -2,6 +2,10 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -48,6 +52,9 @@ func TestNewApp(t *testing.T) {
 	assert.NotNil(t, app.Printer)
 	assert.NotNil(t, app.Runner)
 	assert.NotNil(t, app.StatusReader)
+	assert.NotNil(t, app.StateStore)
+	assert.NotNil(t, app.Router)
+	assert.NotNil(t, app.Repo)
 	assert.Equal(t, cfg, app.Config)
 }
 
@@ -71,6 +78,12 @@ func TestNewRootCommand_HasAllSubcommands(t *testing.T) {
 		"git-commit",
 		"run",
 		"queue",
+		"resume",
+		"retry",
+		"replay",
+		"status",
+		"set-status",
+		"watch",
 		"raw",
 	}
 
@@ -420,3 +433,90 @@ func TestExecuteResult(t *testing.T) {
 		assert.Error(t, result.Err)
 	})
 }
+
+func TestOutputFlag_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: review")
+	app, _, textBuf := setupRunTestApp(tmpDir)
+	app.StatusWriter = status.NewWriter(tmpDir)
+
+	jsonBuf := &bytes.Buffer{}
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(jsonBuf)
+	rootCmd.SetArgs([]string{"--output", "json", "run", "STORY-1"})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Empty(t, textBuf.String(), "text printer should be replaced")
+	assert.Equal(t, "json", app.Config.Output.Format)
+
+	var types []string
+	decoder := json.NewDecoder(jsonBuf)
+	for decoder.More() {
+		var event output.JSONEvent
+		require.NoError(t, decoder.Decode(&event))
+		types = append(types, event.Type)
+	}
+	assert.Contains(t, types, output.EventStepStart)
+	assert.Contains(t, types, output.EventCommandStart)
+	assert.Contains(t, types, output.EventCommandEnd)
+	assert.Equal(t, output.EventCycleSummary, types[len(types)-1])
+}
+
+func TestOutputFlag_JSONStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: review")
+	app, _, _ := setupRunTestApp(tmpDir)
+	app.StatusWriter = status.NewWriter(tmpDir)
+
+	script := filepath.Join(tmpDir, "claude")
+	content := "#!/bin/sh\necho 'rate limit warning' >&2\necho '{\"type\":\"result\"}'\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+	app.Runner = workflow.NewRunner(claude.NewExecutor(claude.ExecutorConfig{
+		BinaryPath:    script,
+		StderrHandler: app.printStderr,
+	}), app.Printer, app.Config)
+
+	jsonBuf := &bytes.Buffer{}
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(jsonBuf)
+	rootCmd.SetArgs([]string{"--output", "json", "run", "STORY-1"})
+	require.NoError(t, rootCmd.Execute())
+
+	var stderr []string
+	decoder := json.NewDecoder(jsonBuf)
+	for decoder.More() {
+		var event output.JSONEvent
+		require.NoError(t, decoder.Decode(&event), "stdout should only contain JSON")
+		if event.Type == output.EventStderr {
+			stderr = append(stderr, event.Message)
+		}
+	}
+	assert.Equal(t, []string{"rate limit warning", "rate limit warning"}, stderr, "one line each from code-review and git-commit")
+}
+
+func TestOutputFlag_Text(t *testing.T) {
+	app := setupTestApp()
+	printer := app.Printer
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"--output", "text", "raw", "hello"})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Same(t, printer, app.Printer, "an unchanged format should keep the printer")
+}
+
+func TestOutputFlag_Invalid(t *testing.T) {
+	app := setupTestApp()
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--output", "yaml", "raw", "hello"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown output format")
+}
@@ -3,15 +3,21 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/report"
 	"bmad-automate/internal/router"
+	"bmad-automate/internal/runlog"
 )
 
 func newEpicCommand(app *App) *cobra.Command {
 	var dryRun bool
+	var parallel int
+	var reports reportFlag
 
 	cmd := &cobra.Command{
 		Use:   "epic <epic-id>",
@@ -33,12 +39,20 @@ Status is updated in sprint-status.yaml after each successful workflow.
 
 Use --dry-run to preview workflows without executing them.
 
+Use --report junit=report.xml to write a JUnit XML report of the run for CI,
+or --report markdown=run.md (or html=run.html) for a report of what Claude did.
+A Markdown report of every epic run is also written to the output.report_dir
+directory, _bmad-output/reports by default.
+
+Use --parallel N to run up to N stories at once. Each story runs in its own
+git worktree under .bmad-worktrees/ on a bmad/<story-key> branch, and its
+output is printed in one block when it finishes. Status updates are serialized.
+
 Example:
   bmad-automate epic 6
   # Runs 6-1-*, 6-2-*, 6-3-*, etc. each to completion in order`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
 			epicID := args[0]
 
 			// Get all stories for this epic
@@ -49,73 +63,65 @@ Example:
 			}
 
 			// Create lifecycle executor with app dependencies
-			executor := lifecycle.NewExecutor(app.Runner, app.StatusReader, app.StatusWriter)
+			executor := app.newLifecycleExecutor()
 
 			// Handle dry-run mode
 			if dryRun {
-				return runEpicDryRun(cmd, executor, epicID, storyKeys)
+				return runEpicDryRun(cmd, app, executor, epicID, storyKeys)
 			}
 
-			// Execute full lifecycle for each story in order
-			for _, storyKey := range storyKeys {
-				err := executor.Execute(ctx, storyKey)
-				if err != nil {
-					cmd.SilenceUsage = true
-					if errors.Is(err, router.ErrStoryComplete) {
-						fmt.Printf("Story %s is already complete, skipping\n", storyKey)
-						continue
-					}
-					fmt.Printf("Error running lifecycle for story %s: %v\n", storyKey, err)
-					return NewExitError(1)
-				}
-				fmt.Printf("Story %s completed successfully\n", storyKey)
-			}
-
-			fmt.Printf("All %d stories processed\n", len(storyKeys))
-			return nil
+			return runStories(cmd, app, executor, "epic "+epicID, storyKeys, parallel, withEpicReport(app, epicID, reports))
 		},
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview workflows without executing them")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of stories to run at once, each in its own git worktree")
+	addReportFlag(cmd, &reports)
 
 	return cmd
 }
 
-func runEpicDryRun(cmd *cobra.Command, executor *lifecycle.Executor, epicID string, storyKeys []string) error {
-	fmt.Printf("Dry run for epic %s:\n", epicID)
+// withEpicReport adds the Markdown report in the configured report directory of
+// the project, if any, to the reports requested for a run of the given epic.
+func withEpicReport(app *App, epicID string, reports reportFlag) reportFlag {
+	dir := app.projectPath(app.Config.Output.ReportDir)
+	if dir == "" {
+		return reports
+	}
+	name := fmt.Sprintf("epic-%s-%s.md", epicID, runlog.NewRunID(time.Now()))
+	return append(reports, report.Spec{Format: report.FormatMarkdown, Path: filepath.Join(dir, name), GitIgnore: true})
+}
+
+func runEpicDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, epicID string, storyKeys []string) error {
+	app.Printer.Info("Dry run for epic %s:", epicID)
+	app.Printer.Newline()
 
 	totalWorkflows := 0
 	storiesWithWork := 0
 	storiesComplete := 0
 
 	for _, storyKey := range storyKeys {
-		fmt.Println()
-		fmt.Printf("Story %s:\n", storyKey)
-
 		steps, err := executor.GetSteps(storyKey)
 		if err != nil {
 			if errors.Is(err, router.ErrStoryComplete) {
-				fmt.Printf("  (already complete)\n")
+				app.Printer.Plan(storyKey, nil)
 				storiesComplete++
 				continue
 			}
 			cmd.SilenceUsage = true
-			fmt.Printf("  Error: %v\n", err)
+			app.Printer.Error("story %s: %v", storyKey, err)
 			return NewExitError(1)
 		}
 
-		for i, step := range steps {
-			fmt.Printf("  %d. %s → %s\n", i+1, step.Workflow, step.NextStatus)
-		}
+		app.Printer.Plan(storyKey, planSteps(steps))
 		totalWorkflows += len(steps)
 		storiesWithWork++
 	}
 
-	fmt.Println()
 	if storiesComplete > 0 {
-		fmt.Printf("Total: %d workflows across %d stories (%d already complete)\n", totalWorkflows, storiesWithWork, storiesComplete)
+		app.Printer.Info("Total: %d workflows across %d stories (%d already complete)", totalWorkflows, storiesWithWork, storiesComplete)
 	} else {
-		fmt.Printf("Total: %d workflows across %d stories\n", totalWorkflows, storiesWithWork)
+		app.Printer.Info("Total: %d workflows across %d stories", totalWorkflows, storiesWithWork)
 	}
 
 	return nil
@@ -2,12 +2,14 @@ package cli
 
 import (
 	"bytes"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
 	"bmad-automate/internal/status"
 )
 
@@ -145,12 +147,15 @@ func TestEpicCommand_FullLifecycleExecution(t *testing.T) {
 			}
 			mockWriter := &MockStatusWriter{}
 			statusReader := status.NewReader(tmpDir)
+			cfg := config.DefaultConfig()
+			cfg.Output.ReportDir = filepath.Join(tmpDir, "reports")
 
 			app := &App{
-				Config:       config.DefaultConfig(),
+				Config:       cfg,
 				StatusReader: statusReader,
 				StatusWriter: mockWriter,
 				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
 			}
 
 			rootCmd := NewRootCommand(app)
@@ -205,6 +210,7 @@ func TestEpicCommand_NoStoriesFoundReturnsError(t *testing.T) {
 		StatusReader: statusReader,
 		StatusWriter: mockWriter,
 		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
 	}
 
 	rootCmd := NewRootCommand(app)
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"path/filepath"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/lifecycle"
+)
+
+// repoGuard returns the git safety checks of the app's repository as
+// configured in a.Config.Git, or nil if they are disabled or the app has no
+// repository.
+func (a *App) repoGuard() lifecycle.RepoGuard {
+	if a.Repo == nil || !a.Config.Git.Checks {
+		return nil
+	}
+	return git.NewGuard(a.Repo, guardOptions(a.Config.Git, a.ownFiles()))
+}
+
+// worktreeGuard returns the git safety checks for the worktree of a parallel
+// story at dir, or nil if they are disabled. Worktrees have their own
+// bmad/<story-key> branch, so the default branch check is skipped.
+func worktreeGuard(cfg config.GitConfig, dir string) lifecycle.RepoGuard {
+	if !cfg.Checks {
+		return nil
+	}
+	opts := guardOptions(cfg, nil)
+	opts.AllowDefaultBranch = true
+	return git.NewGuard(git.NewRepo(dir), opts)
+}
+
+// guardOptions converts the git configuration to [git.GuardOptions] that
+// ignore changes to exclude.
+func guardOptions(cfg config.GitConfig, exclude []string) git.GuardOptions {
+	return git.GuardOptions{
+		AllowDirty:         cfg.AllowDirty,
+		AllowDefaultBranch: cfg.AllowDefaultBranch,
+		CommitWorkflow:     cfg.CommitWorkflow,
+		Exclude:            exclude,
+	}
+}
+
+// ownFiles returns the paths bmad-automate writes to while it runs, whose
+// changes the git checks ignore: the worktrees of parallel runs, the run logs
+// and reports, and the sprint status file and its lock. The checkpoint is kept
+// in the git directory, outside the working tree.
+//
+// Relative paths are relative to the project root, the directory of a.Repo.
+func (a *App) ownFiles() []string {
+	files := []string{WorktreeDir}
+	for _, dir := range []string{a.Config.Output.RunLogDir, a.Config.Output.ReportDir} {
+		if dir != "" {
+			files = append(files, dir)
+		}
+	}
+	if located, ok := a.StatusReader.(interface{ Path() string }); ok {
+		// The status path is relative to the working directory.
+		path := located.Path()
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		files = append(files, path, path+".lock")
+	}
+	return files
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/status"
+)
+
+// initGitProject turns dir into a git repository on branch main with all
+// its files committed.
+func initGitProject(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"add", "-A"},
+		{"commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+}
+
+func TestApp_RepoGuard(t *testing.T) {
+	app, _, _ := setupRunTestApp(t.TempDir())
+	assert.Nil(t, app.repoGuard(), "no repository")
+
+	app.Repo = git.NewRepo(t.TempDir())
+	assert.NotNil(t, app.repoGuard())
+
+	app.Config.Git.Checks = false
+	assert.Nil(t, app.repoGuard(), "checks disabled")
+}
+
+func TestApp_OwnFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	app, _, _ := setupRunTestApp(tmpDir)
+	app.Config.Output.RunLogDir = ""
+	statusFile := filepath.Join(tmpDir, status.DefaultStatusPath)
+
+	assert.Equal(t, []string{
+		".bmad-worktrees",
+		filepath.Join(tmpDir, "_bmad-output", "reports"),
+		statusFile,
+		statusFile + ".lock",
+	}, app.ownFiles())
+}
+
+func TestRunCommand_GitChecks(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		setup       func(t *testing.T, dir string)
+		wantPrompts int
+		wantOutput  string
+	}{
+		{
+			name:       "default branch",
+			wantOutput: "repository check failed: refusing to run on the default branch main",
+		},
+		{
+			name: "dirty tree",
+			args: []string{"--allow-default-branch"},
+			setup: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "wip.go"), nil, 0644))
+			},
+			wantOutput: "repository check failed: working tree has uncommitted changes: wip.go",
+		},
+		{
+			name: "commit workflow did not commit",
+			args: []string{"--allow-default-branch", "--allow-dirty"},
+			setup: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "wip.go"), nil, 0644))
+			},
+			wantPrompts: 2,
+			wantOutput:  "git-commit stopped: no new commit since the story started",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: review\n")
+			initGitProject(t, tmpDir)
+			if tt.setup != nil {
+				tt.setup(t, tmpDir)
+			}
+
+			app, mockExecutor, buf := setupRunTestApp(tmpDir)
+			app.Repo = git.NewRepo(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(append([]string{"run", "7-1-schema"}, tt.args...))
+
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			assert.Len(t, mockExecutor.RecordedPrompts, tt.wantPrompts)
+			assert.Contains(t, buf.String(), tt.wantOutput)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+)
+
+// ExitCodeInterrupted is the exit code returned when a run is stopped with
+// Ctrl-C, following the shell convention of 128 + SIGINT.
+const ExitCodeInterrupted = 130
+
+// handleInterrupts returns a context for running commands that reacts to Ctrl-C.
+//
+// The first SIGINT requests a graceful stop via [lifecycle.WithStopRequest]: the
+// running workflow step finishes and its status is written, then no further step
+// or story starts and the checkpoint is kept for resume. The second SIGINT cancels
+// the context, which terminates Claude and its child processes. After that the
+// default signal behavior is restored, so a third SIGINT exits immediately.
+//
+// Claude runs in its own process group, so the terminal's SIGINT does not reach
+// it directly. Notices are printed as warnings through the printer returned by
+// printer at the time of the signal, which follows an output format chosen after
+// the call. Call the returned function to stop listening for signals.
+func handleInterrupts(parent context.Context, printer func() output.Printer) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	stop := make(chan struct{})
+	ctx = lifecycle.WithStopRequest(ctx, stop)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		close(stop)
+		printer().Newline()
+		printer().Warning("Interrupt received: finishing the current step, then stopping. Press Ctrl-C again to stop Claude now.")
+
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		signal.Stop(signals)
+		printer().Newline()
+		printer().Warning("Stopping Claude...")
+		cancel()
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
+
+// finishInterrupted prints the summary of a run that was stopped early and
+// returns the [ExitCodeInterrupted] error for the command. If resumable is true
+// and a checkpoint was written, it also tells the user how to continue.
+func finishInterrupted(cmd *cobra.Command, app *App, results []output.StoryResult, storyKeys []string, start time.Time, resumable bool) error {
+	cmd.SilenceUsage = true
+	app.Printer.QueueSummary(results, storyKeys, time.Since(start))
+	if resumable && app.StateStore != nil {
+		app.Printer.Info("Run 'bmad-automate resume' to continue the interrupted story")
+	}
+	return NewExitError(ExitCodeInterrupted)
+}
+
+// stopRequested reports whether the user asked the run to stop.
+func stopRequested(ctx context.Context) bool {
+	return lifecycle.StopRequested(ctx) || ctx.Err() != nil
+}
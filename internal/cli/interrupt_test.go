@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/state"
+	"bmad-automate/internal/status"
+)
+
+// stoppingRunner requests a graceful stop while its first workflow runs.
+type stoppingRunner struct {
+	MockWorkflowRunner
+	stop chan struct{}
+}
+
+func (r *stoppingRunner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
+	if len(r.ExecutedWorkflows) == 0 {
+		close(r.stop)
+	}
+	return r.MockWorkflowRunner.RunSingle(ctx, workflowName, storyKey)
+}
+
+func TestQueueCommand_Interrupted(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog
+  STORY-2: backlog`)
+
+	runner := &stoppingRunner{stop: make(chan struct{})}
+	writer := &MockStatusWriter{}
+	store := state.NewManager(tmpDir)
+	printed := &bytes.Buffer{}
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: writer,
+		Runner:       runner,
+		StateStore:   store,
+		Printer:      output.NewPrinterWithWriter(printed),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"queue", "STORY-1", "STORY-2"})
+
+	err := rootCmd.ExecuteContext(lifecycle.WithStopRequest(context.Background(), runner.stop))
+
+	require.Error(t, err)
+	code, ok := IsExitError(err)
+	require.True(t, ok, "error should be an ExitError")
+	assert.Equal(t, ExitCodeInterrupted, code)
+
+	assert.Equal(t, []string{"create-story"}, runner.ExecutedWorkflows, "the current step should finish and no other should start")
+	assert.Equal(t, []StatusUpdate{{StoryKey: "STORY-1", NewStatus: status.StatusReadyForDev}}, writer.Updates)
+	assert.Contains(t, printed.String(), "QUEUE INTERRUPTED")
+	assert.Contains(t, printed.String(), "Remaining: 1")
+
+	saved, err := store.Load()
+	require.NoError(t, err, "checkpoint should be kept for resume")
+	assert.Equal(t, "STORY-1", saved.StoryKey)
+	assert.Equal(t, 1, saved.StepIndex)
+}
+
+func TestResumeCommand_Interrupted(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: ready-for-dev")
+
+	runner := &stoppingRunner{stop: make(chan struct{})}
+	store := state.NewManager(tmpDir)
+	require.NoError(t, store.Save(state.State{StoryKey: "STORY-1", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"}))
+	printed := &bytes.Buffer{}
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       runner,
+		StateStore:   store,
+		Printer:      output.NewPrinterWithWriter(printed),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"resume"})
+
+	err := rootCmd.ExecuteContext(lifecycle.WithStopRequest(context.Background(), runner.stop))
+
+	require.Error(t, err)
+	code, ok := IsExitError(err)
+	require.True(t, ok, "error should be an ExitError")
+	assert.Equal(t, ExitCodeInterrupted, code)
+
+	assert.Equal(t, []string{"dev-story"}, runner.ExecutedWorkflows)
+	assert.Contains(t, printed.String(), "QUEUE INTERRUPTED")
+	assert.Contains(t, printed.String(), "dev-story")
+	assert.Contains(t, printed.String(), "Run 'bmad-automate resume' to continue")
+
+	saved, err := store.Load()
+	require.NoError(t, err, "checkpoint should be kept for the next resume")
+	assert.Equal(t, 2, saved.StepIndex)
+}
+
+func TestHandleInterrupts(t *testing.T) {
+	proc, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+
+	notices := &lockedBuffer{}
+	printer := output.NewPrinterWithWriter(notices)
+	ctx, stop := handleInterrupts(context.Background(), func() output.Printer { return printer })
+	defer stop()
+
+	if err := proc.Signal(os.Interrupt); err != nil {
+		t.Skipf("cannot send interrupt: %v", err)
+	}
+	require.Eventually(t, func() bool { return lifecycle.StopRequested(ctx) }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, ctx.Err(), "first interrupt should not cancel the context")
+	require.Eventually(t, func() bool {
+		return strings.Contains(notices.String(), "Warning: Interrupt received")
+	}, time.Second, 5*time.Millisecond, "the notice should be printed as a warning")
+
+	require.NoError(t, proc.Signal(os.Interrupt))
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("second interrupt should cancel the context")
+	}
+	assert.Contains(t, notices.String(), "Warning: Stopping Claude...")
+}
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
+	"bmad-automate/internal/workflow"
+)
+
+// WorktreeDir is the directory, relative to the repository root, in which
+// per-story worktrees are created for parallel runs.
+const WorktreeDir = ".bmad-worktrees"
+
+// newWorktreeWorkspaceFactory returns a [lifecycle.WorkspaceFactory] that runs each
+// story in its own git worktree on a bmad/<story-key> branch. Worktrees are
+// created in the [WorktreeDir] of the project at repo.Dir(), and Claude works in
+// the worktree's copy of the project.
+//
+// Worktrees of stories that complete are removed; worktrees of failed stories are
+// kept so the changes can be inspected, and must be removed before the story
+// runs in parallel again. An existing bmad/<story-key> branch is reused with its
+// commits. Output from Claude, including stderr, is
+// buffered per story in the output format from cfg.Output.Format. Every story's
+// runner shares the given run budget and run log. Review loops read the story
+// files in the worktree's copy of the directory returned by storyDir. The git
+// safety checks of cfg.Git run against the worktree.
+func newWorktreeWorkspaceFactory(cfg *config.Config, repo *git.Repo, budget *workflow.Budget, runLog *runlog.Log, storyDir func() string) lifecycle.WorkspaceFactory {
+	return func(storyKey string) (*lifecycle.Workspace, error) {
+		dir, err := filepath.Abs(filepath.Join(repo.Dir(), WorktreeDir, storyKey))
+		if err != nil {
+			return nil, err
+		}
+		prefix, err := repo.Prefix()
+		if err != nil {
+			return nil, err
+		}
+		if err := repo.AddWorktree(dir, "bmad/"+storyKey); err != nil {
+			return nil, err
+		}
+		// The worktree holds the whole repository; the project is where
+		// repo.Dir() is within it.
+		project := filepath.Join(dir, filepath.FromSlash(prefix))
+
+		buf := &syncBuffer{}
+		printer := output.Format(cfg.Output.Format).NewPrinter(buf)
+		executor := claude.NewExecutor(claude.ExecutorConfig{
+			BinaryPath:    cfg.Claude.BinaryPath,
+			OutputFormat:  cfg.Claude.OutputFormat,
+			GracePeriod:   cfg.Claude.GracePeriod,
+			WorkDir:       project,
+			StderrHandler: printer.Stderr,
+		})
+
+		printer.CycleHeader(storyKey)
+
+		runner := workflow.NewRunner(executor, printer, cfg)
+		runner.SetBudget(budget)
+		runner.SetRunLog(runLog)
+		runner.SetWorkDir(project)
+
+		stories := worktreePath(repo.Dir(), project, storyDir())
+		return &lifecycle.Workspace{
+			Runner: runner,
+			Progress: func(stepIndex, totalSteps int, workflow string) {
+				printer.StepStart(stepIndex, totalSteps, workflow)
+			},
+			Findings: lifecycle.ReviewFindings{StoryDir: stories},
+			Tasks:    lifecycle.StoryTasks{StoryDir: stories},
+			Guard:    worktreeGuard(cfg.Git, project),
+			Output:   buf,
+			Release: func(success bool) error {
+				if !success {
+					printer.Info("Worktree kept for inspection: %s (remove it with \"git worktree remove --force %s\" before running the story again)", dir, dir)
+					return nil
+				}
+				return repo.RemoveWorktree(dir)
+			},
+		}, nil
+	}
+}
+
+// worktreePath returns the path in the worktree at worktree that corresponds to
+// path in the repository at repoDir. Paths outside the repository, and empty
+// paths, are returned unchanged.
+func worktreePath(repoDir, worktree, path string) string {
+	if path == "" {
+		return ""
+	}
+	absRepo, errRepo := filepath.Abs(repoDir)
+	absPath, errPath := filepath.Abs(path)
+	if errRepo != nil || errPath != nil {
+		return path
+	}
+	rel, err := filepath.Rel(absRepo, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.Join(worktree, rel)
+}
+
+// runParallel runs the lifecycle for storyKeys using a pool of workers and prints
+// a queue summary when all stories have finished. It returns the result of every
+// story that ran.
+//
+// Parallel runs neither write nor resume checkpoints, so a story that still has
+// one is refused: running it here would start its lifecycle over and leave the
+// checkpoint behind.
+func runParallel(cmd *cobra.Command, app *App, storyKeys []string, workers int) ([]output.StoryResult, error) {
+	cmd.SilenceUsage = true
+
+	if app.WorkspaceFactory == nil {
+		app.Printer.Error("parallel execution is not available")
+		return nil, NewExitError(1)
+	}
+
+	if app.StateStore != nil {
+		if saved, err := app.StateStore.Load(); err == nil && slices.Contains(storyKeys, saved.StoryKey) {
+			app.Printer.Error("story %s was interrupted at step %d/%d and parallel runs cannot resume it; run 'bmad-automate resume' first", saved.StoryKey, saved.StepIndex+1, saved.TotalSteps)
+			return nil, NewExitError(1)
+		}
+	}
+
+	start := time.Now()
+	app.Printer.QueueHeader(len(storyKeys), storyKeys)
+
+	pool := lifecycle.NewParallelExecutor(workers, app.StatusReader, app.StatusWriter, app.WorkspaceFactory, cmd.OutOrStdout())
+	pool.SetPrinter(app.Printer)
+	if app.Router != nil {
+		pool.SetRouter(app.Router)
+	}
+	results := pool.Execute(cmd.Context(), storyKeys)
+
+	// Parallel runs write no checkpoints, so there is nothing to resume.
+	if stopRequested(cmd.Context()) {
+		return results, finishInterrupted(cmd, app, results, storyKeys, start, false)
+	}
+
+	app.Printer.QueueSummary(results, storyKeys, time.Since(start))
+
+	for _, r := range results {
+		if !r.Success {
+			return results, NewExitError(1)
+		}
+	}
+	if len(results) < len(storyKeys) {
+		return results, NewExitError(1)
+	}
+	return results, nil
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent writes.
+//
+// Claude's stderr is read on a separate goroutine from its event stream, so
+// both can write to a story's buffered output at the same time.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends p to the buffer.
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// WriteTo drains the buffer into w.
+func (b *syncBuffer) WriteTo(w io.Writer) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.WriteTo(w)
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/lifecycle"
+)
+
+func TestWorktreePath(t *testing.T) {
+	repo := t.TempDir()
+	worktree := filepath.Join(repo, WorktreeDir, "7-1-schema")
+	outside := t.TempDir()
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"inside the repository", filepath.Join(repo, "_bmad-output", "implementation-artifacts"), filepath.Join(worktree, "_bmad-output", "implementation-artifacts")},
+		{"repository root", repo, worktree},
+		{"outside the repository", outside, outside},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, worktreePath(repo, worktree, tt.path))
+		})
+	}
+}
+
+func TestWorktreeWorkspaceFactory_FailedStoryRunsAgain(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("hello\n"), 0644))
+	initGitProject(t, tmpDir)
+	repo := git.NewRepo(tmpDir)
+	factory := newWorktreeWorkspaceFactory(config.DefaultConfig(), repo, nil, nil, func() string { return "" })
+	dir := filepath.Join(tmpDir, WorktreeDir, "7-1-schema")
+
+	// The first run commits on the story branch and fails.
+	ws, err := factory("7-1-schema")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.sql"), []byte("create table\n"), 0644))
+	for _, args := range [][]string{{"add", "schema.sql"}, {"commit", "-q", "-m", "schema"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	require.NoError(t, ws.Release(false))
+	assert.DirExists(t, dir, "failed worktree is kept")
+
+	_, err = factory("7-1-schema")
+	require.ErrorIs(t, err, git.ErrWorktreeExists)
+	assert.Contains(t, err.Error(), "git worktree remove --force "+dir)
+
+	require.NoError(t, repo.RemoveWorktree(dir))
+	ws, err = factory("7-1-schema")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "schema.sql"), "commits of the failed run are kept")
+	require.NoError(t, ws.Release(true))
+	assert.NoDirExists(t, dir)
+}
+
+func TestWorktreeWorkspaceFactory_ProjectInSubdirectory(t *testing.T) {
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "services", "shop")
+	createSprintStatusFile(t, projectDir, "development_status:\n  7-1-schema: ready-for-dev\n")
+	initGitProject(t, repoDir)
+	storyDir := filepath.Join(projectDir, "_bmad-output", "implementation-artifacts")
+	factory := newWorktreeWorkspaceFactory(config.DefaultConfig(), git.NewRepo(projectDir), nil, nil, func() string { return storyDir })
+
+	ws, err := factory("7-1-schema")
+	require.NoError(t, err)
+
+	worktree := filepath.Join(projectDir, WorktreeDir, "7-1-schema")
+	assert.FileExists(t, filepath.Join(worktree, "services", "shop", "_bmad-output", "implementation-artifacts", "sprint-status.yaml"))
+	assert.Equal(t, lifecycle.ReviewFindings{StoryDir: filepath.Join(worktree, "services", "shop", "_bmad-output", "implementation-artifacts")}, ws.Findings)
+	require.NoError(t, ws.Release(true))
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/runlog"
+	"bmad-automate/internal/state"
+	"bmad-automate/internal/status"
+)
+
+// statusPath returns the path of the sprint status file for the project
+// configuration.
+//
+// A configured status file is used as is, relative to the project directory.
+// Otherwise the file is discovered with [status.Locate], starting in the
+// project directory. If no BMAD project is found, the default location in the
+// project directory is used, so that commands report the missing file.
+func statusPath(project config.ProjectConfig) (string, error) {
+	dir := projectRoot(project)
+
+	if project.StatusFile != "" {
+		if filepath.IsAbs(project.StatusFile) {
+			return project.StatusFile, nil
+		}
+		return filepath.Join(dir, project.StatusFile), nil
+	}
+
+	path, _, err := status.Locate(dir)
+	if errors.Is(err, status.ErrNotFound) {
+		return filepath.Join(dir, status.DefaultStatusPath), nil
+	}
+	return path, err
+}
+
+// defaultProjectDir returns project.Dir if it is set. Otherwise it returns
+// the root of the BMAD project found with [status.Locate] from the working
+// directory, so that commands run from a subdirectory use the whole project,
+// or "" if there is none. A configured status file is relative to the working
+// directory, so no project is looked for then.
+func defaultProjectDir(project config.ProjectConfig) string {
+	if project.Dir != "" || project.StatusFile != "" {
+		return project.Dir
+	}
+	if _, root, err := status.Locate("."); err == nil {
+		return root
+	}
+	return ""
+}
+
+// projectRoot returns the directory of the project: project.Dir, or the
+// working directory if it is not set.
+func projectRoot(project config.ProjectConfig) string {
+	if project.Dir == "" {
+		return "."
+	}
+	return project.Dir
+}
+
+// projectPath returns path relative to the project root. Absolute and empty
+// paths are returned unchanged.
+func (a *App) projectPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(projectRoot(a.Config.Project), path)
+}
+
+// setProjectDir points the app at the project in dir: Claude and the
+// verification commands run in it, the git checks, checkpoints and parallel
+// worktrees use its repository, run logs start afresh in its run log
+// directory, and the status reader and writer use its status file. Injected
+// dependencies that cannot be re-pointed are kept.
+func (a *App) setProjectDir(dir string) error {
+	a.Config.Project.Dir = dir
+	root := projectRoot(a.Config.Project)
+
+	if executor, ok := a.Executor.(interface{ SetWorkDir(string) }); ok {
+		executor.SetWorkDir(root)
+	}
+	if runner, ok := a.Runner.(interface{ SetWorkDir(string) }); ok {
+		runner.SetWorkDir(root)
+	}
+	if a.Repo != nil {
+		a.Repo = git.NewRepo(root)
+		if _, ok := a.StateStore.(*state.Manager); ok {
+			a.StateStore = state.NewManager(stateDir(a.Repo))
+		}
+	}
+	a.startRun(runlog.NewRunID(time.Now()))
+
+	path, err := statusPath(a.Config.Project)
+	if err != nil {
+		return err
+	}
+	if reader, ok := a.StatusReader.(interface{ SetPath(string) }); ok {
+		reader.SetPath(path)
+	}
+	if writer, ok := a.StatusWriter.(interface{ SetPath(string) }); ok {
+		writer.SetPath(path)
+	}
+	return nil
+}
+
+// stateDir returns the directory of the lifecycle checkpoint: the git
+// directory of repo, so that the workflows never commit the checkpoint, or
+// the directory of repo if it is not a git repository.
+func stateDir(repo *git.Repo) string {
+	if dir, err := repo.GitDir(); err == nil {
+		return dir
+	}
+	return repo.Dir()
+}
+
+// storyDir returns the directory of the story files, which is the directory of
+// the sprint status file, or "" if the status reader does not know its path.
+func (a *App) storyDir() string {
+	located, ok := a.StatusReader.(interface{ Path() string })
+	if !ok {
+		return ""
+	}
+	return filepath.Dir(located.Path())
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/status"
+	"bmad-automate/internal/workflow"
+)
+
+func TestStatusPath(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "shop", "_bmad", "bmm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "shop", status.BMADConfigPath), []byte(`output_folder: "{project-root}/docs"`), 0644))
+
+	tests := []struct {
+		name    string
+		project config.ProjectConfig
+		want    string
+	}{
+		{
+			name:    "configured status file",
+			project: config.ProjectConfig{Dir: root, StatusFile: "planning/sprint-status.yaml"},
+			want:    filepath.Join(root, "planning", "sprint-status.yaml"),
+		},
+		{
+			name:    "absolute status file",
+			project: config.ProjectConfig{Dir: "ignored", StatusFile: filepath.Join(root, "status.yaml")},
+			want:    filepath.Join(root, "status.yaml"),
+		},
+		{
+			name:    "discovered from BMAD config",
+			project: config.ProjectConfig{Dir: filepath.Join(root, "shop")},
+			want:    filepath.Join(root, "shop", "docs", "implementation-artifacts", "sprint-status.yaml"),
+		},
+		{
+			name:    "no project found",
+			project: config.ProjectConfig{Dir: root},
+			want:    filepath.Join(root, status.DefaultStatusPath),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := statusPath(tt.project)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDefaultProjectDir(t *testing.T) {
+	root := t.TempDir()
+	createSprintStatusFile(t, root, "development_status: {}")
+	subdir := filepath.Join(root, "src", "api")
+	require.NoError(t, os.MkdirAll(subdir, 0755))
+
+	tests := []struct {
+		name    string
+		project config.ProjectConfig
+		want    string
+	}{
+		{
+			name: "project found from a subdirectory",
+			want: root,
+		},
+		{
+			name:    "configured project directory",
+			project: config.ProjectConfig{Dir: "services/shop"},
+			want:    "services/shop",
+		},
+		{
+			name:    "configured status file",
+			project: config.ProjectConfig{StatusFile: "sprint-status.yaml"},
+			want:    "",
+		},
+	}
+
+	t.Chdir(subdir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, defaultProjectDir(tt.project))
+		})
+	}
+
+	t.Run("no project found", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		assert.Empty(t, defaultProjectDir(config.ProjectConfig{}))
+	})
+
+	t.Run("NewApp runs in the project root", func(t *testing.T) {
+		app := NewApp(config.DefaultConfig())
+
+		assert.Equal(t, root, app.Config.Project.Dir)
+		assert.Equal(t, root, app.Repo.Dir())
+		assert.Equal(t, filepath.Join(root, status.DefaultStatusPath), app.StatusReader.(*status.Reader).Path())
+	})
+}
+
+func TestProjectDirFlag(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  1-1-home: done\n")
+	projectDir := filepath.Join(tmpDir, "services", "shop")
+	createSprintStatusFile(t, projectDir, "development_status:\n  7-1-cart: backlog\n")
+	app, _, buf := setupRunTestApp(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"set-status", "--project-dir", projectDir, "7-1-cart", "ready-for-dev"})
+	require.NoError(t, rootCmd.Execute())
+
+	got, err := status.NewReader(projectDir).GetStoryStatus("7-1-cart")
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReadyForDev, got)
+	assert.Equal(t, projectDir, app.Config.Project.Dir)
+
+	rootCmd = NewRootCommand(app)
+	rootCmd.SetArgs([]string{"status", "--project-dir", projectDir})
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, buf.String(), "7-1-cart")
+	assert.NotContains(t, buf.String(), "1-1-home")
+}
+
+func TestProjectDirFlag_InvalidBMADConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "_bmad", "bmm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, status.BMADConfigPath), []byte("output_folder: [unclosed"), 0644))
+	app, _, _ := setupRunTestApp(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"status", "--project-dir", tmpDir})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), status.BMADConfigPath)
+}
+
+func TestStateDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, dir, stateDir(git.NewRepo(dir)), "outside a git repository")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("shop\n"), 0644))
+	initGitProject(t, dir)
+	want, err := filepath.EvalSymlinks(filepath.Join(dir, ".git"))
+	require.NoError(t, err)
+	assert.Equal(t, want, stateDir(git.NewRepo(dir)))
+}
+
+func TestProjectDirFlag_RunsInProject(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	repoDir := t.TempDir()
+	projectDir := filepath.Join(repoDir, "services", "shop")
+	createSprintStatusFile(t, projectDir, "development_status:\n  7-1-schema: review\n")
+	initGitProject(t, repoDir)
+	logDir := t.TempDir()
+
+	// The fake Claude records the directory it runs in.
+	script := filepath.Join(logDir, "claude")
+	content := "#!/bin/sh\npwd >> " + filepath.Join(logDir, "claude.txt") + "\necho '{\"type\":\"result\"}'\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	cfg := config.DefaultConfig()
+	cfg.Claude.BinaryPath = script
+	cfg.Git.CommitWorkflow = ""
+	wf := cfg.Workflows["code-review"]
+	wf.Verify = config.VerifyConfig{Commands: []string{"pwd >> " + filepath.Join(logDir, "verify.txt")}}
+	cfg.Workflows["code-review"] = wf
+	app := NewApp(cfg)
+	app.Printer = output.NewPrinterWithWriter(&bytes.Buffer{})
+	app.Runner.(*workflow.Runner).SetPrinter(app.Printer)
+
+	t.Chdir(t.TempDir())
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"run", "--project-dir", projectDir, "--allow-default-branch", "7-1-schema"})
+	require.NoError(t, rootCmd.Execute())
+
+	wantDir, err := filepath.EvalSymlinks(projectDir)
+	require.NoError(t, err)
+	for _, name := range []string{"claude.txt", "verify.txt"} {
+		data, err := os.ReadFile(filepath.Join(logDir, name))
+		require.NoError(t, err)
+		for _, dir := range strings.Fields(string(data)) {
+			assert.Equal(t, wantDir, dir, name)
+		}
+	}
+	assert.DirExists(t, filepath.Join(projectDir, "_bmad-output", "runs"))
+	assert.Equal(t, projectDir, app.Repo.Dir())
+	assert.Equal(t, filepath.Join(wantDir, "..", "..", ".git"), stateDir(app.Repo))
+
+	got, err := status.NewReader(projectDir).GetStoryStatus("7-1-schema")
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusDone, got)
+
+	cwd, err := os.ReadDir(".")
+	require.NoError(t, err)
+	assert.Empty(t, cwd, "nothing is written to the working directory")
+}
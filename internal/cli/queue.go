@@ -2,16 +2,19 @@ package cli
 
 import (
 	"errors"
-	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
 	"bmad-automate/internal/router"
 )
 
 func newQueueCommand(app *App) *cobra.Command {
 	var dryRun bool
+	var parallel int
+	var reports reportFlag
 
 	cmd := &cobra.Command{
 		Use:   "queue <story-key> [story-key...]",
@@ -32,80 +35,102 @@ Status is updated in sprint-status.yaml after each successful workflow.
 
 Use --dry-run to preview workflows without executing them.
 
+Use --report junit=report.xml to write a JUnit XML report of the run for CI,
+or --report markdown=run.md (or html=run.html) for a report of what Claude did.
+
+Use --parallel N to run up to N stories at once. Each story runs in its own
+git worktree under .bmad-worktrees/ on a bmad/<story-key> branch, and its
+output is printed in one block when it finishes. Status updates are serialized.
+
 Example:
   bmad-automate queue 6-5 6-6 6-7 6-8`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
-
 			// Create lifecycle executor with app dependencies
-			executor := lifecycle.NewExecutor(app.Runner, app.StatusReader, app.StatusWriter)
+			executor := app.newLifecycleExecutor()
 
 			// Handle dry-run mode
 			if dryRun {
-				return runQueueDryRun(cmd, executor, args)
+				return runQueueDryRun(cmd, app, executor, args)
 			}
 
-			// Execute full lifecycle for each story in order
-			for _, storyKey := range args {
-				err := executor.Execute(ctx, storyKey)
-				if err != nil {
-					cmd.SilenceUsage = true
-					if errors.Is(err, router.ErrStoryComplete) {
-						fmt.Printf("Story %s is already complete, skipping\n", storyKey)
-						continue
-					}
-					fmt.Printf("Error running lifecycle for story %s: %v\n", storyKey, err)
-					return NewExitError(1)
-				}
-				fmt.Printf("Story %s completed successfully\n", storyKey)
-			}
-
-			fmt.Printf("All %d stories processed\n", len(args))
-			return nil
+			return runStories(cmd, app, executor, "queue", args, parallel, reports)
 		},
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview workflows without executing them")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of stories to run at once, each in its own git worktree")
+	addReportFlag(cmd, &reports)
 
 	return cmd
 }
 
-func runQueueDryRun(cmd *cobra.Command, executor *lifecycle.Executor, storyKeys []string) error {
-	fmt.Printf("Dry run for %d stories:\n", len(storyKeys))
+// runSequential runs the full lifecycle for each story in order, stopping at the
+// first failure or interrupt, and prints a queue summary including retry attempts.
+// It returns the result of every story that ran.
+func runSequential(cmd *cobra.Command, app *App, executor *lifecycle.Executor, storyKeys []string) ([]output.StoryResult, error) {
+	ctx := cmd.Context()
+	start := time.Now()
+	results := make([]output.StoryResult, 0, len(storyKeys))
+
+	for _, storyKey := range storyKeys {
+		if stopRequested(ctx) {
+			return results, finishInterrupted(cmd, app, results, storyKeys, start, false)
+		}
+
+		result, err := executor.ExecuteWithResult(ctx, storyKey)
+		results = append(results, result)
+		if err != nil {
+			cmd.SilenceUsage = true
+			if errors.Is(err, lifecycle.ErrInterrupted) {
+				return results, finishInterrupted(cmd, app, results, storyKeys, start, true)
+			}
+			if errors.Is(err, router.ErrStoryComplete) {
+				app.Printer.Info("Story %s is already complete, skipping", storyKey)
+				continue
+			}
+			app.Printer.Error("running lifecycle for story %s: %v", storyKey, err)
+			app.Printer.QueueSummary(results, storyKeys, time.Since(start))
+			return results, NewExitError(1)
+		}
+		app.Printer.Info("Story %s completed successfully", storyKey)
+	}
+
+	app.Printer.Info("All %d stories processed", len(storyKeys))
+	app.Printer.QueueSummary(results, storyKeys, time.Since(start))
+	return results, nil
+}
+
+func runQueueDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, storyKeys []string) error {
+	app.Printer.Info("Dry run for %d stories:", len(storyKeys))
+	app.Printer.Newline()
 
 	totalWorkflows := 0
 	storiesWithWork := 0
 	storiesComplete := 0
 
 	for _, storyKey := range storyKeys {
-		fmt.Println()
-		fmt.Printf("Story %s:\n", storyKey)
-
 		steps, err := executor.GetSteps(storyKey)
 		if err != nil {
 			if errors.Is(err, router.ErrStoryComplete) {
-				fmt.Printf("  (already complete)\n")
+				app.Printer.Plan(storyKey, nil)
 				storiesComplete++
 				continue
 			}
 			cmd.SilenceUsage = true
-			fmt.Printf("  Error: %v\n", err)
+			app.Printer.Error("story %s: %v", storyKey, err)
 			return NewExitError(1)
 		}
 
-		for i, step := range steps {
-			fmt.Printf("  %d. %s → %s\n", i+1, step.Workflow, step.NextStatus)
-		}
+		app.Printer.Plan(storyKey, planSteps(steps))
 		totalWorkflows += len(steps)
 		storiesWithWork++
 	}
 
-	fmt.Println()
 	if storiesComplete > 0 {
-		fmt.Printf("Total: %d workflows across %d stories (%d already complete)\n", totalWorkflows, storiesWithWork, storiesComplete)
+		app.Printer.Info("Total: %d workflows across %d stories (%d already complete)", totalWorkflows, storiesWithWork, storiesComplete)
 	} else {
-		fmt.Printf("Total: %d workflows across %d stories\n", totalWorkflows, storiesWithWork)
+		app.Printer.Info("Total: %d workflows across %d stories", totalWorkflows, storiesWithWork)
 	}
 
 	return nil
@@ -2,12 +2,16 @@ package cli
 
 import (
 	"bytes"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"bmad-automate/internal/config"
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/state"
 	"bmad-automate/internal/status"
 )
 
@@ -167,6 +171,7 @@ func TestQueueCommand_FullLifecycleExecution(t *testing.T) {
 				StatusReader: statusReader,
 				StatusWriter: mockWriter,
 				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
 			}
 
 			rootCmd := NewRootCommand(app)
@@ -223,6 +228,7 @@ func TestQueueCommand_StoryNotFoundReturnsError(t *testing.T) {
 		StatusReader: statusReader,
 		StatusWriter: mockWriter,
 		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
 	}
 
 	rootCmd := NewRootCommand(app)
@@ -255,6 +261,7 @@ func TestQueueCommand_MissingSprintStatusFile(t *testing.T) {
 		StatusReader: statusReader,
 		StatusWriter: mockWriter,
 		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
 	}
 
 	rootCmd := NewRootCommand(app)
@@ -276,3 +283,139 @@ func TestQueueCommand_MissingSprintStatusFile(t *testing.T) {
 // Note: Legacy tests removed - obsolete after lifecycle executor change.
 // The queue command now executes full lifecycle (multiple workflows per story), not single workflow routing.
 // See TestQueueCommand_FullLifecycleExecution for comprehensive lifecycle testing.
+
+func TestQueueCommand_Parallel(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: review
+  STORY-2: done
+  STORY-3: ready-for-dev`)
+
+	var mu sync.Mutex
+	runners := map[string]*MockWorkflowRunner{}
+	released := map[string]bool{}
+
+	mockWriter := &MockStatusWriter{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: mockWriter,
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		WorkspaceFactory: func(storyKey string) (*lifecycle.Workspace, error) {
+			runner := &MockWorkflowRunner{}
+			mu.Lock()
+			runners[storyKey] = runner
+			mu.Unlock()
+			return &lifecycle.Workspace{
+				Runner: runner,
+				Output: &bytes.Buffer{},
+				Release: func(success bool) error {
+					mu.Lock()
+					released[storyKey] = success
+					mu.Unlock()
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	rootCmd := NewRootCommand(app)
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs([]string{"queue", "--parallel", "2", "STORY-1", "STORY-2", "STORY-3"})
+
+	err := rootCmd.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, runners, "STORY-1")
+	require.Contains(t, runners, "STORY-3")
+	assert.NotContains(t, runners, "STORY-2", "done story should not get a workspace")
+	assert.Equal(t, []string{"code-review", "git-commit"}, runners["STORY-1"].ExecutedWorkflows)
+	assert.Equal(t, []string{"dev-story", "code-review", "git-commit"}, runners["STORY-3"].ExecutedWorkflows)
+	assert.Equal(t, map[string]bool{"STORY-1": true, "STORY-3": true}, released)
+	assert.Len(t, mockWriter.Updates, 5)
+	assert.Empty(t, app.Runner.(*MockWorkflowRunner).ExecutedWorkflows, "shared runner should not be used")
+}
+
+func TestQueueCommand_ParallelUnavailable(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: review")
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"queue", "--parallel", "2", "STORY-1"})
+
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	code, ok := IsExitError(err)
+	assert.True(t, ok, "error should be an ExitError")
+	assert.Equal(t, 1, code)
+}
+
+func TestQueueCommand_ParallelCheckpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkpoint string
+		wantErr    bool
+	}{
+		{name: "checkpoint for a queued story", checkpoint: "STORY-1", wantErr: true},
+		{name: "checkpoint for another story", checkpoint: "STORY-9", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: review")
+
+			store := state.NewManager(tmpDir)
+			require.NoError(t, store.Save(state.State{StoryKey: tt.checkpoint, StepIndex: 1, TotalSteps: 2, StartStatus: "review"}))
+
+			var started []string
+			outBuf := &bytes.Buffer{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       &MockWorkflowRunner{},
+				Printer:      output.NewPrinterWithWriter(outBuf),
+				StateStore:   store,
+				WorkspaceFactory: func(storyKey string) (*lifecycle.Workspace, error) {
+					started = append(started, storyKey)
+					return &lifecycle.Workspace{Runner: &MockWorkflowRunner{}, Output: &bytes.Buffer{}}, nil
+				},
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(outBuf)
+			rootCmd.SetErr(outBuf)
+			rootCmd.SetArgs([]string{"queue", "--parallel", "2", "STORY-1"})
+
+			err := rootCmd.Execute()
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, []string{"STORY-1"}, started)
+				return
+			}
+			require.Error(t, err)
+			code, ok := IsExitError(err)
+			assert.True(t, ok, "error should be an ExitError")
+			assert.Equal(t, 1, code)
+			assert.Empty(t, started, "no story should start")
+			assert.Contains(t, outBuf.String(), "story STORY-1 was interrupted at step 2/2")
+			assert.True(t, store.Exists(), "checkpoint should be kept")
+		})
+	}
+}
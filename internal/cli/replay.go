@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/runlog"
+	"bmad-automate/internal/workflow"
+)
+
+func newReplayCommand(app *App) *cobra.Command {
+	var (
+		speed float64
+		step  string
+		story string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay <run-dir>",
+		Short: "Re-render the Claude output of a recorded run",
+		Long: `Replay the run log of an earlier run in the terminal, exactly as the output
+looked live.
+
+The run directory is one of the directories in _bmad-output/runs. Every
+attempt recorded in its step logs is replayed in the order the attempts
+started, so retries and review loops appear as they did live, with the
+recorded pauses between events divided by --speed. Use --speed 0 to print
+everything at once.
+
+Use --step and --story to replay only some of the steps.
+
+Examples:
+  bmad-automate replay _bmad-output/runs/20260304-050607
+  bmad-automate replay _bmad-output/runs/20260304-050607 --step code-review --speed 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			attempts, err := runlog.Attempts(args[0])
+			if err != nil {
+				app.Printer.Error("%v", err)
+				return NewExitError(1)
+			}
+
+			// Consecutive attempts of a step are replayed together, so the
+			// pauses between retries are kept.
+			var selected []runlog.Attempt
+			for _, a := range attempts {
+				if (step != "" && a.Step != step) || (story != "" && a.Story != story) {
+					continue
+				}
+				if n := len(selected); n > 0 && selected[n-1].Path == a.Path {
+					selected[n-1].Records = append(selected[n-1].Records, a.Records...)
+					continue
+				}
+				selected = append(selected, a)
+			}
+			if len(selected) == 0 {
+				app.Printer.Error("no step logs to replay in %s", args[0])
+				return NewExitError(1)
+			}
+
+			// There is no step to finish, so the first Ctrl-C stops the replay.
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+			go func() {
+				select {
+				case <-lifecycle.StopRequest(ctx):
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			runner := workflow.NewRunner(app.Executor, app.Printer, app.Config)
+			for i, a := range selected {
+				app.Printer.StepStart(i+1, len(selected), fmt.Sprintf("%s / %s", a.Story, a.Step))
+				if err := runner.Replay(ctx, a.Records, speed); err != nil {
+					return NewExitError(ExitCodeInterrupted)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64Var(&speed, "speed", 1, "Replay speed multiplier; 0 replays without pauses")
+	cmd.Flags().StringVar(&step, "step", "", "Only replay this workflow step, e.g. code-review")
+	cmd.Flags().StringVar(&story, "story", "", "Only replay the steps of this story")
+
+	return cmd
+}
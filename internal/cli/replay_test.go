@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
+)
+
+func writeRunLog(t *testing.T, dir string) string {
+	t.Helper()
+	log := runlog.New(dir, "run-1", "abc123")
+	for _, s := range []struct{ step, text string }{
+		{"create-story", "Story file written"},
+		{"code-review", "No issues found"},
+	} {
+		step, err := log.StartStep("STORY-1", s.step, 1, s.step+" prompt")
+		require.NoError(t, err)
+		step.Event(claude.Event{Raw: &claude.StreamEvent{
+			Type:    "assistant",
+			Message: &claude.MessageContent{Content: []claude.ContentBlock{{Type: "text", Text: s.text}}},
+		}})
+		require.NoError(t, step.End(0, output.Usage{}, "", ""))
+	}
+	return log.Dir()
+}
+
+func TestReplayCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "all steps",
+			args:     []string{"--speed", "0"},
+			contains: []string{"Story file written", "No issues found"},
+		},
+		{
+			name:        "filter by step",
+			args:        []string{"--speed", "0", "--step", "code-review"},
+			contains:    []string{"No issues found", "code-review: STORY-1"},
+			notContains: []string{"Story file written"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			runDir := writeRunLog(t, tmpDir)
+			app, mockExecutor, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(append([]string{"replay", runDir}, tt.args...))
+			require.NoError(t, rootCmd.Execute())
+
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, buf.String(), s)
+			}
+			assert.Empty(t, mockExecutor.RecordedPrompts, "replay should not run Claude")
+		})
+	}
+}
+
+func TestReplayCommand_AttemptOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	log := runlog.New(tmpDir, "run-1", "abc123")
+	for _, s := range []struct {
+		step    string
+		attempt int
+		text    string
+	}{
+		{"code-review", 1, "Found 2 issues"},
+		{"dev-story", 1, "Fixed the issues"},
+		{"code-review", 2, "No issues found"},
+	} {
+		step, err := log.StartStep("STORY-1", s.step, s.attempt, s.step+" prompt")
+		require.NoError(t, err)
+		step.Event(claude.Event{Raw: &claude.StreamEvent{
+			Type:    "assistant",
+			Message: &claude.MessageContent{Content: []claude.ContentBlock{{Type: "text", Text: s.text}}},
+		}})
+		require.NoError(t, step.End(0, output.Usage{}, "", ""))
+		time.Sleep(time.Millisecond)
+	}
+	app, _, buf := setupRunTestApp(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"replay", log.Dir(), "--speed", "0"})
+	require.NoError(t, rootCmd.Execute())
+
+	out := buf.String()
+	first := strings.Index(out, "Found 2 issues")
+	fix := strings.Index(out, "Fixed the issues")
+	second := strings.Index(out, "No issues found")
+	require.True(t, first >= 0 && fix >= 0 && second >= 0, out)
+	assert.Less(t, first, fix, "the fix should follow the first review")
+	assert.Less(t, fix, second, "the second review should follow the fix")
+	assert.Contains(t, out, "[3/3] STORY-1 / code-review")
+}
+
+func TestReplayCommand_NothingToReplay(t *testing.T) {
+	tests := []struct {
+		name string
+		args func(runDir string) []string
+	}{
+		{
+			name: "missing run directory",
+			args: func(runDir string) []string { return []string{"replay", filepath.Join(runDir, "missing")} },
+		},
+		{
+			name: "no matching step",
+			args: func(runDir string) []string { return []string{"replay", runDir, "--step", "dev-story"} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			runDir := writeRunLog(t, tmpDir)
+			app, _, _ := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args(runDir))
+			err := rootCmd.Execute()
+
+			code, ok := IsExitError(err)
+			require.True(t, ok)
+			assert.Equal(t, 1, code)
+		})
+	}
+}
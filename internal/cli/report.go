@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/report"
+)
+
+// reportFlag collects the reports requested with --report. It implements
+// pflag.Value, so invalid values are rejected while flags are parsed.
+type reportFlag []report.Spec
+
+// String returns the requested reports, comma-separated.
+func (f *reportFlag) String() string {
+	specs := make([]string, len(*f))
+	for i, spec := range *f {
+		specs[i] = spec.String()
+	}
+	return strings.Join(specs, ",")
+}
+
+// Set adds a <format>=<path> report.
+func (f *reportFlag) Set(value string) error {
+	spec, err := report.ParseSpec(value)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, spec)
+	return nil
+}
+
+// Type returns the value placeholder shown in help output.
+func (f *reportFlag) Type() string {
+	return "format=path"
+}
+
+// addReportFlag registers the repeatable --report flag on cmd.
+func addReportFlag(cmd *cobra.Command, reports *reportFlag) {
+	cmd.Flags().Var(reports, "report", "Write a report of the run, e.g. junit=report.xml (repeatable)")
+}
+
+// writeReports writes the requested reports of run and returns err, the
+// command's own result. If a report cannot be written, the problem is printed
+// and a command that otherwise succeeded fails with exit code 1.
+func writeReports(app *App, reports reportFlag, run report.Run, err error) error {
+	for _, spec := range reports {
+		if writeErr := spec.Write(run); writeErr != nil {
+			app.Printer.Error("%v", writeErr)
+			if err == nil {
+				err = NewExitError(1)
+			}
+			continue
+		}
+		app.Printer.Info("Wrote %s report to %s", spec.Format, spec.Path)
+	}
+	return err
+}
+
+// runStories runs the lifecycle of every story, sequentially or with the given
+// number of parallel workers, and writes the requested reports. The name
+// identifies the command in reports.
+func runStories(cmd *cobra.Command, app *App, executor *lifecycle.Executor, name string, storyKeys []string, parallel int, reports reportFlag) error {
+	start := time.Now()
+
+	var (
+		results []output.StoryResult
+		err     error
+	)
+	if parallel > 1 {
+		results, err = runParallel(cmd, app, storyKeys, parallel)
+	} else {
+		results, err = runSequential(cmd, app, executor, storyKeys)
+	}
+
+	return writeReports(app, reports, report.Run{
+		Name:     name,
+		Stories:  storyKeys,
+		Results:  results,
+		Started:  start,
+		Duration: time.Since(start),
+	}, err)
+}
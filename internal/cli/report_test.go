@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/status"
+)
+
+func TestReportFlag_JUnit(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		exitCodes    []int
+		wantExitCode int
+		contains     []string
+	}{
+		{
+			name:     "queue",
+			args:     []string{"queue", "STORY-1", "STORY-2"},
+			contains: []string{`<testsuite name="STORY-1" tests="2"`, `<skipped message="already done">`, `name="bmad-automate queue"`},
+		},
+		{
+			name:         "failed run",
+			args:         []string{"run", "STORY-1"},
+			exitCodes:    []int{0, 2},
+			wantExitCode: 1,
+			contains:     []string{`<failure message="git-commit failed with exit code 2"`, `name="bmad-automate run STORY-1"`},
+		},
+		{
+			name:     "epic",
+			args:     []string{"epic", "STORY"},
+			contains: []string{`name="bmad-automate epic STORY"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: review\n  STORY-2: done")
+			app, mockExecutor, buf := setupRunTestApp(tmpDir)
+			app.StatusWriter = status.NewWriter(tmpDir)
+			mockExecutor.ExitCodes = tt.exitCodes
+			path := filepath.Join(tmpDir, "reports", "junit.xml")
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(append(tt.args, "--report", "junit="+path))
+			err := rootCmd.Execute()
+
+			if tt.wantExitCode != 0 {
+				code, ok := IsExitError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantExitCode, code)
+			} else {
+				require.NoError(t, err)
+			}
+			data, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			for _, s := range tt.contains {
+				assert.Contains(t, string(data), s)
+			}
+			assert.Contains(t, buf.String(), "Wrote junit report to "+path)
+		})
+	}
+}
+
+func TestReportFlag_Invalid(t *testing.T) {
+	app := setupTestApp()
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"queue", "STORY-1", "--report", "tap=report.tap"})
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown report format")
+}
+
+func TestReportFlag_WriteError(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: done")
+	app, _, buf := setupRunTestApp(tmpDir)
+	// A file where the report directory should be
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "reports"), nil, 0644))
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"run", "STORY-1", "--report", "junit=" + filepath.Join(tmpDir, "reports", "junit.xml")})
+	err := rootCmd.Execute()
+
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code, "a report that cannot be written should fail the command")
+	assert.Contains(t, buf.String(), "failed to create report directory")
+}
+
+func TestEpicCommand_MarkdownReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: review\n  7-2-api: done")
+	app, mockExecutor, buf := setupRunTestApp(tmpDir)
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, SessionStarted: true},
+		{Type: claude.EventTypeAssistant, ToolName: "Edit", ToolFilePath: "internal/schema.go"},
+		{Type: claude.EventTypeAssistant, ToolName: "Bash", ToolCommand: "go test ./..."},
+		{Type: claude.EventTypeAssistant, Text: "All review findings fixed."},
+		{Type: claude.EventTypeResult, SessionComplete: true, CostUSD: 0.25},
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"epic", "7"})
+	require.NoError(t, rootCmd.Execute())
+
+	matches, err := filepath.Glob(filepath.Join(app.Config.Output.ReportDir, "epic-7-*.md"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	data, err := os.ReadFile(matches[0])
+	require.NoError(t, err)
+
+	report := string(data)
+	assert.Contains(t, report, "# bmad-automate epic 7")
+	assert.Contains(t, report, "| 7-1-schema | completed | review → done |")
+	assert.Contains(t, report, "| 7-2-api | skipped (already done) |")
+	assert.Contains(t, report, "### code-review")
+	assert.Contains(t, report, "- `internal/schema.go`")
+	assert.Contains(t, report, "go test ./...")
+	assert.Contains(t, report, "All review findings fixed.")
+	assert.Contains(t, buf.String(), "Wrote markdown report to "+matches[0])
+	assert.FileExists(t, filepath.Join(app.Config.Output.ReportDir, ".gitignore"), "reports are never committed")
+}
+
+func TestEpicCommand_MarkdownReportDisabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: done")
+	app, _, buf := setupRunTestApp(tmpDir)
+	app.Config.Output.ReportDir = ""
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"epic", "7"})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.NotContains(t, buf.String(), "Wrote markdown report")
+}
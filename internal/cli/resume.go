@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/state"
+)
+
+func newResumeCommand(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume",
+		Short: "Resume an interrupted story lifecycle",
+		Long: `Resume the story lifecycle recorded in .bmad-state.json.
+
+The run, queue, and epic commands checkpoint their position before every
+workflow step. If a run fails or the process dies, resume continues the
+interrupted story from the saved step instead of starting over.
+
+Before resuming, the story's current status in sprint-status.yaml is compared
+with the checkpoint. If the story was moved by hand since the run stopped,
+resume refuses to continue; use run to start again from the current status.
+
+Only one checkpoint is kept: a queue or epic run overwrites it as each story
+starts, so resume continues the story that was running when the run stopped.
+Rerun the queue or epic afterwards to process the remaining stories.
+
+Runs with --parallel greater than 1 write no checkpoints, so resume cannot
+continue them. Remove the worktrees kept for interrupted stories and rerun
+the queue or epic instead. A queue or epic with --parallel is refused while
+one of its stories has a checkpoint; resume that story first.
+
+Example:
+  bmad-automate resume`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			executor := app.newLifecycleExecutor()
+
+			if app.StateStore != nil {
+				if saved, err := app.StateStore.Load(); err == nil {
+					app.Printer.Info("Resuming story %s at step %d/%d", saved.StoryKey, saved.StepIndex+1, saved.TotalSteps)
+				}
+			}
+
+			executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+				app.Printer.StepStart(stepIndex, totalSteps, workflow)
+			})
+
+			start := time.Now()
+			result, err := executor.ResumeWithResult(ctx)
+			if err != nil {
+				cmd.SilenceUsage = true
+				if errors.Is(err, state.ErrNoState) {
+					app.Printer.Info("No interrupted lifecycle to resume")
+					return nil
+				}
+				if errors.Is(err, lifecycle.ErrInterrupted) {
+					return finishInterrupted(cmd, app, []output.StoryResult{result}, []string{result.Key}, start, true)
+				}
+				app.Printer.Error("%v", err)
+				if errors.Is(err, lifecycle.ErrStatusDrift) {
+					app.Printer.Info("Use 'bmad-automate run <story-key>' to start from the current status")
+				}
+				if len(result.Steps) > 0 {
+					app.Printer.CycleFailed(result.Key, result.FailedAt, result.Steps, result.Duration)
+				}
+				return NewExitError(1)
+			}
+
+			app.Printer.CycleSummary(result.Key, result.Steps, result.Duration)
+			return nil
+		},
+	}
+}
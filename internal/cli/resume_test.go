@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/state"
+	"bmad-automate/internal/status"
+)
+
+func TestResumeCommand(t *testing.T) {
+	tests := []struct {
+		name              string
+		saved             *state.State
+		storyStatus       string
+		expectedWorkflows []string
+		expectError       bool
+	}{
+		{
+			name:              "resumes from saved step",
+			saved:             &state.State{StoryKey: "STORY-1", StepIndex: 2, TotalSteps: 4, StartStatus: "backlog"},
+			storyStatus:       "review",
+			expectedWorkflows: []string{"code-review", "git-commit"},
+		},
+		{
+			name:              "no saved state is not an error",
+			storyStatus:       "backlog",
+			expectedWorkflows: nil,
+		},
+		{
+			name:              "drifted status returns error",
+			saved:             &state.State{StoryKey: "STORY-1", StepIndex: 2, TotalSteps: 4, StartStatus: "backlog"},
+			storyStatus:       "backlog",
+			expectedWorkflows: nil,
+			expectError:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: "+tt.storyStatus)
+
+			stateManager := state.NewManager(tmpDir)
+			if tt.saved != nil {
+				require.NoError(t, stateManager.Save(*tt.saved))
+			}
+
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+				StateStore:   stateManager,
+			}
+
+			rootCmd := NewRootCommand(app)
+			outBuf := &bytes.Buffer{}
+			rootCmd.SetOut(outBuf)
+			rootCmd.SetErr(outBuf)
+			rootCmd.SetArgs([]string{"resume"})
+
+			err := rootCmd.Execute()
+
+			if tt.expectError {
+				require.Error(t, err)
+				code, ok := IsExitError(err)
+				assert.True(t, ok, "error should be an ExitError")
+				assert.Equal(t, 1, code)
+				assert.True(t, stateManager.Exists(), "drifted state should be kept for inspection")
+			} else {
+				assert.NoError(t, err)
+				assert.False(t, stateManager.Exists(), "state should be cleared after completion")
+			}
+
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+		})
+	}
+}
+
+func TestResumeCommand_Summary(t *testing.T) {
+	tests := []struct {
+		name        string
+		runner      *MockWorkflowRunner
+		wantSummary string
+	}{
+		{
+			name:        "completed lifecycle prints the cycle summary",
+			runner:      &MockWorkflowRunner{},
+			wantSummary: "CYCLE COMPLETE",
+		},
+		{
+			name:        "failed lifecycle prints the failed cycle",
+			runner:      &MockWorkflowRunner{FailOnWorkflow: "git-commit"},
+			wantSummary: "CYCLE FAILED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: review")
+			stateManager := state.NewManager(tmpDir)
+			require.NoError(t, stateManager.Save(state.State{StoryKey: "STORY-1", StepIndex: 2, TotalSteps: 4, StartStatus: "backlog"}))
+
+			printed := &bytes.Buffer{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       tt.runner,
+				Printer:      output.NewPrinterWithWriter(printed),
+				StateStore:   stateManager,
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs([]string{"resume"})
+			_ = rootCmd.Execute()
+
+			assert.Contains(t, printed.String(), tt.wantSummary)
+			assert.Contains(t, printed.String(), "Story: STORY-1")
+			assert.Contains(t, printed.String(), "code-review")
+		})
+	}
+}
+
+func TestRunCommand_CheckpointLeftOnFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: backlog")
+	stateManager := state.NewManager(tmpDir)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "dev-story"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		StateStore:   stateManager,
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"run", "STORY-1"})
+
+	require.Error(t, rootCmd.Execute())
+
+	saved, err := stateManager.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "STORY-1", saved.StoryKey)
+	assert.Equal(t, 1, saved.StepIndex)
+	assert.Equal(t, "backlog", saved.StartStatus)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/state"
+)
+
+func newRetryCommand(app *App) *cobra.Command {
+	var continueSession bool
+
+	cmd := &cobra.Command{
+		Use:   "retry <story-key>",
+		Short: "Retry the failed step of a story",
+		Long: `Retry the failed workflow step of a story from its saved checkpoint and
+continue the lifecycle to completion.
+
+The checkpoint in .bmad-state.json must belong to the given story. As with
+resume, the story's current status is compared with the checkpoint first.
+Stories that failed in a run with --parallel greater than 1 have no checkpoint
+and cannot be retried.
+
+Every step records the ID of its Claude session in the checkpoint. With
+--continue-session, the failed step continues that session instead of starting
+a new one, so Claude keeps the context of the failed run, such as the files it
+already read and the changes it already made. The workflow's prompt is sent
+again as the next message.
+
+Example:
+  bmad-automate retry 7-1-define-schema --continue-session`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			storyKey := args[0]
+			ctx := cmd.Context()
+
+			executor := app.newLifecycleExecutor()
+
+			if app.StateStore != nil {
+				if saved, err := app.StateStore.Load(); err == nil && saved.StoryKey == storyKey {
+					app.Printer.Info("Retrying story %s at step %d/%d", saved.StoryKey, saved.StepIndex+1, saved.TotalSteps)
+				}
+			}
+
+			executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+				app.Printer.StepStart(stepIndex, totalSteps, workflow)
+			})
+
+			start := time.Now()
+			result, err := executor.RetryWithResult(ctx, storyKey, continueSession)
+			if err != nil {
+				cmd.SilenceUsage = true
+				if errors.Is(err, lifecycle.ErrInterrupted) {
+					return finishInterrupted(cmd, app, []output.StoryResult{result}, []string{storyKey}, start, true)
+				}
+				app.Printer.Error("%v", err)
+				switch {
+				case errors.Is(err, state.ErrNoState):
+					app.Printer.Info("Use 'bmad-automate run %s' to start the story", storyKey)
+				case errors.Is(err, lifecycle.ErrStatusDrift):
+					app.Printer.Info("Use 'bmad-automate run %s' to start from the current status", storyKey)
+				case errors.Is(err, lifecycle.ErrNoSession):
+					app.Printer.Info("Run without --continue-session to start a new session")
+				}
+				if len(result.Steps) > 0 {
+					app.Printer.CycleFailed(storyKey, result.FailedAt, result.Steps, result.Duration)
+				}
+				return NewExitError(1)
+			}
+
+			app.Printer.CycleSummary(storyKey, result.Steps, result.Duration)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&continueSession, "continue-session", false, "Continue the failed step's Claude session instead of starting a new one")
+
+	return cmd
+}
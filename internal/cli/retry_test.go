@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/state"
+	"bmad-automate/internal/status"
+	"bmad-automate/internal/workflow"
+)
+
+func TestRetryCommand_ContinueSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: backlog")
+
+	app, mockExecutor, printed := setupRunTestApp(tmpDir)
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, Subtype: claude.SubtypeInit, SessionStarted: true, SessionID: "session-1"},
+		{Type: claude.EventTypeResult, SessionComplete: true, SessionID: "session-1"},
+	}
+	mockExecutor.ExitCodes = []int{0, 1, 0}
+	stateManager := state.NewManager(tmpDir)
+	app.StateStore = stateManager
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"run", "STORY-1"})
+	require.Error(t, rootCmd.Execute(), "dev-story should fail")
+
+	saved, err := stateManager.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved.StepIndex)
+	assert.Equal(t, map[string]string{"create-story": "session-1", "dev-story": "session-1"}, saved.Sessions)
+
+	printed.Reset()
+	rootCmd = NewRootCommand(app)
+	rootCmd.SetArgs([]string{"retry", "STORY-1", "--continue-session"})
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, printed.String(), "CYCLE COMPLETE")
+
+	assert.Equal(t, []string{"", "", "session-1", "", ""}, mockExecutor.RecordedSessions,
+		"only the retried step should continue its session")
+	assert.False(t, stateManager.Exists(), "state should be cleared after completion")
+}
+
+func TestRetryCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		saved           *state.State
+		args            []string
+		expectStateKept bool
+	}{
+		{
+			name: "no checkpoint",
+			args: []string{"retry", "STORY-1"},
+		},
+		{
+			name:            "checkpoint for another story",
+			saved:           &state.State{StoryKey: "STORY-2", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"},
+			args:            []string{"retry", "STORY-1"},
+			expectStateKept: true,
+		},
+		{
+			name:            "no session recorded",
+			saved:           &state.State{StoryKey: "STORY-1", StepIndex: 0, TotalSteps: 4, StartStatus: "backlog"},
+			args:            []string{"retry", "STORY-1", "--continue-session"},
+			expectStateKept: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, "development_status:\n  STORY-1: backlog")
+
+			stateManager := state.NewManager(tmpDir)
+			if tt.saved != nil {
+				require.NoError(t, stateManager.Save(*tt.saved))
+			}
+
+			cfg := config.DefaultConfig()
+			printer := output.NewPrinterWithWriter(&bytes.Buffer{})
+			mockExecutor := &claude.MockExecutor{}
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       workflow.NewRunner(mockExecutor, printer, cfg),
+				Printer:      printer,
+				StateStore:   stateManager,
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			code, ok := IsExitError(err)
+			assert.True(t, ok, "error should be an ExitError")
+			assert.Equal(t, 1, code)
+			assert.Empty(t, mockExecutor.RecordedPrompts, "no workflow should run")
+			assert.Equal(t, tt.expectStateKept, stateManager.Exists())
+		})
+	}
+}
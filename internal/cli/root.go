@@ -10,12 +10,18 @@
 //   - [WorkflowRunner] - Interface for executing named workflows or raw prompts
 //   - [StatusReader] - Interface for reading story status from sprint-status.yaml
 //   - [StatusWriter] - Interface for updating story status
+//   - [StateStore] - Interface for persisting lifecycle checkpoints
 //   - [ExecuteResult] - Result type returned by testable entry points
 //
 // Commands provided:
 //   - run - Execute full story lifecycle from current status to done
 //   - queue - Run lifecycle for multiple stories sequentially
 //   - epic - Run all stories in an epic
+//   - resume - Continue an interrupted lifecycle from its checkpoint
+//   - status - Show the sprint board from sprint-status.yaml
+//   - show - Show the details of a story from its story file
+//   - set-status - Move stories to a new status
+//   - watch - Run workflows when stories change status
 //   - raw - Execute a raw prompt directly
 //   - create-story, dev-story, code-review, git-commit - Individual workflow commands
 package cli
@@ -23,13 +29,20 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"bmad-automate/internal/claude"
 	"bmad-automate/internal/config"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/lifecycle"
 	"bmad-automate/internal/output"
+	"bmad-automate/internal/router"
+	"bmad-automate/internal/runlog"
+	"bmad-automate/internal/state"
 	"bmad-automate/internal/status"
 	"bmad-automate/internal/workflow"
 )
@@ -66,6 +79,9 @@ type StatusReader interface {
 	// GetEpicStories returns all story keys belonging to the given epic ID.
 	// Story keys are sorted numerically by story number for predictable execution order.
 	GetEpicStories(epicID string) ([]string, error)
+
+	// Read returns the complete sprint status, for example to show the board.
+	Read() (*status.SprintStatus, error)
 }
 
 // StatusWriter is the interface for updating story status in sprint-status.yaml.
@@ -78,6 +94,21 @@ type StatusWriter interface {
 	UpdateStatus(storyKey string, newStatus status.Status) error
 }
 
+// StateStore is the interface for persisting lifecycle checkpoints.
+//
+// The production implementation is [state.Manager], which stores the checkpoint
+// in .bmad-state.json in the git directory of the repository.
+type StateStore interface {
+	// Save records the lifecycle step that is about to run.
+	Save(s state.State) error
+
+	// Load returns the last checkpoint, or [state.ErrNoState] if there is none.
+	Load() (state.State, error)
+
+	// Clear removes the checkpoint after a lifecycle completes.
+	Clear() error
+}
+
 // App is the main application container with dependency injection.
 //
 // All dependencies are injected via struct fields, enabling comprehensive
@@ -88,10 +119,14 @@ type StatusWriter interface {
 // Fields:
 //   - Config: Application configuration loaded from workflows.yaml and environment
 //   - Executor: Claude CLI executor for subprocess management
-//   - Printer: Terminal output formatter using Lipgloss styles
+//   - Printer: Output formatter for the selected output format
 //   - Runner: Workflow execution engine
 //   - StatusReader: Sprint status file reader
 //   - StatusWriter: Sprint status file writer
+//   - StateStore: Lifecycle checkpoint storage for resume
+//   - WorkspaceFactory: Per-story isolated workspaces for parallel runs
+//   - Router: Lifecycle state machine from the configuration
+//   - Repo: Git repository checked before and after each story
 type App struct {
 	// Config holds application configuration including workflow definitions.
 	Config *config.Config
@@ -99,7 +134,7 @@ type App struct {
 	// Executor runs Claude CLI as a subprocess and streams JSON events.
 	Executor claude.Executor
 
-	// Printer formats and displays output to the terminal.
+	// Printer formats and displays output, as styled text or as JSON events.
 	Printer output.Printer
 
 	// Runner executes named workflows or raw prompts.
@@ -110,41 +145,182 @@ type App struct {
 
 	// StatusWriter updates story status in sprint-status.yaml.
 	StatusWriter StatusWriter
+
+	// StateStore persists lifecycle checkpoints. When nil, no checkpoints are written.
+	StateStore StateStore
+
+	// WorkspaceFactory creates isolated workspaces for --parallel runs.
+	// When nil, parallel execution is not available.
+	WorkspaceFactory lifecycle.WorkspaceFactory
+
+	// Router maps statuses to lifecycle steps. When nil, the default
+	// BMAD lifecycle is used.
+	Router *router.Router
+
+	// Repo is the git repository the stories are committed to. When nil,
+	// the git safety checks of Config.Git are not run.
+	Repo *git.Repo
+
+	// workspaces creates the WorkspaceFactory of a run from its budget and
+	// run log, see [App.startRun]. Nil keeps WorkspaceFactory as it is.
+	workspaces func(budget *workflow.Budget, runLog *runlog.Log) lifecycle.WorkspaceFactory
 }
 
 // NewApp creates a new [App] with all production dependencies wired up.
 //
-// This constructor initializes:
-//   - A [claude.Executor] configured from cfg.Claude settings
-//   - A [workflow.Runner] for workflow execution
-//   - A [status.Reader] and [status.Writer] for sprint status management
-//   - An [output.Printer] for terminal output
+// This constructor initializes, for the project in cfg.Project.Dir, which
+// defaults to the BMAD project containing the working directory:
+//   - A [claude.Executor] configured from cfg.Claude settings, which runs
+//     Claude in the project directory
+//   - A [workflow.Runner] for workflow execution, with a run [workflow.Budget]
+//     from cfg.Budget and a [runlog.Log] in cfg.Output.RunLogDir that parallel
+//     workspaces share
+//   - A [status.Reader] and [status.Writer] for the sprint status file
+//     configured in cfg.Project or discovered from the project directory
+//   - A [git.Repo] for the project directory, checked around each story as
+//     configured in cfg.Git
+//   - A [state.Manager] for lifecycle checkpoints in the repository's git
+//     directory, so they are never committed
+//   - A git worktree based [lifecycle.WorkspaceFactory] for parallel runs
+//   - A [router.Router] built from cfg.Lifecycle
+//   - An [output.Printer] for cfg.Output.Format, which also prints Claude's
+//     stderr
 //
 // For testing, construct [App] directly with mock dependencies instead.
 func NewApp(cfg *config.Config) *App {
-	printer := output.NewPrinter()
+	printer := output.Format(cfg.Output.Format).NewPrinter(os.Stdout)
+	app := &App{Config: cfg, Printer: printer}
+	cfg.Project.Dir = defaultProjectDir(cfg.Project)
+	root := projectRoot(cfg.Project)
 
 	executor := claude.NewExecutor(claude.ExecutorConfig{
-		BinaryPath:   cfg.Claude.BinaryPath,
-		OutputFormat: cfg.Claude.OutputFormat,
-		StderrHandler: func(line string) {
-			// Print stderr to stderr
-			os.Stderr.WriteString("[stderr] " + line + "\n")
-		},
+		BinaryPath:    cfg.Claude.BinaryPath,
+		OutputFormat:  cfg.Claude.OutputFormat,
+		GracePeriod:   cfg.Claude.GracePeriod,
+		WorkDir:       root,
+		StderrHandler: app.printStderr,
 	})
 
 	runner := workflow.NewRunner(executor, printer, cfg)
+	runner.SetWorkDir(root)
+	lifecycleRouter := router.FromConfig(cfg.Lifecycle)
 	statusReader := status.NewReader("")
 	statusWriter := status.NewWriter("")
+	if path, err := statusPath(cfg.Project); err != nil {
+		printer.Warning("%v; using %s", err, statusReader.Path())
+	} else {
+		statusReader.SetPath(path)
+		statusWriter.SetPath(path)
+	}
+	statusWriter.SetValidStatuses(lifecycleRouter.Statuses())
+	statusWriter.SetTerminalStatuses(lifecycleRouter.TerminalStatuses())
+	repo := git.NewRepo(root)
+	stateManager := state.NewManager(stateDir(repo))
 
-	return &App{
-		Config:       cfg,
-		Executor:     executor,
-		Printer:      printer,
-		Runner:       runner,
-		StatusReader: statusReader,
-		StatusWriter: statusWriter,
+	app.Executor = executor
+	app.Runner = runner
+	app.StatusReader = statusReader
+	app.StatusWriter = statusWriter
+	app.StateStore = stateManager
+	app.Router = lifecycleRouter
+	app.Repo = repo
+	app.workspaces = func(budget *workflow.Budget, runLog *runlog.Log) lifecycle.WorkspaceFactory {
+		return newWorktreeWorkspaceFactory(cfg, app.Repo, budget, runLog, app.storyDir)
 	}
+	app.startRun(runlog.NewRunID(time.Now()))
+	return app
+}
+
+// newRun returns a new run budget from a.Config.Budget, whose clock starts
+// now, and a run log with the given ID in the project's run log directory, or
+// nil if run logs are disabled.
+func (a *App) newRun(runID string) (*workflow.Budget, *runlog.Log) {
+	budget := workflow.NewBudget("run", a.Config.Budget)
+	var runLog *runlog.Log
+	if dir := a.projectPath(a.Config.Output.RunLogDir); dir != "" {
+		runLog = runlog.New(dir, runID, a.Config.Hash())
+	}
+	return budget, runLog
+}
+
+// startRun gives the app's runner, and the workspaces of parallel runs, the
+// budget and run log of a new run with the given ID, see [App.newRun].
+// Runners that cannot be re-pointed are kept.
+func (a *App) startRun(runID string) {
+	budget, runLog := a.newRun(runID)
+	if runner, ok := a.Runner.(interface {
+		SetBudget(*workflow.Budget)
+		SetRunLog(*runlog.Log)
+	}); ok {
+		runner.SetBudget(budget)
+		runner.SetRunLog(runLog)
+	}
+	if a.workspaces != nil {
+		a.WorkspaceFactory = a.workspaces(budget, runLog)
+	}
+}
+
+// runWorkspaces returns a WorkspaceFactory whose workspaces share the budget
+// and run log of a new run with the given ID, without changing the app. Apps
+// that cannot create one return their WorkspaceFactory.
+func (a *App) runWorkspaces(runID string) lifecycle.WorkspaceFactory {
+	if a.workspaces == nil {
+		return a.WorkspaceFactory
+	}
+	return a.workspaces(a.newRun(runID))
+}
+
+// printStderr prints a line Claude wrote to stderr with the app's current
+// printer, so it follows the format chosen by --output.
+func (a *App) printStderr(line string) {
+	a.Printer.Stderr(line)
+}
+
+// newLifecycleExecutor creates a [lifecycle.Executor] wired to the app's dependencies.
+func (a *App) newLifecycleExecutor() *lifecycle.Executor {
+	executor := lifecycle.NewExecutor(a.Runner, a.StatusReader, a.StatusWriter)
+	if a.StateStore != nil {
+		executor.SetStateStore(a.StateStore)
+	}
+	if a.Router != nil {
+		executor.SetRouter(a.Router)
+	}
+	executor.SetFindingsCounter(lifecycle.ReviewFindings{StoryDir: a.storyDir()})
+	executor.SetTaskChecker(lifecycle.StoryTasks{StoryDir: a.storyDir()})
+	if guard := a.repoGuard(); guard != nil {
+		executor.SetRepoGuard(guard)
+	}
+	return executor
+}
+
+// lifecycleRouter returns the app's router, or the default BMAD lifecycle
+// if none is set.
+func (a *App) lifecycleRouter() *router.Router {
+	if a.Router != nil {
+		return a.Router
+	}
+	return router.Default()
+}
+
+// setOutputFormat switches the app's printer to the named output format,
+// writing to w. The printer is kept if the format does not change, so a
+// printer injected by tests stays in place.
+func (a *App) setOutputFormat(name string, w io.Writer) error {
+	format, err := output.ParseFormat(name)
+	if err != nil {
+		return err
+	}
+	current, err := output.ParseFormat(a.Config.Output.Format)
+	if err == nil && format == current {
+		return nil
+	}
+
+	a.Config.Output.Format = string(format)
+	a.Printer = format.NewPrinter(w)
+	if runner, ok := a.Runner.(interface{ SetPrinter(output.Printer) }); ok {
+		runner.SetPrinter(a.Printer)
+	}
+	return nil
 }
 
 // NewRootCommand creates the root Cobra command with all subcommands attached.
@@ -153,21 +329,67 @@ func NewApp(cfg *config.Config) *App {
 //   - run: Execute full story lifecycle from current status to done
 //   - queue: Run lifecycle for multiple stories sequentially
 //   - epic: Run all stories in an epic
+//   - resume: Continue an interrupted lifecycle from its checkpoint
+//   - status: Show the sprint board from sprint-status.yaml
+//   - set-status: Move stories to a new status
+//   - watch: Run workflows when stories change status
 //   - raw: Execute a raw prompt directly
 //   - create-story: Create a new story from backlog status
 //   - dev-story: Develop a story (ready-for-dev or in-progress status)
 //   - code-review: Review code (review status)
 //   - git-commit: Commit changes after review
+//
+// The persistent --output flag selects the output format of every command,
+// and --project-dir the project whose sprint status file is used.
+// --allow-dirty and --allow-default-branch relax the git safety checks.
 func NewRootCommand(app *App) *cobra.Command {
+	var outputFormat, projectDir string
+	var allowDirty, allowDefaultBranch bool
+
 	rootCmd := &cobra.Command{
 		Use:   "bmad-automate",
 		Short: "BMAD Automation CLI",
 		Long: `BMAD Automation CLI - Automate development workflows with Claude.
 
 This tool orchestrates Claude to run development workflows including
-story creation, development, code review, and git operations.`,
+story creation, development, code review, and git operations.
+
+Use --output json to print one JSON event per line instead of styled text,
+for example in CI pipelines.
+
+The sprint status file is found by walking up from the working directory, or
+from --project-dir, to the nearest BMAD project. Without --project-dir or
+project.dir, the commands run in the root of that project.
+
+Stories only start on a clean working tree that does not have the default
+branch checked out; --allow-dirty and --allow-default-branch lift these
+checks.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			name := app.Config.Output.Format
+			if cmd.Flags().Changed("output") {
+				name = outputFormat
+			}
+			if err := app.setOutputFormat(name, cmd.OutOrStdout()); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("allow-dirty") {
+				app.Config.Git.AllowDirty = allowDirty
+			}
+			if cmd.Flags().Changed("allow-default-branch") {
+				app.Config.Git.AllowDefaultBranch = allowDefaultBranch
+			}
+			if cmd.Flags().Changed("project-dir") {
+				return app.setProjectDir(projectDir)
+			}
+			return nil
+		},
 	}
 
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", `Output format: "text" or "json" (overrides output.format)`)
+	rootCmd.PersistentFlags().StringVar(&projectDir, "project-dir", "", "Directory of the BMAD project to use (overrides project.dir)")
+	rootCmd.PersistentFlags().BoolVar(&allowDirty, "allow-dirty", false, "Start stories with uncommitted changes (overrides git.allow_dirty)")
+	rootCmd.PersistentFlags().BoolVar(&allowDefaultBranch, "allow-default-branch", false, "Run stories on the default branch (overrides git.allow_default_branch)")
+
 	// Add subcommands
 	rootCmd.AddCommand(
 		newCreateStoryCommand(app),
@@ -177,6 +399,13 @@ story creation, development, code review, and git operations.`,
 		newRunCommand(app),
 		newQueueCommand(app),
 		newEpicCommand(app),
+		newResumeCommand(app),
+		newRetryCommand(app),
+		newReplayCommand(app),
+		newStatusCommand(app),
+		newShowCommand(app),
+		newSetStatusCommand(app),
+		newWatchCommand(app),
 		newRawCommand(app),
 	)
 
@@ -202,15 +431,22 @@ type ExecuteResult struct {
 // so tests can provide custom configurations. It creates an [App] via [NewApp],
 // builds the command tree via [NewRootCommand], and executes the command.
 //
+// Commands run with Ctrl-C handling: the first SIGINT stops after the current
+// workflow step, the second stops Claude immediately.
+//
 // Exit codes:
 //   - 0: Success
 //   - 1: Config or command error
+//   - 130: Interrupted with Ctrl-C ([ExitCodeInterrupted])
 //   - Non-zero from subprocess: Passed through from Claude CLI
 func RunWithConfig(cfg *config.Config) ExecuteResult {
 	app := NewApp(cfg)
 	rootCmd := NewRootCommand(app)
 
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := handleInterrupts(context.Background(), func() output.Printer { return app.Printer })
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		// Check if it's an ExitError from a command
 		if code, ok := IsExitError(err); ok {
 			return ExecuteResult{ExitCode: code, Err: err}
@@ -232,6 +468,7 @@ func RunWithConfig(cfg *config.Config) ExecuteResult {
 func Run() ExecuteResult {
 	cfg, err := config.NewLoader().Load()
 	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		return ExecuteResult{
 			ExitCode: 1,
 			Err:      fmt.Errorf("error loading config: %w", err),
@@ -2,16 +2,19 @@ package cli
 
 import (
 	"errors"
-	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/report"
 	"bmad-automate/internal/router"
 )
 
 func newRunCommand(app *App) *cobra.Command {
 	var dryRun bool
+	var reports reportFlag
 
 	cmd := &cobra.Command{
 		Use:   "run <story-key>",
@@ -26,15 +29,23 @@ The command executes all remaining workflows based on the story's current status
   - done          → no action (story already complete)
 
 Status is updated in sprint-status.yaml after each successful workflow.
+Failed workflows are retried according to their retry settings in the config,
+and every attempt is listed in the summary.
 
-Use --dry-run to preview workflows without executing them.`,
+Press Ctrl-C once to stop after the current workflow finishes, or twice to stop
+Claude immediately. Either way the checkpoint is kept for resume.
+
+Use --dry-run to preview workflows without executing them.
+
+Use --report junit=report.xml to write a JUnit XML report of the run for CI,
+or --report markdown=run.md (or html=run.html) for a report of what Claude did.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			storyKey := args[0]
 			ctx := cmd.Context()
 
 			// Create lifecycle executor with app dependencies
-			executor := lifecycle.NewExecutor(app.Runner, app.StatusReader, app.StatusWriter)
+			executor := app.newLifecycleExecutor()
 
 			// Handle dry-run mode
 			if dryRun {
@@ -42,17 +53,16 @@ Use --dry-run to preview workflows without executing them.`,
 				if err != nil {
 					cmd.SilenceUsage = true
 					if errors.Is(err, router.ErrStoryComplete) {
-						fmt.Printf("Story is already complete, no workflows to run\n")
+						app.Printer.Info("Story is already complete, no workflows to run")
 						return nil
 					}
-					fmt.Printf("Error: %v\n", err)
+					app.Printer.Error("%v", err)
 					return NewExitError(1)
 				}
 
-				fmt.Printf("Dry run for story %s:\n", storyKey)
-				for i, step := range steps {
-					fmt.Printf("  %d. %s → %s\n", i+1, step.Workflow, step.NextStatus)
-				}
+				app.Printer.Info("Dry run for story %s:", storyKey)
+				app.Printer.Newline()
+				app.Printer.Plan(storyKey, planSteps(steps))
 				return nil
 			}
 
@@ -62,22 +72,49 @@ Use --dry-run to preview workflows without executing them.`,
 			})
 
 			// Execute the full lifecycle
-			err := executor.Execute(ctx, storyKey)
+			start := time.Now()
+			result, err := executor.ExecuteWithResult(ctx, storyKey)
+			run := report.Run{
+				Name:     "run " + storyKey,
+				Stories:  []string{storyKey},
+				Results:  []output.StoryResult{result},
+				Started:  start,
+				Duration: time.Since(start),
+			}
 			if err != nil {
 				cmd.SilenceUsage = true
 				if errors.Is(err, router.ErrStoryComplete) {
-					fmt.Printf("Story %s is already complete, no action needed\n", storyKey)
-					return nil
+					app.Printer.Info("Story %s is already complete, no action needed", storyKey)
+					return writeReports(app, reports, run, nil)
 				}
-				fmt.Printf("Error: %v\n", err)
-				return NewExitError(1)
+				if errors.Is(err, lifecycle.ErrInterrupted) {
+					return writeReports(app, reports, run, finishInterrupted(cmd, app, run.Results, run.Stories, start, true))
+				}
+				app.Printer.Error("%v", err)
+				app.Printer.CycleFailed(storyKey, result.FailedAt, result.Steps, result.Duration)
+				return writeReports(app, reports, run, NewExitError(1))
 			}
 
-			return nil
+			app.Printer.CycleSummary(storyKey, result.Steps, result.Duration)
+			return writeReports(app, reports, run, nil)
 		},
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview workflows without executing them")
+	addReportFlag(cmd, &reports)
 
 	return cmd
 }
+
+// planSteps converts lifecycle steps for [output.Printer.Plan].
+func planSteps(steps []router.LifecycleStep) []output.PlanStep {
+	plan := make([]output.PlanStep, len(steps))
+	for i, step := range steps {
+		plan[i] = output.PlanStep{Workflow: step.Workflow, NextStatus: string(step.NextStatus)}
+		if step.Fix != "" {
+			plan[i].Fix = step.Fix
+			plan[i].MaxIterations = step.Iterations()
+		}
+	}
+	return plan
+}
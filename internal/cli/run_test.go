@@ -14,6 +14,7 @@ import (
 	"bmad-automate/internal/claude"
 	"bmad-automate/internal/config"
 	"bmad-automate/internal/output"
+	"bmad-automate/internal/router"
 	"bmad-automate/internal/status"
 	"bmad-automate/internal/workflow"
 )
@@ -58,6 +59,8 @@ func (m *MockStatusWriter) UpdateStatus(storyKey string, newStatus status.Status
 
 func setupRunTestApp(tmpDir string) (*App, *claude.MockExecutor, *bytes.Buffer) {
 	cfg := config.DefaultConfig()
+	cfg.Output.ReportDir = filepath.Join(tmpDir, "_bmad-output", "reports")
+	cfg.Output.RunLogDir = filepath.Join(tmpDir, "_bmad-output", "runs")
 	buf := &bytes.Buffer{}
 	printer := output.NewPrinterWithWriter(buf)
 	mockExecutor := &claude.MockExecutor{
@@ -286,3 +289,85 @@ func TestRunCommand_LifecycleStoryNotFound(t *testing.T) {
 	// No workflows should have been executed
 	assert.Empty(t, mockRunner.ExecutedWorkflows)
 }
+
+func TestRunCommand_VerificationFailureKeepsStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: ready-for-dev\n")
+
+	app, mockExecutor, buf := setupRunTestApp(tmpDir)
+	wf := app.Config.Workflows["dev-story"]
+	wf.Verify = config.VerifyConfig{Commands: []string{"exit 1"}}
+	app.Config.Workflows["dev-story"] = wf
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"run", "7-1-schema"})
+
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	assert.Len(t, mockExecutor.RecordedPrompts, 1, "no workflow runs after the failed verification")
+	assert.Contains(t, buf.String(), "verification failed: exit 1 exited with 1")
+
+	sprint, err := app.StatusReader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReadyForDev, sprint.DevelopmentStatus["7-1-schema"])
+}
+
+func TestRunCommand_ReviewLoopFindingsRemain(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: review\n")
+	storyFile := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts", "7-1-schema.md")
+	require.NoError(t, os.WriteFile(storyFile, []byte("### Review Follow-ups (AI)\n\n- [ ] [AI-Review][High] Validate input\n"), 0644))
+
+	app, mockExecutor, buf := setupRunTestApp(tmpDir)
+	app.Config.Lifecycle.Transitions["review"] = []config.TransitionConfig{
+		{Workflow: "code-review", Next: "done", Fix: "dev-story", MaxIterations: 2},
+		{Workflow: "git-commit", Next: "done"},
+	}
+	app.Router = router.FromConfig(app.Config.Lifecycle)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"run", "7-1-schema"})
+
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	require.Len(t, mockExecutor.RecordedPrompts, 3)
+	assert.Contains(t, mockExecutor.RecordedPrompts[0], "code-review")
+	assert.Contains(t, mockExecutor.RecordedPrompts[1], "dev-story")
+	assert.Contains(t, mockExecutor.RecordedPrompts[2], "code-review")
+	assert.Contains(t, buf.String(), "1 finding still open after 2 reviews")
+
+	sprint, err := app.StatusReader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReview, sprint.DevelopmentStatus["7-1-schema"])
+}
+
+func TestRunCommand_IncompleteTasksKeepStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: ready-for-dev\n")
+	storyFile := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts", "7-1-schema.md")
+	require.NoError(t, os.WriteFile(storyFile, []byte("## Tasks / Subtasks\n\n- [x] Create the schema\n- [ ] Document the schema\n"), 0644))
+
+	app, mockExecutor, buf := setupRunTestApp(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"run", "7-1-schema"})
+
+	err := rootCmd.Execute()
+
+	require.Error(t, err)
+	require.Len(t, mockExecutor.RecordedPrompts, 1)
+	assert.Contains(t, buf.String(), "1 task not done in the story file")
+	assert.Contains(t, buf.String(), "[ ] Document the schema")
+
+	sprint, err := app.StatusReader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReadyForDev, sprint.DevelopmentStatus["7-1-schema"])
+}
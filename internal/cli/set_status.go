@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/status"
+)
+
+func newSetStatusCommand(app *App) *cobra.Command {
+	var (
+		epicID string
+		force  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set-status <story-key|pattern>... <status>",
+		Short: "Move stories to a new status",
+		Long: `Set the status of one or more stories in sprint-status.yaml.
+
+Only the status values change; comments and formatting in the file are kept.
+
+Stories are given by key, by glob pattern such as '7-*' (quote patterns so the
+shell does not expand them), or with --epic for every story of an epic.
+
+A story may only move to a status that one of its current status's transitions
+sets, following the lifecycle, e.g. backlog → ready-for-dev. If any story
+cannot move, nothing is changed. Use --force to set any known status.
+
+Examples:
+  bmad-automate set-status 7-1-define-schema review
+  bmad-automate set-status '7-*' ready-for-dev
+  bmad-automate set-status --epic 7 --force backlog`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if epicID != "" {
+				if len(args) != 1 {
+					return errors.New("with --epic, only the status is given")
+				}
+				return nil
+			}
+			return cobra.MinimumNArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			lifecycle := app.lifecycleRouter()
+			target := status.Status(args[len(args)-1])
+			if !lifecycle.IsValid(target) {
+				app.Printer.Error("unknown status %q (valid statuses: %s)", target, joinStatuses(lifecycle.Statuses()))
+				return NewExitError(1)
+			}
+
+			sprint, err := app.StatusReader.Read()
+			if err != nil {
+				app.Printer.Error("%v", err)
+				return NewExitError(1)
+			}
+
+			var storyKeys []string
+			if epicID != "" {
+				storyKeys, err = app.StatusReader.GetEpicStories(epicID)
+			} else {
+				storyKeys, err = matchStories(sprint, args[:len(args)-1])
+			}
+			if err != nil {
+				app.Printer.Error("%v", err)
+				return NewExitError(1)
+			}
+
+			// Check every story first, so an illegal move changes nothing.
+			illegal := false
+			for _, key := range storyKeys {
+				current := sprint.DevelopmentStatus[key]
+				if force || lifecycle.CanTransition(current, target) {
+					continue
+				}
+				illegal = true
+				allowed := "none"
+				if next := lifecycle.NextStatuses(current); len(next) > 0 {
+					allowed = joinStatuses(next)
+				}
+				app.Printer.Error("cannot move %s from %s to %s (allowed: %s)", key, current, target, allowed)
+			}
+			if illegal {
+				app.Printer.Info("No status was changed. Use --force to override the lifecycle.")
+				return NewExitError(1)
+			}
+
+			for _, key := range storyKeys {
+				current := sprint.DevelopmentStatus[key]
+				if current == target {
+					app.Printer.Info("%s is already %s", key, target)
+					continue
+				}
+				if err := app.StatusWriter.UpdateStatus(key, target); err != nil {
+					app.Printer.Error("%v", err)
+					return NewExitError(1)
+				}
+				app.Printer.Info("%s: %s → %s", key, current, target)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&epicID, "epic", "", "Move every story of this epic")
+	cmd.Flags().BoolVar(&force, "force", false, "Allow moves that the lifecycle does not allow")
+
+	return cmd
+}
+
+// matchStories returns the keys of the stories that match any of patterns,
+// which are story keys or glob patterns as understood by path.Match, in board
+// order. Every pattern must match at least one story; epic and retrospective
+// entries are never matched.
+func matchStories(sprint *status.SprintStatus, patterns []string) ([]string, error) {
+	var all []string
+	for _, epic := range sprint.Epics() {
+		for _, story := range epic.Stories {
+			all = append(all, story.Key)
+		}
+	}
+
+	selected := make(map[string]bool)
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, `*?[\`) {
+			if _, ok := sprint.DevelopmentStatus[pattern]; !ok {
+				return nil, fmt.Errorf("story not found: %s", pattern)
+			}
+			if !status.IsStoryKey(pattern) {
+				return nil, fmt.Errorf("%s is an epic or retrospective entry, not a story", pattern)
+			}
+			selected[pattern] = true
+			continue
+		}
+
+		matched := false
+		for _, key := range all {
+			ok, err := path.Match(pattern, key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			if ok {
+				selected[key] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no stories match %q", pattern)
+		}
+	}
+
+	var keys []string
+	for _, key := range all {
+		if selected[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
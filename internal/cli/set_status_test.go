@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/status"
+)
+
+const setStatusYAML = `# Sprint 7
+development_status:
+  epic-7: in-progress
+  7-1-schema: backlog # first story
+  7-2-api: backlog
+  7-3-ui: review
+  8-1-search: ready-for-dev
+`
+
+func TestSetStatusCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantStatuses map[string]status.Status
+		contains     []string
+	}{
+		{
+			name:         "legal move",
+			args:         []string{"set-status", "7-1-schema", "ready-for-dev"},
+			wantStatuses: map[string]status.Status{"7-1-schema": "ready-for-dev", "7-2-api": "backlog"},
+			contains:     []string{"7-1-schema: backlog → ready-for-dev"},
+		},
+		{
+			name:         "glob pattern",
+			args:         []string{"set-status", "7-[12]-*", "ready-for-dev"},
+			wantStatuses: map[string]status.Status{"7-1-schema": "ready-for-dev", "7-2-api": "ready-for-dev", "7-3-ui": "review"},
+		},
+		{
+			name:         "epic with force",
+			args:         []string{"set-status", "--epic", "7", "--force", "done"},
+			wantStatuses: map[string]status.Status{"7-1-schema": "done", "7-2-api": "done", "7-3-ui": "done", "8-1-search": "ready-for-dev"},
+		},
+		{
+			name:         "same status is a no-op",
+			args:         []string{"set-status", "7-3-ui", "review"},
+			wantStatuses: map[string]status.Status{"7-3-ui": "review"},
+			contains:     []string{"7-3-ui is already review"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, setStatusYAML)
+			app, _, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+			require.NoError(t, rootCmd.Execute())
+
+			reader := status.NewReader(tmpDir)
+			for key, want := range tt.wantStatuses {
+				got, err := reader.GetStoryStatus(key)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, key)
+			}
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+
+			data, err := os.ReadFile(filepath.Join(tmpDir, status.DefaultStatusPath))
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "# Sprint 7", "comments should be preserved")
+			assert.Contains(t, string(data), "# first story", "comments should be preserved")
+		})
+	}
+}
+
+func TestSetStatusCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		contains []string
+	}{
+		{
+			name: "illegal move changes nothing",
+			args: []string{"set-status", "7-*", "review"},
+			contains: []string{
+				"cannot move 7-1-schema from backlog to review (allowed: ready-for-dev)",
+				"cannot move 7-2-api from backlog to review (allowed: ready-for-dev)",
+				"No status was changed. Use --force",
+			},
+		},
+		{
+			name:     "unknown status",
+			args:     []string{"set-status", "7-1-schema", "blocked"},
+			contains: []string{`unknown status "blocked"`},
+		},
+		{
+			name:     "unknown story",
+			args:     []string{"set-status", "7-9-missing", "ready-for-dev"},
+			contains: []string{"story not found: 7-9-missing"},
+		},
+		{
+			name:     "epic entry",
+			args:     []string{"set-status", "epic-7", "done"},
+			contains: []string{"epic-7 is an epic or retrospective entry, not a story"},
+		},
+		{
+			name:     "pattern without matches",
+			args:     []string{"set-status", "9-*", "ready-for-dev"},
+			contains: []string{`no stories match "9-*"`},
+		},
+		{
+			name:     "unknown epic",
+			args:     []string{"set-status", "--epic", "9", "ready-for-dev"},
+			contains: []string{"no stories found for epic: 9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, setStatusYAML)
+			app, _, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			code, ok := IsExitError(err)
+			require.True(t, ok)
+			assert.Equal(t, 1, code)
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+
+			data, readErr := os.ReadFile(filepath.Join(tmpDir, status.DefaultStatusPath))
+			require.NoError(t, readErr)
+			assert.Equal(t, setStatusYAML, string(data), "the status file should not change")
+		})
+	}
+}
+
+func TestSetStatusCommand_UpdatesEpic(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  epic-7: backlog
+  7-1-schema: backlog
+  7-2-api: backlog
+  epic-7-retrospective: optional
+`)
+	app, _, _ := setupRunTestApp(tmpDir)
+	reader := status.NewReader(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"set-status", "7-1-schema", "ready-for-dev"})
+	require.NoError(t, rootCmd.Execute())
+
+	sprint, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, status.EpicInProgress, sprint.DevelopmentStatus["epic-7"], "the first started story should start the epic")
+
+	rootCmd = NewRootCommand(app)
+	rootCmd.SetArgs([]string{"set-status", "--epic", "7", "--force", "done"})
+	require.NoError(t, rootCmd.Execute())
+
+	sprint, err = reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, status.EpicDone, sprint.DevelopmentStatus["epic-7"], "the last completed story should complete the epic")
+	assert.Equal(t, status.RetrospectiveOptional, sprint.DevelopmentStatus["epic-7-retrospective"])
+}
+
+func TestSetStatusCommand_Args(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"status only", []string{"set-status", "review"}},
+		{"epic with story", []string{"set-status", "--epic", "7", "7-1-schema", "review"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp()
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			_, isExit := IsExitError(err)
+			assert.False(t, isExit, "argument errors should be usage errors")
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/status"
+	"bmad-automate/internal/story"
+)
+
+func newShowCommand(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show <story-key>",
+		Short: "Show the details of a story from its story file",
+		Long: `Show a story's title, status, task progress, acceptance criteria, open
+tasks, changed files and change log.
+
+The details are read from the story file that create-story writes next to
+sprint-status.yaml, <story-key>.md. The status in sprint-status.yaml is shown
+next to the status in the story file, so a story whose file disagrees with the
+sprint board stands out.
+
+Use --output json to print the details as a single JSON event.
+
+Examples:
+  bmad-automate show 7-1-define-schema
+  bmad-automate show 7-1-define-schema --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			storyKey := args[0]
+
+			sprintStatus, err := app.StatusReader.GetStoryStatus(storyKey)
+			if err != nil {
+				app.Printer.Error("%v", err)
+				return NewExitError(1)
+			}
+
+			dir := app.storyDir()
+			if dir == "" {
+				app.Printer.Error("show needs the path of the sprint status file")
+				return NewExitError(1)
+			}
+			path := story.Path(dir, storyKey)
+			s, err := story.Load(path)
+			if errors.Is(err, os.ErrNotExist) {
+				app.Printer.Error("no story file for %s at %s; run create-story first", storyKey, path)
+				return NewExitError(1)
+			}
+			if err != nil {
+				app.Printer.Error("%v", err)
+				return NewExitError(1)
+			}
+
+			app.Printer.Story(storyDetails(storyKey, path, sprintStatus, s))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// storyDetails combines a parsed story file with the story's sprint status
+// for display.
+func storyDetails(storyKey, path string, sprintStatus status.Status, s *story.Story) output.StoryDetails {
+	details := output.StoryDetails{
+		Key:                storyKey,
+		Title:              s.Title,
+		Path:               path,
+		SprintStatus:       string(sprintStatus),
+		FileStatus:         string(s.Status),
+		AcceptanceCriteria: s.AcceptanceCriteria,
+		Files:              s.DevAgentRecord.Files,
+		ChangeLog:          s.ChangeLog,
+	}
+	details.TasksDone, details.TasksTotal = s.Progress()
+	for _, task := range s.Incomplete() {
+		details.OpenTasks = append(details.OpenTasks, task.Text)
+	}
+	return details
+}
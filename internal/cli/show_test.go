@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+const showStory = `# Story 7.2: Build the API
+
+Status: in-progress
+
+## Acceptance Criteria
+
+1. Requests are validated
+2. Errors are returned as JSON
+
+## Tasks / Subtasks
+
+- [x] Add the handlers
+  - [x] List
+  - [ ] Create
+- [ ] Document the API
+
+## Dev Agent Record
+
+### File List
+
+- internal/api/handlers.go
+
+## Change Log
+
+| Date       | Version | Description   |
+| ---------- | ------- | ------------- |
+| 2026-01-10 | 1.0     | Initial draft |
+`
+
+// writeStoryFile writes a story file next to the sprint status file.
+func writeStoryFile(t *testing.T, dir, storyKey, content string) {
+	t.Helper()
+	path := filepath.Join(dir, "_bmad-output", "implementation-artifacts", storyKey+".md")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestShowCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, boardYAML)
+	writeStoryFile(t, tmpDir, "7-2-api", showStory)
+	app, _, buf := setupRunTestApp(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"show", "7-2-api"})
+	require.NoError(t, rootCmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "Story 7.2: Build the API")
+	assert.Contains(t, out, "7-2-api.md")
+	assert.Contains(t, out, "sprint review · story file in-progress")
+	assert.Contains(t, out, "2/4 done (50%)")
+	assert.Contains(t, out, "1. Requests are validated")
+	assert.Contains(t, out, "[ ] Create")
+	assert.Contains(t, out, "[ ] Document the API")
+	assert.Contains(t, out, "- internal/api/handlers.go")
+	assert.Contains(t, out, "- 2026-01-10 | 1.0 | Initial draft")
+}
+
+func TestShowCommand_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, boardYAML)
+	writeStoryFile(t, tmpDir, "7-2-api", showStory)
+	app, _, _ := setupRunTestApp(tmpDir)
+	var buf bytes.Buffer
+	app.Printer = output.NewJSONPrinterWithWriter(&buf)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"show", "7-2-api"})
+	require.NoError(t, rootCmd.Execute())
+
+	var event output.JSONEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+	assert.Equal(t, output.EventStory, event.Type)
+	assert.Equal(t, "7-2-api", event.Story)
+	require.NotNil(t, event.Details)
+	assert.Equal(t, "review", event.Details.SprintStatus)
+	assert.Equal(t, "in-progress", event.Details.FileStatus)
+	assert.Equal(t, 2, event.Details.TasksDone)
+	assert.Equal(t, 4, event.Details.TasksTotal)
+	assert.Equal(t, []string{"Create", "Document the API"}, event.Details.OpenTasks)
+}
+
+func TestShowCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "unknown story", args: []string{"show", "9-9-nothing"}, want: "story not found: 9-9-nothing"},
+		{name: "no story file", args: []string{"show", "7-3-ui"}, want: "no story file for 7-3-ui"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, boardYAML)
+			app, _, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+
+			require.Error(t, err)
+			code, ok := IsExitError(err)
+			require.True(t, ok)
+			assert.Equal(t, 1, code)
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/router"
+	"bmad-automate/internal/status"
+)
+
+func newStatusCommand(app *App) *cobra.Command {
+	var (
+		epicID   string
+		statuses []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of every epic and story",
+		Long: `Show the sprint board from sprint-status.yaml.
+
+Stories are grouped by epic and colored by status. Each epic shows a progress
+bar of its done stories, and the number of stories per status is shown last.
+
+Use --epic to show a single epic and --status to show only the stories with
+the given statuses. Progress bars always cover the whole epic.
+
+Use --output json to print the board as a single JSON event.
+
+Examples:
+  bmad-automate status
+  bmad-automate status --epic 7
+  bmad-automate status --status review,in-progress`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			lifecycle := app.lifecycleRouter()
+			for _, s := range statuses {
+				if !lifecycle.IsValid(status.Status(s)) {
+					app.Printer.Error("unknown status %q (valid statuses: %s)", s, joinStatuses(lifecycle.Statuses()))
+					return NewExitError(1)
+				}
+			}
+
+			sprint, err := app.StatusReader.Read()
+			if err != nil {
+				app.Printer.Error("%v", err)
+				return NewExitError(1)
+			}
+
+			board := buildBoard(sprint, lifecycle, epicID, statuses)
+			if epicID != "" && len(board.Epics) == 0 && len(statuses) == 0 {
+				app.Printer.Error("no stories found for epic: %s", epicID)
+				return NewExitError(1)
+			}
+
+			app.Printer.Board(board)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&epicID, "epic", "", "Only show the stories of this epic")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only show stories with these statuses (repeatable)")
+
+	return cmd
+}
+
+// buildBoard groups the stories of sprint by epic for display. Only the given
+// epic is included if epicID is set, and only stories with one of statuses if
+// any are given; epics left without stories by the status filter are dropped.
+func buildBoard(sprint *status.SprintStatus, lifecycle *router.Router, epicID string, statuses []string) output.Board {
+	board := output.Board{}
+	for _, s := range lifecycle.Statuses() {
+		board.Statuses = append(board.Statuses, string(s))
+	}
+
+	show := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		show[s] = true
+	}
+
+	for _, epic := range sprint.Epics() {
+		if epicID != "" && epic.ID != epicID {
+			continue
+		}
+
+		boardEpic := output.BoardEpic{
+			ID:            epic.ID,
+			Status:        string(epic.Status),
+			Retrospective: string(epic.Retrospective),
+			Total:         len(epic.Stories),
+		}
+		for _, story := range epic.Stories {
+			done := lifecycle.IsTerminal(story.Status)
+			if done {
+				boardEpic.Done++
+			}
+			if len(show) > 0 && !show[string(story.Status)] {
+				continue
+			}
+			boardEpic.Stories = append(boardEpic.Stories, output.BoardStory{
+				Key:    story.Key,
+				Status: string(story.Status),
+				Done:   done,
+			})
+		}
+
+		if len(boardEpic.Stories) > 0 || len(show) == 0 {
+			board.Epics = append(board.Epics, boardEpic)
+		}
+	}
+	return board
+}
+
+// joinStatuses joins statuses with commas.
+func joinStatuses(statuses []status.Status) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+const boardYAML = `development_status:
+  7-1-schema: done
+  7-2-api: review
+  7-3-ui: backlog
+  8-1-search: in-progress
+  8-2-filters: done
+`
+
+func TestStatusCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "all epics",
+			args: []string{"status"},
+			contains: []string{
+				"Epic 7", "1/3 done", "7-1-schema", "7-3-ui",
+				"Epic 8", "1/2 done", "8-1-search",
+				"5 stories: 1 backlog · 1 in-progress · 1 review · 2 done",
+			},
+		},
+		{
+			name:        "epic filter",
+			args:        []string{"status", "--epic", "8"},
+			contains:    []string{"Epic 8", "8-2-filters", "2 stories: 1 in-progress · 1 done"},
+			notContains: []string{"Epic 7"},
+		},
+		{
+			name:        "status filter",
+			args:        []string{"status", "--status", "review,in-progress"},
+			contains:    []string{"Epic 7", "1/3 done", "7-2-api", "8-1-search", "2 stories: 1 in-progress · 1 review"},
+			notContains: []string{"7-1-schema", "8-2-filters"},
+		},
+		{
+			name:        "no matching stories",
+			args:        []string{"status", "--epic", "7", "--status", "in-progress"},
+			contains:    []string{"0 stories"},
+			notContains: []string{"Epic"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, boardYAML)
+			app, _, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+			require.NoError(t, rootCmd.Execute())
+
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func TestStatusCommand_EpicEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `project: shop
+development_status:
+  epic-7: in-progress
+  7-1-schema: done
+  7-2-api: review
+  epic-7-retrospective: optional
+  epic-8: backlog
+`)
+	app, _, buf := setupRunTestApp(tmpDir)
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetArgs([]string{"status"})
+	require.NoError(t, rootCmd.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "Epic 7 (in-progress)")
+	assert.Contains(t, out, "retrospective: optional")
+	assert.Contains(t, out, "Epic 8 (backlog)")
+	assert.Contains(t, out, "2 stories: 1 review · 1 done", "epic entries are not stories")
+}
+
+func TestStatusCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		content string
+		want    string
+	}{
+		{name: "unknown epic", args: []string{"status", "--epic", "9"}, content: boardYAML, want: "no stories found for epic: 9"},
+		{name: "unknown status", args: []string{"status", "--status", "blocked"}, content: boardYAML, want: `unknown status "blocked"`},
+		{name: "missing file", args: []string{"status"}, want: "failed to read sprint status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if tt.content != "" {
+				createSprintStatusFile(t, tmpDir, tt.content)
+			}
+			app, _, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			code, ok := IsExitError(err)
+			require.True(t, ok)
+			assert.Equal(t, 1, code)
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestStatusCommand_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, boardYAML)
+	app, _, _ := setupRunTestApp(tmpDir)
+
+	var buf bytes.Buffer
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&buf)
+	rootCmd.SetArgs([]string{"status", "--output", "json", "--epic", "7"})
+	require.NoError(t, rootCmd.Execute())
+
+	var event output.JSONEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+	assert.Equal(t, output.EventBoard, event.Type)
+	assert.Equal(t, 3, event.Total)
+	require.Len(t, event.Epics, 1)
+	assert.Equal(t, output.BoardEpic{
+		ID:    "7",
+		Done:  1,
+		Total: 3,
+		Stories: []output.BoardStory{
+			{Key: "7-1-schema", Status: "done", Done: true},
+			{Key: "7-2-api", Status: "review"},
+			{Key: "7-3-ui", Status: "backlog"},
+		},
+	}, event.Epics[0])
+	assert.Equal(t, []output.StatusCount{
+		{Status: "backlog", Count: 1},
+		{Status: "review", Count: 1},
+		{Status: "done", Count: 1},
+	}, event.StatusCounts)
+}
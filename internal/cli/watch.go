@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/router"
+	"bmad-automate/internal/runlog"
+	"bmad-automate/internal/watch"
+)
+
+func newWatchCommand(app *App) *cobra.Command {
+	var parallel int
+	var debounce time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Run workflows when stories change status in sprint-status.yaml",
+		Long: `Watch sprint-status.yaml and run the lifecycle of every story that is moved
+into a status with a workflow.
+
+For example, when you move a story from backlog to ready-for-dev in your editor
+or with set-status, watch runs dev-story, code-review and git-commit for it,
+just like run would. Stories moved to a done status, stories that are already
+running, and stories waiting when watch starts are left alone; use queue or
+epic for those.
+
+Edits are picked up once the file has not changed for --debounce, so an editor
+saving several times starts each story only once. A story that fails is
+reported and watching continues.
+
+Every story is a run of its own, with a fresh run budget and run log.
+
+Use --parallel N to run up to N stories at once. Each story runs in its own
+git worktree under .bmad-worktrees/ on a bmad/<story-key> branch. Without it,
+stories run one at a time in the working directory, in the order they changed.
+
+Press Ctrl-C once to stop watching and let running stories finish their
+current workflow, or twice to stop Claude immediately.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			located, ok := app.StatusReader.(interface{ Path() string })
+			if !ok {
+				app.Printer.Error("watch needs the path of the sprint status file")
+				return NewExitError(1)
+			}
+			if parallel > 1 && app.WorkspaceFactory == nil {
+				app.Printer.Error("parallel execution is not available")
+				return NewExitError(1)
+			}
+
+			w := &storyWatcher{app: app, cmd: cmd, running: make(map[string]bool), slots: make(chan struct{}, max(parallel, 1))}
+			if parallel > 1 {
+				w.pool = lifecycle.NewParallelExecutor(1, app.StatusReader, app.StatusWriter, w.newWorkspace, cmd.OutOrStdout())
+				w.pool.SetPrinter(app.Printer)
+				if app.Router != nil {
+					w.pool.SetRouter(app.Router)
+				}
+			}
+			return w.watch(located.Path(), debounce)
+		},
+	}
+
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Number of stories to run at once, each in its own git worktree")
+	cmd.Flags().DurationVar(&debounce, "debounce", time.Second, "How long the file must be unchanged before changes are picked up")
+
+	return cmd
+}
+
+// storyWatcher runs the lifecycle of stories that the watched status file
+// moves into a status with a workflow.
+type storyWatcher struct {
+	app  *App
+	cmd  *cobra.Command
+	pool *lifecycle.ParallelExecutor // nil runs stories in place
+
+	mu      sync.Mutex
+	running map[string]bool // Stories waiting for a slot or running
+	slots   chan struct{}
+	wg      sync.WaitGroup
+}
+
+// watch watches the status file at path until a stop is requested, then
+// waits for the running stories.
+func (w *storyWatcher) watch(path string, debounce time.Duration) error {
+	ctx := w.cmd.Context()
+
+	// The first Ctrl-C stops watching; running stories see the stop request
+	// themselves and stop after their current step.
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-lifecycle.StopRequest(ctx):
+			cancel()
+		case <-watchCtx.Done():
+		}
+	}()
+
+	watcher, err := watch.New(path, w.app.StatusReader, debounce)
+	if err != nil {
+		w.app.Printer.Error("%v", err)
+		return NewExitError(1)
+	}
+	defer watcher.Close()
+	watcher.SetErrorHandler(func(err error) {
+		w.app.Printer.Warning("%v", err)
+	})
+
+	w.app.Printer.Info("Watching %s for status changes. Press Ctrl-C to stop.", path)
+	watcher.Run(watchCtx, w.handle)
+	w.wg.Wait()
+	return NewExitError(ExitCodeInterrupted)
+}
+
+// handle starts the lifecycle of every changed story that has workflows to
+// run and is not running yet.
+func (w *storyWatcher) handle(changes []watch.Change) {
+	r := w.app.lifecycleRouter()
+	for _, change := range changes {
+		if _, err := r.GetLifecycle(change.To); err != nil {
+			continue
+		}
+
+		w.mu.Lock()
+		busy := w.running[change.Key]
+		w.running[change.Key] = true
+		w.mu.Unlock()
+		if busy {
+			continue
+		}
+
+		if change.From == "" {
+			w.app.Printer.Info("Story %s added as %s", change.Key, change.To)
+		} else {
+			w.app.Printer.Info("Story %s moved from %s to %s", change.Key, change.From, change.To)
+		}
+
+		w.wg.Add(1)
+		go func(storyKey string) {
+			defer w.wg.Done()
+			defer w.finish(storyKey)
+
+			// Slots are handed out in the order stories are waiting for them.
+			w.slots <- struct{}{}
+			defer func() { <-w.slots }()
+			if stopRequested(w.cmd.Context()) {
+				return
+			}
+			w.run(storyKey)
+		}(change.Key)
+	}
+}
+
+// run runs the lifecycle of one story and reports the result.
+func (w *storyWatcher) run(storyKey string) {
+	ctx := w.cmd.Context()
+
+	if w.pool != nil {
+		results := w.pool.Execute(ctx, []string{storyKey})
+		if len(results) == 1 && results[0].Success && !results[0].Skipped {
+			w.app.Printer.Info("Story %s completed successfully", storyKey)
+		}
+		return
+	}
+
+	// Stories run one at a time in place, so their output does not interleave.
+	w.app.startRun(storyRunID(time.Now(), storyKey))
+	executor := w.app.newLifecycleExecutor()
+	executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+		w.app.Printer.StepStart(stepIndex, totalSteps, workflow)
+	})
+
+	w.app.Printer.CycleHeader(storyKey)
+	result, err := executor.ExecuteWithResult(ctx, storyKey)
+	switch {
+	case errors.Is(err, router.ErrStoryComplete):
+		w.app.Printer.Info("Story %s is already complete, no action needed", storyKey)
+	case errors.Is(err, lifecycle.ErrInterrupted):
+		w.app.Printer.Info("Story %s stopped. Run 'bmad-automate resume' to continue it", storyKey)
+	case err != nil:
+		w.app.Printer.Error("running lifecycle for story %s: %v", storyKey, err)
+		w.app.Printer.CycleFailed(storyKey, result.FailedAt, result.Steps, result.Duration)
+	default:
+		w.app.Printer.CycleSummary(storyKey, result.Steps, result.Duration)
+	}
+}
+
+// newWorkspace creates the worktree of a story that runs in parallel, with a
+// run budget and run log of its own.
+func (w *storyWatcher) newWorkspace(storyKey string) (*lifecycle.Workspace, error) {
+	return w.app.runWorkspaces(storyRunID(time.Now(), storyKey))(storyKey)
+}
+
+// storyRunID returns the run ID of a story that watch started at t. Every story
+// is a run of its own, with its own budget and run log directory.
+func storyRunID(t time.Time, storyKey string) string {
+	return runlog.NewRunID(t) + "-" + storyKey
+}
+
+// finish marks a story as no longer running, so that its next change starts
+// it again.
+func (w *storyWatcher) finish(storyKey string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.running, storyKey)
+}
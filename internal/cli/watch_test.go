@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/lifecycle"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
+	"bmad-automate/internal/status"
+	"bmad-automate/internal/workflow"
+)
+
+// lockedBuffer is a bytes.Buffer that can be read while a command writes to it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// startWatch runs the watch command in the background until the returned
+// function is called, which stops it like Ctrl-C and returns its error.
+func startWatch(t *testing.T, app *App, out *lockedBuffer, args ...string) func() error {
+	t.Helper()
+	app.Printer = output.NewPrinterWithWriter(out)
+	app.Runner.(*workflow.Runner).SetPrinter(app.Printer)
+
+	stop := make(chan struct{})
+	ctx := lifecycle.WithStopRequest(context.Background(), stop)
+	errs := make(chan error, 1)
+	go func() {
+		rootCmd := NewRootCommand(app)
+		rootCmd.SetArgs(append([]string{"watch", "--debounce", "20ms"}, args...))
+		errs <- rootCmd.ExecuteContext(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "Watching ") }, 5*time.Second, 5*time.Millisecond)
+	return func() error {
+		close(stop)
+		select {
+		case err := <-errs:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("watch did not stop")
+			return nil
+		}
+	}
+}
+
+func TestWatchCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: backlog\n  7-2-api: ready-for-dev\n  7-3-ui: review\n")
+	app, mockExecutor, _ := setupRunTestApp(tmpDir)
+	reader := status.NewReader(tmpDir)
+	var out lockedBuffer
+
+	stopWatch := startWatch(t, app, &out)
+
+	// A human moves a story into ready-for-dev, and another one to done.
+	writer := status.NewWriter(tmpDir)
+	require.NoError(t, writer.UpdateStatus("7-1-schema", status.StatusReadyForDev))
+	require.NoError(t, writer.UpdateStatus("7-3-ui", status.StatusDone))
+
+	require.Eventually(t, func() bool {
+		s, err := reader.GetStoryStatus("7-1-schema")
+		return err == nil && s == status.StatusDone
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err := stopWatch()
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, ExitCodeInterrupted, code)
+
+	assert.Contains(t, out.String(), "Story 7-1-schema moved from backlog to ready-for-dev")
+	assert.NotContains(t, out.String(), "7-3-ui moved", "stories moved to a terminal status are not run")
+	assert.Len(t, mockExecutor.RecordedPrompts, 3, "dev-story, code-review and git-commit")
+	for _, prompt := range mockExecutor.RecordedPrompts {
+		assert.Contains(t, prompt, "7-1-schema")
+	}
+
+	s, err := reader.GetStoryStatus("7-2-api")
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReadyForDev, s, "stories waiting at start are not run")
+}
+
+func TestWatchCommand_FailureKeepsWatching(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: backlog\n  7-2-api: backlog\n")
+	app, mockExecutor, _ := setupRunTestApp(tmpDir)
+	mockExecutor.ExitCodes = []int{1, 0}
+	reader := status.NewReader(tmpDir)
+	writer := status.NewWriter(tmpDir)
+	var out lockedBuffer
+
+	stopWatch := startWatch(t, app, &out)
+
+	require.NoError(t, writer.UpdateStatus("7-1-schema", status.StatusReadyForDev))
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "running lifecycle for story 7-1-schema") }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, writer.UpdateStatus("7-2-api", status.StatusReadyForDev))
+	require.Eventually(t, func() bool {
+		s, err := reader.GetStoryStatus("7-2-api")
+		return err == nil && s == status.StatusDone
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_ = stopWatch()
+	s, err := reader.GetStoryStatus("7-1-schema")
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReadyForDev, s, "the failed story keeps its status")
+}
+
+func TestWatchCommand_RunPerStory(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: backlog\n")
+	app, _, _ := setupRunTestApp(tmpDir)
+	app.Config.Budget.MaxDuration = 100 * time.Millisecond
+	app.startRun(runlog.NewRunID(time.Now()))
+	reader := status.NewReader(tmpDir)
+	var out lockedBuffer
+
+	stopWatch := startWatch(t, app, &out)
+
+	// The budget of the run started with the command has expired by now.
+	time.Sleep(2 * app.Config.Budget.MaxDuration)
+	require.NoError(t, status.NewWriter(tmpDir).UpdateStatus("7-1-schema", status.StatusReadyForDev))
+	require.Eventually(t, func() bool {
+		s, err := reader.GetStoryStatus("7-1-schema")
+		return err == nil && s == status.StatusDone
+	}, 5*time.Second, 10*time.Millisecond, out.String())
+
+	_ = stopWatch()
+	assert.NotContains(t, out.String(), "budget exceeded")
+	runs, err := os.ReadDir(app.Config.Output.RunLogDir)
+	require.NoError(t, err)
+	var names []string
+	for _, run := range runs {
+		names = append(names, run.Name())
+	}
+	assert.Len(t, names, 2, "a .gitignore and the run of the story")
+	assert.True(t, strings.HasSuffix(names[len(names)-1], "-7-1-schema"), names)
+}
+
+func TestWatchCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		create bool
+		want   string
+	}{
+		{name: "missing status file", args: []string{"watch"}, want: "failed to read sprint status"},
+		{name: "parallel without workspaces", args: []string{"watch", "--parallel", "2"}, create: true, want: "parallel execution is not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if tt.create {
+				createSprintStatusFile(t, tmpDir, "development_status:\n  7-1-schema: backlog\n")
+			}
+			app, _, buf := setupRunTestApp(tmpDir)
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetArgs(tt.args)
+			err := rootCmd.Execute()
+
+			code, ok := IsExitError(err)
+			require.True(t, ok)
+			assert.Equal(t, 1, code)
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -42,8 +42,9 @@ func NewLoader() *Loader {
 // Environment variable names use underscores for nested keys. For example,
 // claude.binary_path becomes BMAD_CLAUDE_BINARY_PATH.
 //
-// Returns an error if a config file exists but cannot be parsed. Missing
-// config files are not an error; the loader falls back to defaults.
+// Returns an error if a config file exists but cannot be parsed, or if the
+// resulting lifecycle fails [Config.Validate]. Missing config files are not an
+// error; the loader falls back to defaults.
 func (l *Loader) Load() (*Config, error) {
 	// Start with defaults
 	cfg := DefaultConfig()
@@ -75,8 +76,8 @@ func (l *Loader) Load() (*Config, error) {
 	}
 
 	// Unmarshal into config struct
-	if err := l.v.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+	if err := l.unmarshal(cfg); err != nil {
+		return nil, err
 	}
 
 	// Override Claude binary path from env if set
@@ -84,6 +85,10 @@ func (l *Loader) Load() (*Config, error) {
 		cfg.Claude.BinaryPath = binaryPath
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
@@ -93,7 +98,8 @@ func (l *Loader) Load() (*Config, error) {
 // searching default locations or checking environment variables. The file
 // extension determines the expected format (yaml, json, etc.).
 //
-// Returns an error if the file cannot be read or parsed.
+// Returns an error if the file cannot be read or parsed, or if the resulting
+// lifecycle fails [Config.Validate].
 func (l *Loader) LoadFromFile(path string) (*Config, error) {
 	cfg := DefaultConfig()
 
@@ -104,13 +110,33 @@ func (l *Loader) LoadFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("error reading config file %s: %w", path, err)
 	}
 
-	if err := l.v.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+	if err := l.unmarshal(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
+// unmarshal decodes the loaded configuration over the defaults in cfg.
+//
+// Workflows are merged with the defaults so a config file can add a single
+// workflow, but a configured lifecycle replaces the default transitions so
+// that removed statuses do not linger.
+func (l *Loader) unmarshal(cfg *Config) error {
+	if l.v.IsSet("lifecycle.transitions") {
+		cfg.Lifecycle.Transitions = nil
+	}
+
+	if err := l.v.Unmarshal(cfg); err != nil {
+		return fmt.Errorf("error unmarshaling config: %w", err)
+	}
+	return nil
+}
+
 // GetPrompt returns the expanded prompt for a workflow and story key.
 //
 // The workflowName must match a key in the Workflows map. The storyKey is
@@ -126,6 +152,26 @@ func (c *Config) GetPrompt(workflowName, storyKey string) (string, error) {
 	return expandTemplate(workflow.PromptTemplate, PromptData{StoryKey: storyKey})
 }
 
+// GetFixPrompt returns the expanded prompt that asks Claude to fix a failed
+// verification of a workflow's work on a story.
+//
+// The workflow's [VerifyConfig.FixPrompt] is expanded with the story key, the
+// failed command and its output, falling back to [DefaultVerifyFixPrompt].
+//
+// Returns an error if the workflow is not found or if template expansion fails.
+func (c *Config) GetFixPrompt(workflowName, storyKey, command, output string) (string, error) {
+	workflow, ok := c.Workflows[workflowName]
+	if !ok {
+		return "", fmt.Errorf("unknown workflow: %s", workflowName)
+	}
+
+	tmpl := workflow.Verify.FixPrompt
+	if tmpl == "" {
+		tmpl = DefaultVerifyFixPrompt
+	}
+	return expandTemplate(tmpl, PromptData{StoryKey: storyKey, Command: command, Output: output})
+}
+
 // GetFullCycleSteps returns the list of workflow steps for a full lifecycle.
 //
 // This returns the configured FullCycle.Steps slice, which defines the
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,8 +25,12 @@ func TestDefaultConfig(t *testing.T) {
 	// Check defaults
 	assert.Equal(t, "stream-json", cfg.Claude.OutputFormat)
 	assert.Equal(t, "claude", cfg.Claude.BinaryPath)
+	assert.Equal(t, 10*time.Second, cfg.Claude.GracePeriod)
 	assert.Equal(t, 20, cfg.Output.TruncateLines)
 	assert.Equal(t, 60, cfg.Output.TruncateLength)
+	assert.Equal(t, "text", cfg.Output.Format)
+	assert.Equal(t, "_bmad-output/runs", cfg.Output.RunLogDir)
+	assert.Equal(t, "_bmad-output/reports", cfg.Output.ReportDir)
 }
 
 func TestConfig_GetPrompt(t *testing.T) {
@@ -110,6 +115,142 @@ output:
 	assert.Equal(t, 50, cfg.Output.TruncateLines)
 }
 
+func TestLoader_LoadFromFile_Budget(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+budget:
+  max_cost_usd: 25
+  max_duration: 4h
+workflows:
+  dev-story:
+    prompt_template: "Develop {{.StoryKey}}"
+    budget:
+      max_cost_usd: 5.5
+      max_turns: 200
+      max_duration: 45m
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, BudgetConfig{MaxCostUSD: 25, MaxDuration: 4 * time.Hour}, cfg.Budget)
+	assert.Equal(t, BudgetConfig{MaxCostUSD: 5.5, MaxTurns: 200, MaxDuration: 45 * time.Minute}, cfg.Workflows["dev-story"].Budget)
+	assert.Equal(t, BudgetConfig{}, cfg.Workflows["git-commit"].Budget, "budgets are unlimited by default")
+}
+
+func TestLoader_LoadFromFile_Project(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+project:
+  dir: services/shop
+  status_file: planning/sprint-status.yaml
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, ProjectConfig{Dir: "services/shop", StatusFile: "planning/sprint-status.yaml"}, cfg.Project)
+	assert.Equal(t, ProjectConfig{}, DefaultConfig().Project, "the project is discovered by default")
+}
+
+func TestLoader_LoadFromFile_Git(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+git:
+  allow_dirty: true
+  allow_default_branch: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, GitConfig{Checks: true, AllowDirty: true, AllowDefaultBranch: true, CommitWorkflow: "git-commit"}, cfg.Git)
+	assert.Equal(t, GitConfig{Checks: true, CommitWorkflow: "git-commit"}, DefaultConfig().Git, "checks are enabled by default")
+}
+
+func TestLoader_LoadFromFile_Timeouts(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+claude:
+  grace_period: 30s
+workflows:
+  dev-story:
+    prompt_template: "Develop {{.StoryKey}}"
+    timeout: 45m
+  git-commit:
+    prompt_template: "Commit {{.StoryKey}}"
+    timeout: 5m
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, cfg.Workflows["dev-story"].Timeout)
+	assert.Equal(t, 5*time.Minute, cfg.Workflows["git-commit"].Timeout)
+	assert.Zero(t, cfg.Workflows["code-review"].Timeout, "timeouts are disabled by default")
+	assert.Equal(t, 30*time.Second, cfg.Claude.GracePeriod)
+}
+
+func TestLoader_LoadFromFile_Verify(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+workflows:
+  dev-story:
+    prompt_template: "Develop {{.StoryKey}}"
+    verify:
+      commands:
+        - go build ./...
+        - go test ./...
+      timeout: 10m
+      fix_attempts: 2
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, VerifyConfig{
+		Commands:    []string{"go build ./...", "go test ./..."},
+		Timeout:     10 * time.Minute,
+		FixAttempts: 2,
+	}, cfg.Workflows["dev-story"].Verify)
+	assert.Empty(t, cfg.Workflows["code-review"].Verify.Commands, "verification is disabled by default")
+}
+
+func TestConfig_GetFixPrompt(t *testing.T) {
+	cfg := DefaultConfig()
+
+	prompt, err := cfg.GetFixPrompt("dev-story", "7-1-schema", "go test ./...", "--- FAIL: TestSchema")
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "`go test ./...` failed after your work on story 7-1-schema")
+	assert.Contains(t, prompt, "--- FAIL: TestSchema")
+
+	wf := cfg.Workflows["dev-story"]
+	wf.Verify.FixPrompt = "Fix {{.StoryKey}}: {{.Command}}\n{{.Output}}"
+	cfg.Workflows["dev-story"] = wf
+
+	prompt, err = cfg.GetFixPrompt("dev-story", "7-1-schema", "npm test", "1 failing")
+	require.NoError(t, err)
+	assert.Equal(t, "Fix 7-1-schema: npm test\n1 failing", prompt)
+
+	_, err = cfg.GetFixPrompt("unknown", "7-1-schema", "npm test", "")
+	assert.Error(t, err)
+}
+
 func TestLoader_Load_WithEnvOverride(t *testing.T) {
 	// Set environment variable
 	os.Setenv("BMAD_CLAUDE_PATH", "/env/claude")
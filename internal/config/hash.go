@@ -0,0 +1,22 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Hash returns a short fingerprint of the configuration.
+//
+// Two configurations with the same settings have the same hash, so it can be
+// recorded with a run to tell later whether the prompts or limits used for the
+// run have changed since.
+func (c *Config) Hash() string {
+	// Map keys are sorted by encoding/json, so the encoding is stable.
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:6])
+}
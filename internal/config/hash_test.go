@@ -0,0 +1,20 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Hash(t *testing.T) {
+	hash := DefaultConfig().Hash()
+
+	assert.Len(t, hash, 12)
+	assert.Equal(t, hash, DefaultConfig().Hash(), "equal configs should have equal hashes")
+
+	cfg := DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.PromptTemplate = "Work on {{.StoryKey}}"
+	cfg.Workflows["dev-story"] = wf
+	assert.NotEqual(t, hash, cfg.Hash(), "a changed prompt should change the hash")
+}
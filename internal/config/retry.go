@@ -0,0 +1,76 @@
+package config
+
+import (
+	"math"
+	"regexp"
+	"time"
+)
+
+// Attempts returns the total number of attempts allowed, at least 1.
+func (r RetryConfig) Attempts() int {
+	if r.MaxAttempts < 1 {
+		return 1
+	}
+	return r.MaxAttempts
+}
+
+// Delay returns how long to wait after the given failed attempt (1-based)
+// before starting the next one.
+//
+// The delay starts at InitialDelay and is multiplied by Multiplier for each
+// further retry, capped at MaxDelay. Unset fields use the package defaults.
+func (r RetryConfig) Delay(attempt int) time.Duration {
+	initial := r.InitialDelay
+	if initial <= 0 {
+		initial = DefaultRetryInitialDelay
+	}
+	maxDelay := r.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	multiplier := r.Multiplier
+	if multiplier <= 0 {
+		multiplier = DefaultRetryMultiplier
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// IsRetryable reports whether a failed attempt with the given exit code and
+// stderr output should be retried.
+//
+// With no ExitCodes and no StderrPatterns configured, every failure is
+// retryable. Patterns that fail to compile are ignored; [Config.Validate]
+// reports them when the configuration is loaded.
+func (r RetryConfig) IsRetryable(exitCode int, stderr []string) bool {
+	if len(r.ExitCodes) == 0 && len(r.StderrPatterns) == 0 {
+		return true
+	}
+
+	for _, code := range r.ExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+
+	for _, pattern := range r.StderrPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		for _, line := range stderr {
+			if re.MatchString(line) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryConfig_Attempts(t *testing.T) {
+	assert.Equal(t, 1, RetryConfig{}.Attempts())
+	assert.Equal(t, 1, RetryConfig{MaxAttempts: 1}.Attempts())
+	assert.Equal(t, 5, RetryConfig{MaxAttempts: 5}.Attempts())
+}
+
+func TestRetryConfig_Delay(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   RetryConfig
+		attempt int
+		want    time.Duration
+	}{
+		{"defaults first retry", RetryConfig{}, 1, DefaultRetryInitialDelay},
+		{"defaults second retry doubles", RetryConfig{}, 2, 2 * DefaultRetryInitialDelay},
+		{"custom multiplier", RetryConfig{InitialDelay: time.Second, Multiplier: 3}, 3, 9 * time.Second},
+		{"capped at max delay", RetryConfig{InitialDelay: time.Second, MaxDelay: 5 * time.Second}, 10, 5 * time.Second},
+		{"default cap", RetryConfig{InitialDelay: time.Minute}, 20, DefaultRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.retry.Delay(tt.attempt))
+		})
+	}
+}
+
+func TestRetryConfig_IsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		retry    RetryConfig
+		exitCode int
+		stderr   []string
+		want     bool
+	}{
+		{"no filters retries everything", RetryConfig{}, 1, nil, true},
+		{"listed exit code", RetryConfig{ExitCodes: []int{2, 75}}, 75, nil, true},
+		{"unlisted exit code", RetryConfig{ExitCodes: []int{75}}, 1, nil, false},
+		{"stderr pattern match", RetryConfig{StderrPatterns: []string{`(?i)overloaded`}}, 1, []string{"API Overloaded"}, true},
+		{"stderr pattern no match", RetryConfig{StderrPatterns: []string{`timeout`}}, 1, []string{"syntax error"}, false},
+		{"exit code or pattern", RetryConfig{ExitCodes: []int{75}, StderrPatterns: []string{`timeout`}}, 1, []string{"read timeout"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.retry.IsRetryable(tt.exitCode, tt.stderr))
+		})
+	}
+}
+
+func TestLoader_LoadFromFile_RetryConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+workflows:
+  git-commit:
+    prompt_template: "Commit {{.StoryKey}}"
+    retry:
+      max_attempts: 3
+      initial_delay: 30s
+      max_delay: 2m
+      multiplier: 1.5
+      exit_codes: [1]
+      stderr_patterns:
+        - "(?i)could not read from remote"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, RetryConfig{
+		MaxAttempts:    3,
+		InitialDelay:   30 * time.Second,
+		MaxDelay:       2 * time.Minute,
+		Multiplier:     1.5,
+		ExitCodes:      []int{1},
+		StderrPatterns: []string{"(?i)could not read from remote"},
+	}, cfg.Workflows["git-commit"].Retry)
+}
@@ -10,6 +10,11 @@
 //   - [Loader] handles Viper-based configuration loading
 //   - [WorkflowConfig] defines a single workflow's prompt template
 //   - [ClaudeConfig] contains Claude CLI binary settings
+//   - [BudgetConfig] limits the cost, turns, and wall time of a run
+//   - [VerifyConfig] checks a workflow's work with commands such as tests
+//   - [LifecycleConfig] defines the story status state machine
+//   - [ProjectConfig] locates the sprint status file
+//   - [GitConfig] controls the git safety checks around each story
 //
 // Configuration priority (highest to lowest):
 //  1. Environment variables (BMAD_ prefix)
@@ -18,6 +23,8 @@
 //  4. [DefaultConfig] defaults
 package config
 
+import "time"
+
 // Config represents the root configuration structure.
 //
 // This is the main configuration container loaded by [Loader] and used throughout
@@ -36,6 +43,21 @@ type Config struct {
 
 	// Output contains terminal output formatting configuration.
 	Output OutputConfig `mapstructure:"output"`
+
+	// Lifecycle defines the story statuses and the workflows that move
+	// a story from one status to the next.
+	Lifecycle LifecycleConfig `mapstructure:"lifecycle"`
+
+	// Budget limits the whole run: every workflow of every story started
+	// by a single command counts against it. Unlimited by default.
+	Budget BudgetConfig `mapstructure:"budget"`
+
+	// Project locates the BMAD project and its sprint status file.
+	Project ProjectConfig `mapstructure:"project"`
+
+	// Git controls the checks of the git repository before a story starts
+	// and after its commit workflow. Enabled by default.
+	Git GitConfig `mapstructure:"git"`
 }
 
 // WorkflowConfig represents a single workflow configuration.
@@ -47,6 +69,111 @@ type WorkflowConfig struct {
 	// Use {{.StoryKey}} to reference the story key.
 	// Example: "Work on story: {{.StoryKey}}"
 	PromptTemplate string `mapstructure:"prompt_template"`
+
+	// Retry controls whether a failed run of the workflow is retried.
+	// By default a workflow runs once.
+	Retry RetryConfig `mapstructure:"retry"`
+
+	// Budget limits a single step of the workflow, including its retries.
+	// Unlimited by default.
+	Budget BudgetConfig `mapstructure:"budget"`
+
+	// Timeout limits each run of the workflow. When it fires, Claude and the
+	// processes it started are terminated and the run fails as timed out.
+	// Zero disables the timeout.
+	// Example: "45m"
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Verify runs commands after each successful run of the workflow. The
+	// step only succeeds, and the story only advances, if they all pass.
+	// Disabled by default.
+	Verify VerifyConfig `mapstructure:"verify"`
+}
+
+// Default backoff settings used when retries are enabled but the
+// corresponding [RetryConfig] field is left unset.
+const (
+	DefaultRetryInitialDelay = 10 * time.Second
+	DefaultRetryMaxDelay     = 5 * time.Minute
+	DefaultRetryMultiplier   = 2.0
+)
+
+// RetryConfig defines the retry policy for a workflow.
+//
+// A failed attempt is retried after an exponentially growing delay until
+// MaxAttempts is reached. If neither ExitCodes nor StderrPatterns is set,
+// every failure is retryable; otherwise a failure is retried only if its exit
+// code is listed or a line of Claude's stderr matches one of the patterns.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// 0 or 1 disables retries.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// InitialDelay is the wait before the first retry.
+	// Default: [DefaultRetryInitialDelay]
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+
+	// MaxDelay caps the wait between attempts.
+	// Default: [DefaultRetryMaxDelay]
+	MaxDelay time.Duration `mapstructure:"max_delay"`
+
+	// Multiplier is applied to the delay after each retry.
+	// Default: [DefaultRetryMultiplier]
+	Multiplier float64 `mapstructure:"multiplier"`
+
+	// ExitCodes lists the exit codes that are retryable.
+	ExitCodes []int `mapstructure:"exit_codes"`
+
+	// StderrPatterns lists regular expressions matched against each line
+	// Claude writes to stderr. A match makes the failure retryable.
+	// Example: "(?i)rate limit"
+	StderrPatterns []string `mapstructure:"stderr_patterns"`
+}
+
+// DefaultVerifyFixPrompt is the prompt template used to ask Claude to fix a
+// failed verification when [VerifyConfig.FixPrompt] is not set.
+const DefaultVerifyFixPrompt = "The verification command `{{.Command}}` failed after your work on story {{.StoryKey}}. Fix the cause so that it passes. Do not ask questions.\n\nOutput:\n{{.Output}}"
+
+// VerifyConfig defines the verification gate of a workflow.
+//
+// Commands run one after the other in the directory Claude works in, after
+// Claude succeeded. The first command that exits non-zero fails the
+// verification. If FixAttempts allows it, Claude is then asked to fix the
+// failure in the same session, and all commands run again.
+type VerifyConfig struct {
+	// Commands are shell commands run with "sh -c" ("cmd /C" on Windows).
+	// Example: ["go build ./...", "go test ./..."]
+	Commands []string `mapstructure:"commands"`
+
+	// Timeout limits each command. A command that times out fails.
+	// Zero disables the timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// FixAttempts is how often Claude is asked to fix a failed verification
+	// before the step fails. 0 disables fixing.
+	FixAttempts int `mapstructure:"fix_attempts"`
+
+	// FixPrompt is the Go template of the fix prompt. Use {{.StoryKey}},
+	// {{.Command}} for the failed command and {{.Output}} for its output.
+	// Default: [DefaultVerifyFixPrompt]
+	FixPrompt string `mapstructure:"fix_prompt"`
+}
+
+// BudgetConfig defines spending limits for Claude sessions.
+//
+// A limit of zero is disabled. Turns and wall time are enforced while Claude
+// runs; cost is only known once Claude reports it at the end of a session, so
+// the cost limit is checked after each session and before starting the next.
+type BudgetConfig struct {
+	// MaxCostUSD is the maximum cost in US dollars.
+	MaxCostUSD float64 `mapstructure:"max_cost_usd"`
+
+	// MaxTurns is the maximum number of conversation turns.
+	MaxTurns int `mapstructure:"max_turns"`
+
+	// MaxDuration is the maximum wall time, including retry backoff.
+	// Example: "45m"
+	MaxDuration time.Duration `mapstructure:"max_duration"`
 }
 
 // FullCycleConfig defines the steps for a full development cycle.
@@ -71,6 +198,11 @@ type ClaudeConfig struct {
 	// Default: "claude" (assumes Claude is in PATH).
 	// Can be overridden with BMAD_CLAUDE_PATH environment variable.
 	BinaryPath string `mapstructure:"binary_path"`
+
+	// GracePeriod is how long Claude is given to exit after SIGTERM when a
+	// run is stopped, before it is killed.
+	// Default: 10s
+	GracePeriod time.Duration `mapstructure:"grace_period"`
 }
 
 // OutputConfig contains terminal output formatting configuration.
@@ -86,14 +218,133 @@ type OutputConfig struct {
 	// Longer lines are truncated with "..." suffix.
 	// Default: 60
 	TruncateLength int `mapstructure:"truncate_length"`
+
+	// Format is the output format: "text" for styled terminal output or
+	// "json" for one JSON event per line. The --output flag overrides it.
+	// Default: "text"
+	Format string `mapstructure:"format"`
+
+	// RunLogDir is the directory in which every Claude event of a run is
+	// recorded, as <run-id>/<story>/<step>.jsonl. Empty disables the run log.
+	// A relative path is relative to the project directory.
+	// Default: "_bmad-output/runs"
+	RunLogDir string `mapstructure:"run_log_dir"`
+
+	// ReportDir is the directory in which a Markdown report of every epic run
+	// is written, as epic-<epic-id>-<run-id>.md. Empty disables the report.
+	// A relative path is relative to the project directory.
+	// Default: "_bmad-output/reports"
+	ReportDir string `mapstructure:"report_dir"`
+}
+
+// ProjectConfig locates the BMAD project and its sprint status file.
+//
+// By default the status file is discovered by walking up from the working
+// directory; see the status.Locate function.
+type ProjectConfig struct {
+	// Dir is the directory of the project. Claude, verification commands and
+	// git run in it, relative output directories are resolved against it, and
+	// the status file is discovered from it. The --project-dir flag overrides it.
+	// Default: "" (the root of the BMAD project containing the working
+	// directory, or the working directory if there is none or StatusFile is set)
+	Dir string `mapstructure:"dir"`
+
+	// StatusFile is the path of sprint-status.yaml, relative to Dir.
+	// When set, the status file is not discovered.
+	// Default: "" (discovered)
+	StatusFile string `mapstructure:"status_file"`
+}
+
+// GitConfig controls the git safety checks around each story.
+//
+// Before a story starts, the working tree must be clean and must not have
+// the default branch checked out. After the commit workflow succeeds, HEAD
+// must have moved, the working tree must be clean and the branch must be
+// pushed to its upstream; otherwise the step fails.
+type GitConfig struct {
+	// Checks enables the checks.
+	// Default: true
+	Checks bool `mapstructure:"checks"`
+
+	// AllowDirty lets stories start with uncommitted changes. The
+	// --allow-dirty flag overrides it.
+	// Default: false
+	AllowDirty bool `mapstructure:"allow_dirty"`
+
+	// AllowDefaultBranch lets stories run on the default branch. The
+	// --allow-default-branch flag overrides it.
+	// Default: false
+	AllowDefaultBranch bool `mapstructure:"allow_default_branch"`
+
+	// CommitWorkflow is the workflow that commits and pushes the work of a
+	// story. Must be a key in the workflows configuration. Empty skips the
+	// checks after it.
+	// Default: "git-commit"
+	CommitWorkflow string `mapstructure:"commit_workflow"`
+}
+
+// LifecycleConfig defines the story status state machine.
+//
+// Each non-terminal status maps to an ordered list of transitions. Running a
+// story from a status executes each transition's workflow in turn and sets the
+// story to the transition's next status. After the last transition, the story
+// continues from the last next status until it reaches a terminal status.
+//
+// A lifecycle section in a config file replaces the default transitions
+// entirely rather than merging with them. Use [Config.Validate] to check the
+// state machine for unknown statuses and workflows and for unreachable states.
+type LifecycleConfig struct {
+	// Statuses lists every valid story status.
+	// Default: ["backlog", "ready-for-dev", "in-progress", "review", "done"]
+	Statuses []string `mapstructure:"statuses"`
+
+	// Terminal lists the statuses in which a story is complete.
+	// Default: ["done"]
+	Terminal []string `mapstructure:"terminal"`
+
+	// Transitions maps each non-terminal status to the transitions run from it.
+	Transitions map[string][]TransitionConfig `mapstructure:"transitions"`
 }
 
+// TransitionConfig is a single workflow step in the lifecycle state machine.
+type TransitionConfig struct {
+	// Workflow is the name of the workflow to run.
+	// Must be a key in the workflows configuration.
+	Workflow string `mapstructure:"workflow"`
+
+	// Next is the status to set after the workflow succeeds.
+	// Must be listed in [LifecycleConfig.Statuses].
+	Next string `mapstructure:"next"`
+
+	// Fix makes the transition a review loop: while the workflow reports
+	// findings, the Fix workflow runs to address them and the workflow runs
+	// again. Must be a key in the workflows configuration. Empty disables the
+	// loop.
+	// Example: "dev-story"
+	Fix string `mapstructure:"fix"`
+
+	// MaxIterations is how often the workflow runs at most in a review loop.
+	// If findings remain after the last run, the transition fails.
+	// Default: [DefaultMaxIterations]
+	MaxIterations int `mapstructure:"max_iterations"`
+
+	// RequireTasks makes the transition fail, without setting Next, while the
+	// story file has open tasks. Transitions to a terminal status always
+	// require them.
+	// Default: false (true for the built-in dev-story transitions)
+	RequireTasks bool `mapstructure:"require_tasks"`
+}
+
+// DefaultMaxIterations is how often the workflow of a review loop runs at
+// most when [TransitionConfig.MaxIterations] is not set.
+const DefaultMaxIterations = 3
+
 // DefaultConfig returns a new [Config] with sensible defaults.
 //
 // The defaults include standard workflow prompts for create-story, dev-story,
-// code-review, and git-commit workflows, as well as Claude CLI and output
-// formatting settings. These defaults work out of the box without any
-// configuration file.
+// code-review, and git-commit workflows, the standard BMAD story lifecycle,
+// and Claude CLI and output formatting settings. These defaults work out of
+// the box without any configuration file.
 func DefaultConfig() *Config {
 	return &Config{
 		Workflows: map[string]WorkflowConfig{
@@ -116,10 +367,37 @@ func DefaultConfig() *Config {
 		Claude: ClaudeConfig{
 			OutputFormat: "stream-json",
 			BinaryPath:   "claude",
+			GracePeriod:  10 * time.Second,
 		},
 		Output: OutputConfig{
 			TruncateLines:  20,
 			TruncateLength: 60,
+			Format:         "text",
+			RunLogDir:      "_bmad-output/runs",
+			ReportDir:      "_bmad-output/reports",
+		},
+		Git: GitConfig{
+			Checks:         true,
+			CommitWorkflow: "git-commit",
+		},
+		Lifecycle: LifecycleConfig{
+			Statuses: []string{"backlog", "ready-for-dev", "in-progress", "review", "done"},
+			Terminal: []string{"done"},
+			Transitions: map[string][]TransitionConfig{
+				"backlog": {
+					{Workflow: "create-story", Next: "ready-for-dev"},
+				},
+				"ready-for-dev": {
+					{Workflow: "dev-story", Next: "review", RequireTasks: true},
+				},
+				"in-progress": {
+					{Workflow: "dev-story", Next: "review", RequireTasks: true},
+				},
+				"review": {
+					{Workflow: "code-review", Next: "done"},
+					{Workflow: "git-commit", Next: "done"},
+				},
+			},
 		},
 	}
 }
@@ -132,4 +410,9 @@ type PromptData struct {
 	// StoryKey is the identifier of the story being processed.
 	// Access in templates with {{.StoryKey}}.
 	StoryKey string
+
+	// Command and Output are the failed command and its output in the fix
+	// prompt of a [VerifyConfig]. Empty in workflow prompts.
+	Command string
+	Output  string
 }
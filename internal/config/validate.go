@@ -0,0 +1,211 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Validate checks the lifecycle state machine and workflow retry policies for
+// consistency.
+//
+// It reports:
+//   - statuses that are listed twice, and terminal statuses that are not listed
+//   - transitions from unknown or terminal statuses
+//   - transitions that run an unknown workflow or move to an unknown status
+//   - review loops with an unknown fix workflow or invalid max_iterations
+//   - non-terminal statuses without transitions
+//   - statuses whose transitions never reach a terminal status (cycles)
+//   - terminal statuses that no transition leads to
+//   - retry policies with negative settings or invalid stderr patterns
+//   - negative budget limits, timeouts, and grace periods
+//   - verification settings that are negative or have no commands to check
+//   - a git commit workflow that is not defined
+//
+// All problems are collected and returned in a single error so a broken
+// workflows.yaml can be fixed in one pass. Returns nil if the configuration
+// is valid.
+func (c *Config) Validate() error {
+	lc := c.Lifecycle
+	var problems []string
+
+	if len(lc.Statuses) == 0 {
+		problems = append(problems, "lifecycle.statuses must not be empty")
+	}
+	if len(lc.Terminal) == 0 {
+		problems = append(problems, "lifecycle.terminal must not be empty")
+	}
+
+	known := make(map[string]bool, len(lc.Statuses))
+	for _, s := range lc.Statuses {
+		if known[s] {
+			problems = append(problems, fmt.Sprintf("status %q is listed more than once", s))
+		}
+		known[s] = true
+	}
+
+	terminal := make(map[string]bool, len(lc.Terminal))
+	for _, s := range lc.Terminal {
+		if !known[s] {
+			problems = append(problems, fmt.Sprintf("terminal status %q is not listed in lifecycle.statuses", s))
+		}
+		terminal[s] = true
+	}
+
+	reached := make(map[string]bool)
+	for _, from := range sortedKeys(lc.Transitions) {
+		steps := lc.Transitions[from]
+		if !known[from] {
+			problems = append(problems, fmt.Sprintf("transitions defined for unknown status %q", from))
+		}
+		if terminal[from] {
+			problems = append(problems, fmt.Sprintf("terminal status %q must not have transitions", from))
+		}
+		if len(steps) == 0 {
+			problems = append(problems, fmt.Sprintf("status %q has an empty transition list", from))
+		}
+		for _, step := range steps {
+			if _, ok := c.Workflows[step.Workflow]; !ok {
+				problems = append(problems, fmt.Sprintf("status %q: unknown workflow %q", from, step.Workflow))
+			}
+			if !known[step.Next] {
+				problems = append(problems, fmt.Sprintf("status %q: workflow %q moves to unknown status %q", from, step.Workflow, step.Next))
+			}
+			if _, ok := c.Workflows[step.Fix]; step.Fix != "" && !ok {
+				problems = append(problems, fmt.Sprintf("status %q: workflow %q is fixed by unknown workflow %q", from, step.Workflow, step.Fix))
+			}
+			if step.MaxIterations < 0 {
+				problems = append(problems, fmt.Sprintf("status %q: workflow %q: max_iterations must not be negative", from, step.Workflow))
+			}
+			if step.MaxIterations > 0 && step.Fix == "" {
+				problems = append(problems, fmt.Sprintf("status %q: workflow %q has max_iterations but no fix workflow", from, step.Workflow))
+			}
+			reached[step.Next] = true
+		}
+	}
+
+	for _, s := range lc.Statuses {
+		if !terminal[s] && len(lc.Transitions[s]) == 0 {
+			problems = append(problems, fmt.Sprintf("status %q has no transitions and is not terminal", s))
+		}
+	}
+
+	// Reachability is only meaningful once every transition is well formed.
+	if len(problems) == 0 {
+		for _, s := range lc.Statuses {
+			if !terminal[s] && !reachesTerminal(lc, s, terminal) {
+				problems = append(problems, fmt.Sprintf("status %q never reaches a terminal status", s))
+			}
+		}
+		for _, s := range lc.Terminal {
+			if !reached[s] {
+				problems = append(problems, fmt.Sprintf("terminal status %q is unreachable", s))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(c.Workflows) {
+		problems = append(problems, validateRetry(name, c.Workflows[name].Retry)...)
+		problems = append(problems, validateBudget(fmt.Sprintf("workflow %q: budget", name), c.Workflows[name].Budget)...)
+		if c.Workflows[name].Timeout < 0 {
+			problems = append(problems, fmt.Sprintf("workflow %q: timeout must not be negative", name))
+		}
+		problems = append(problems, validateVerify(name, c.Workflows[name].Verify)...)
+	}
+	problems = append(problems, validateBudget("budget", c.Budget)...)
+	if c.Claude.GracePeriod < 0 {
+		problems = append(problems, "claude.grace_period must not be negative")
+	}
+	if _, ok := c.Workflows[c.Git.CommitWorkflow]; c.Git.CommitWorkflow != "" && !ok {
+		problems = append(problems, fmt.Sprintf("git.commit_workflow: unknown workflow %q", c.Git.CommitWorkflow))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// validateVerify checks the verification settings of a single workflow.
+func validateVerify(workflow string, v VerifyConfig) []string {
+	var problems []string
+	if v.Timeout < 0 {
+		problems = append(problems, fmt.Sprintf("workflow %q: verify.timeout must not be negative", workflow))
+	}
+	if v.FixAttempts < 0 {
+		problems = append(problems, fmt.Sprintf("workflow %q: verify.fix_attempts must not be negative", workflow))
+	}
+	if len(v.Commands) == 0 && (v.FixAttempts > 0 || v.FixPrompt != "") {
+		problems = append(problems, fmt.Sprintf("workflow %q: verify has fix settings but no commands", workflow))
+	}
+	for i, command := range v.Commands {
+		if strings.TrimSpace(command) == "" {
+			problems = append(problems, fmt.Sprintf("workflow %q: verify.commands[%d] is empty", workflow, i))
+		}
+	}
+	return problems
+}
+
+// validateRetry checks the retry policy of a single workflow.
+func validateRetry(workflow string, r RetryConfig) []string {
+	var problems []string
+	if r.MaxAttempts < 0 {
+		problems = append(problems, fmt.Sprintf("workflow %q: retry.max_attempts must not be negative", workflow))
+	}
+	if r.InitialDelay < 0 || r.MaxDelay < 0 {
+		problems = append(problems, fmt.Sprintf("workflow %q: retry delays must not be negative", workflow))
+	}
+	if r.Multiplier != 0 && r.Multiplier < 1 {
+		problems = append(problems, fmt.Sprintf("workflow %q: retry.multiplier must be at least 1", workflow))
+	}
+	for _, pattern := range r.StderrPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("workflow %q: invalid retry.stderr_patterns entry %q: %v", workflow, pattern, err))
+		}
+	}
+	return problems
+}
+
+// validateBudget checks a set of budget limits. The prefix names the
+// configuration section in messages.
+func validateBudget(prefix string, b BudgetConfig) []string {
+	var problems []string
+	if b.MaxCostUSD < 0 {
+		problems = append(problems, prefix+".max_cost_usd must not be negative")
+	}
+	if b.MaxTurns < 0 {
+		problems = append(problems, prefix+".max_turns must not be negative")
+	}
+	if b.MaxDuration < 0 {
+		problems = append(problems, prefix+".max_duration must not be negative")
+	}
+	return problems
+}
+
+// reachesTerminal reports whether following the transitions from status ends
+// in a terminal status. Transitions are deterministic, so a repeated status
+// means the lifecycle loops forever.
+func reachesTerminal(lc LifecycleConfig, status string, terminal map[string]bool) bool {
+	seen := make(map[string]bool)
+	for !terminal[status] {
+		if seen[status] {
+			return false
+		}
+		seen[status] = true
+
+		steps := lc.Transitions[status]
+		status = steps[len(steps)-1].Next
+	}
+	return true
+}
+
+// sortedKeys returns the keys of m in sorted order for deterministic output.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
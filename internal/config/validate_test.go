@@ -0,0 +1,323 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate_Default(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(lc *LifecycleConfig)
+		wantErr []string
+	}{
+		{
+			name:    "no statuses",
+			modify:  func(lc *LifecycleConfig) { lc.Statuses = nil; lc.Transitions = nil; lc.Terminal = nil },
+			wantErr: []string{"lifecycle.statuses must not be empty", "lifecycle.terminal must not be empty"},
+		},
+		{
+			name:    "duplicate status",
+			modify:  func(lc *LifecycleConfig) { lc.Statuses = append(lc.Statuses, "review") },
+			wantErr: []string{`status "review" is listed more than once`},
+		},
+		{
+			name:    "terminal status not listed",
+			modify:  func(lc *LifecycleConfig) { lc.Terminal = append(lc.Terminal, "archived") },
+			wantErr: []string{`terminal status "archived" is not listed`},
+		},
+		{
+			name: "transition from unknown status",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["qa"] = []TransitionConfig{{Workflow: "code-review", Next: "done"}}
+			},
+			wantErr: []string{`transitions defined for unknown status "qa"`},
+		},
+		{
+			name: "transition from terminal status",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["done"] = []TransitionConfig{{Workflow: "git-commit", Next: "done"}}
+			},
+			wantErr: []string{`terminal status "done" must not have transitions`},
+		},
+		{
+			name: "unknown workflow",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["review"] = []TransitionConfig{{Workflow: "security-review", Next: "done"}}
+			},
+			wantErr: []string{`status "review": unknown workflow "security-review"`},
+		},
+		{
+			name: "unknown next status",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["review"] = []TransitionConfig{{Workflow: "code-review", Next: "qa"}}
+			},
+			wantErr: []string{`status "review": workflow "code-review" moves to unknown status "qa"`},
+		},
+		{
+			name: "unknown fix workflow",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["review"] = []TransitionConfig{{Workflow: "code-review", Next: "done", Fix: "hotfix"}}
+			},
+			wantErr: []string{`status "review": workflow "code-review" is fixed by unknown workflow "hotfix"`},
+		},
+		{
+			name: "negative max iterations",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["review"] = []TransitionConfig{{Workflow: "code-review", Next: "done", Fix: "dev-story", MaxIterations: -1}}
+			},
+			wantErr: []string{`status "review": workflow "code-review": max_iterations must not be negative`},
+		},
+		{
+			name: "max iterations without fix",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["review"] = []TransitionConfig{{Workflow: "code-review", Next: "done", MaxIterations: 3}}
+			},
+			wantErr: []string{`status "review": workflow "code-review" has max_iterations but no fix workflow`},
+		},
+		{
+			name:    "empty transition list",
+			modify:  func(lc *LifecycleConfig) { lc.Transitions["review"] = []TransitionConfig{} },
+			wantErr: []string{`status "review" has an empty transition list`},
+		},
+		{
+			name:    "non-terminal status without transitions",
+			modify:  func(lc *LifecycleConfig) { delete(lc.Transitions, "in-progress") },
+			wantErr: []string{`status "in-progress" has no transitions and is not terminal`},
+		},
+		{
+			name: "cycle never reaches terminal",
+			modify: func(lc *LifecycleConfig) {
+				lc.Transitions["review"] = []TransitionConfig{{Workflow: "dev-story", Next: "ready-for-dev"}}
+			},
+			wantErr: []string{
+				`status "backlog" never reaches a terminal status`,
+				`status "ready-for-dev" never reaches a terminal status`,
+				`status "review" never reaches a terminal status`,
+			},
+		},
+		{
+			name: "unreachable terminal status",
+			modify: func(lc *LifecycleConfig) {
+				lc.Statuses = append(lc.Statuses, "archived")
+				lc.Terminal = append(lc.Terminal, "archived")
+			},
+			wantErr: []string{`terminal status "archived" is unreachable`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg.Lifecycle)
+
+			err := cfg.Validate()
+
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Retry(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   RetryConfig
+		wantErr string
+	}{
+		{"negative attempts", RetryConfig{MaxAttempts: -1}, "retry.max_attempts must not be negative"},
+		{"negative delay", RetryConfig{InitialDelay: -time.Second}, "retry delays must not be negative"},
+		{"multiplier below one", RetryConfig{Multiplier: 0.5}, "retry.multiplier must be at least 1"},
+		{"invalid pattern", RetryConfig{StderrPatterns: []string{"("}}, "invalid retry.stderr_patterns entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			wf := cfg.Workflows["dev-story"]
+			wf.Retry = tt.retry
+			cfg.Workflows["dev-story"] = wf
+
+			err := cfg.Validate()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `workflow "dev-story": `+tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Validate_Budget(t *testing.T) {
+	cfg := DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Budget = BudgetConfig{MaxCostUSD: -1, MaxDuration: -time.Minute}
+	cfg.Workflows["dev-story"] = wf
+	cfg.Budget = BudgetConfig{MaxTurns: -5}
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `workflow "dev-story": budget.max_cost_usd must not be negative`)
+	assert.Contains(t, err.Error(), `workflow "dev-story": budget.max_duration must not be negative`)
+	assert.Contains(t, err.Error(), "budget.max_turns must not be negative")
+}
+
+func TestConfig_Validate_Timeouts(t *testing.T) {
+	cfg := DefaultConfig()
+	wf := cfg.Workflows["git-commit"]
+	wf.Timeout = -time.Minute
+	cfg.Workflows["git-commit"] = wf
+	cfg.Claude.GracePeriod = -time.Second
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `workflow "git-commit": timeout must not be negative`)
+	assert.Contains(t, err.Error(), "claude.grace_period must not be negative")
+}
+
+func TestConfig_Validate_Git(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Git.CommitWorkflow = "commit-and-push"
+
+	err := cfg.Validate()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `git.commit_workflow: unknown workflow "commit-and-push"`)
+
+	cfg.Git.CommitWorkflow = ""
+	assert.NoError(t, cfg.Validate(), "an empty commit workflow skips the checks after it")
+}
+
+func TestConfig_Validate_Verify(t *testing.T) {
+	tests := []struct {
+		name    string
+		verify  VerifyConfig
+		wantErr string
+	}{
+		{"negative timeout", VerifyConfig{Commands: []string{"go test ./..."}, Timeout: -time.Second}, "verify.timeout must not be negative"},
+		{"negative fix attempts", VerifyConfig{Commands: []string{"go test ./..."}, FixAttempts: -1}, "verify.fix_attempts must not be negative"},
+		{"fix without commands", VerifyConfig{FixAttempts: 2}, "verify has fix settings but no commands"},
+		{"empty command", VerifyConfig{Commands: []string{"go vet ./...", " "}}, "verify.commands[1] is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			wf := cfg.Workflows["dev-story"]
+			wf.Verify = tt.verify
+			cfg.Workflows["dev-story"] = wf
+
+			err := cfg.Validate()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), `workflow "dev-story": `+tt.wantErr)
+		})
+	}
+}
+
+func TestLoader_LoadFromFile_CustomLifecycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+workflows:
+  security-review:
+    prompt_template: "Security review: {{.StoryKey}}"
+lifecycle:
+  statuses: [backlog, ready-for-dev, review, qa, done]
+  terminal: [done]
+  transitions:
+    backlog:
+      - workflow: create-story
+        next: ready-for-dev
+    ready-for-dev:
+      - workflow: dev-story
+        next: review
+    review:
+      - workflow: code-review
+        next: qa
+    qa:
+      - workflow: security-review
+        next: done
+      - workflow: git-commit
+        next: done
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backlog", "ready-for-dev", "review", "qa", "done"}, cfg.Lifecycle.Statuses)
+	assert.Equal(t, []TransitionConfig{
+		{Workflow: "security-review", Next: "done"},
+		{Workflow: "git-commit", Next: "done"},
+	}, cfg.Lifecycle.Transitions["qa"])
+	assert.NotContains(t, cfg.Lifecycle.Transitions, "in-progress", "configured transitions should replace the defaults")
+	assert.Contains(t, cfg.Workflows, "dev-story", "workflows should still merge with the defaults")
+}
+
+func TestLoader_LoadFromFile_ReviewLoop(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+lifecycle:
+  transitions:
+    backlog:
+      - { workflow: create-story, next: ready-for-dev }
+    ready-for-dev:
+      - { workflow: dev-story, next: review, require_tasks: true }
+    in-progress:
+      - { workflow: dev-story, next: review }
+    review:
+      - { workflow: code-review, next: done, fix: dev-story, max_iterations: 4 }
+      - { workflow: git-commit, next: done }
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, []TransitionConfig{
+		{Workflow: "code-review", Next: "done", Fix: "dev-story", MaxIterations: 4},
+		{Workflow: "git-commit", Next: "done"},
+	}, cfg.Lifecycle.Transitions["review"])
+	assert.Equal(t, []TransitionConfig{
+		{Workflow: "dev-story", Next: "review", RequireTasks: true},
+	}, cfg.Lifecycle.Transitions["ready-for-dev"])
+}
+
+func TestLoader_LoadFromFile_InvalidLifecycle(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "workflows.yaml")
+
+	configContent := `
+lifecycle:
+  statuses: [backlog, review, done]
+  terminal: [done]
+  transitions:
+    backlog:
+      - workflow: create-story
+        next: review
+    review:
+      - workflow: security-review
+        next: done
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	_, err := NewLoader().LoadFromFile(configPath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown workflow "security-review"`)
+}
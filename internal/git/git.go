@@ -0,0 +1,289 @@
+// Package git provides a thin wrapper around the git command line.
+//
+// The package is used to isolate parallel story runs in separate git worktrees
+// and to check the repository before and after a story runs. All operations
+// shell out to the git binary found in PATH, so the behavior matches what a
+// developer would get running the same commands by hand. [IgnoreAll] keeps
+// the files a tool writes into a repository out of commits.
+//
+// Key types:
+//   - [Repo] runs git commands against a repository working tree
+//   - [Snapshot] records HEAD, the branch and uncommitted changes
+//   - [Guard] refuses unsafe starts and verifies the commit of a story
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrWorktreeExists is returned by [Repo.AddWorktree] when the path of the
+// new worktree already exists.
+var ErrWorktreeExists = errors.New("worktree already exists")
+
+// IgnoreAll writes a .gitignore that ignores every file into dir, unless dir
+// already has one. Use it for directories that a tool owns, so their files are
+// never committed.
+func IgnoreAll(dir string) error {
+	f, err := os.OpenFile(filepath.Join(dir, ".gitignore"), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString("*\n"); err != nil {
+		_ = f.Close() //nolint:errcheck // The write error is more useful
+		return err
+	}
+	return f.Close()
+}
+
+// Snapshot is the state of a repository working tree at one point in time.
+type Snapshot struct {
+	// Head is the commit checked out, or "" in a repository without commits.
+	Head string
+
+	// Branch is the branch checked out, or "" if HEAD is detached.
+	Branch string
+
+	// Upstream is the upstream of Branch, e.g. "origin/main", or "" if the
+	// branch has none. Ahead and Behind count the commits by which Branch
+	// and the local copy of its upstream differ.
+	Upstream string
+	Ahead    int
+	Behind   int
+
+	// Changes lists the files with uncommitted changes, including untracked
+	// files, relative to the repository root.
+	Changes []string
+}
+
+// Clean reports whether the working tree has no uncommitted changes.
+func (s Snapshot) Clean() bool {
+	return len(s.Changes) == 0
+}
+
+// Pushed reports whether Branch has an upstream that contains every commit of
+// the branch, as of the last fetch or push.
+func (s Snapshot) Pushed() bool {
+	return s.Upstream != "" && s.Ahead == 0
+}
+
+// Repo runs git commands against the repository containing dir.
+//
+// Use [NewRepo] to create an instance.
+type Repo struct {
+	dir string
+}
+
+// NewRepo creates a [Repo] for the repository containing dir.
+//
+// Pass "." to use the current working directory.
+func NewRepo(dir string) *Repo {
+	return &Repo{dir: dir}
+}
+
+// Dir returns the directory git commands are run in.
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// AddWorktree creates a new worktree at path with branch checked out.
+//
+// A new branch is created from the current HEAD. An existing branch is checked
+// out as it is, so the commits of an earlier run are kept; delete the branch to
+// start over from HEAD. A relative path is relative to [Repo.Dir].
+//
+// Returns an error wrapping [ErrWorktreeExists] if something already exists at
+// path, such as the worktree of an earlier run that was kept.
+func (r *Repo) AddWorktree(path, branch string) error {
+	target := path
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(r.dir, target)
+	}
+	if _, err := os.Lstat(target); err == nil {
+		return fmt.Errorf("%w: %s (remove it with \"git worktree remove --force %s\", and the branch with \"git branch -D %s\" to start over)",
+			ErrWorktreeExists, path, path, branch)
+	}
+
+	// Forget worktrees whose directories were deleted by hand, which would
+	// otherwise keep their branches checked out.
+	if _, err := r.run("worktree", "prune"); err != nil {
+		return fmt.Errorf("failed to add worktree %s: %w", path, err)
+	}
+
+	args := []string{"worktree", "add", "-b", branch, path, "HEAD"}
+	if _, err := r.run("rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		args = []string{"worktree", "add", path, branch}
+	}
+	if _, err := r.run(args...); err != nil {
+		return fmt.Errorf("failed to add worktree %s: %w", path, err)
+	}
+	return nil
+}
+
+// RemoveWorktree removes the worktree at path.
+//
+// The worktree is removed even if it contains uncommitted changes. The branch
+// checked out in the worktree is kept.
+func (r *Repo) RemoveWorktree(path string) error {
+	if _, err := r.run("worktree", "remove", "--force", path); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", path, err)
+	}
+	return nil
+}
+
+// Snapshot records the state of the working tree.
+//
+// Changes to files matching one of the exclude paths are left out, so that
+// files a tool writes itself, such as logs, do not count as changes. Relative
+// paths are relative to [Repo.Dir]; paths outside the repository are ignored.
+func (r *Repo) Snapshot(exclude ...string) (Snapshot, error) {
+	args := []string{"status", "--porcelain=v2", "--branch", "-z", "--", ":/"}
+	if len(exclude) > 0 {
+		prefix, err := r.Prefix()
+		if err != nil {
+			return Snapshot{}, err
+		}
+		for _, path := range exclude {
+			if rel, ok := r.rootPath(prefix, path); ok {
+				args = append(args, ":(top,exclude)"+rel)
+			}
+		}
+	}
+	out, err := r.run(args...)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return parseStatus(out), nil
+}
+
+// parseStatus parses the output of git status --porcelain=v2 --branch -z.
+func parseStatus(out string) Snapshot {
+	var s Snapshot
+	records := strings.Split(out, "\x00")
+	for i := 0; i < len(records); i++ {
+		record := records[i]
+		switch {
+		case strings.HasPrefix(record, "# branch.oid "):
+			if oid := strings.TrimPrefix(record, "# branch.oid "); oid != "(initial)" {
+				s.Head = oid
+			}
+		case strings.HasPrefix(record, "# branch.head "):
+			if head := strings.TrimPrefix(record, "# branch.head "); head != "(detached)" {
+				s.Branch = head
+			}
+		case strings.HasPrefix(record, "# branch.upstream "):
+			s.Upstream = strings.TrimPrefix(record, "# branch.upstream ")
+		case strings.HasPrefix(record, "# branch.ab "):
+			if fields := strings.Fields(strings.TrimPrefix(record, "# branch.ab ")); len(fields) == 2 {
+				s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				s.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(record, "1 "):
+			s.Changes = append(s.Changes, field(record, 8))
+		case strings.HasPrefix(record, "2 "):
+			s.Changes = append(s.Changes, field(record, 9))
+			i++ // The next record is the path the file was renamed from.
+		case strings.HasPrefix(record, "u "):
+			s.Changes = append(s.Changes, field(record, 10))
+		case strings.HasPrefix(record, "? "):
+			s.Changes = append(s.Changes, strings.TrimPrefix(record, "? "))
+		}
+	}
+	return s
+}
+
+// rootPath returns path relative to the repository root, given the prefix of
+// [Repo.Dir] within the repository. It reports false if path is outside the
+// repository.
+func (r *Repo) rootPath(prefix, path string) (string, bool) {
+	if filepath.IsAbs(path) {
+		dir, err := filepath.Abs(r.dir)
+		if err != nil {
+			return "", false
+		}
+		if path, err = filepath.Rel(dir, path); err != nil {
+			return "", false
+		}
+	}
+	rel := filepath.ToSlash(filepath.Join(filepath.FromSlash(prefix), path))
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
+	}
+	return rel, true
+}
+
+// field returns the space-separated field n of record and everything after
+// it, which for the last field is a path that may contain spaces.
+func field(record string, n int) string {
+	fields := strings.SplitN(record, " ", n+1)
+	if len(fields) <= n {
+		return ""
+	}
+	return fields[n]
+}
+
+// Prefix returns the path of [Repo.Dir] relative to the repository root with
+// a trailing slash, e.g. "services/shop/", or "" at the root.
+func (r *Repo) Prefix() (string, error) {
+	return r.run("rev-parse", "--show-prefix")
+}
+
+// GitDir returns the absolute path of the repository's git directory, e.g.
+// "/src/shop/.git". Files in it are never part of the working tree.
+func (r *Repo) GitDir() (string, error) {
+	return r.run("rev-parse", "--absolute-git-dir")
+}
+
+// DefaultBranch returns the name of the repository's default branch.
+//
+// It is the branch origin/HEAD points to. Without a remote, it is the first
+// of the configured init.defaultBranch, "main" and "master" that exists.
+// Returns "" if none of them is known.
+func (r *Repo) DefaultBranch() string {
+	if ref, err := r.run("symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		return strings.TrimPrefix(ref, "origin/")
+	}
+
+	candidates := []string{"main", "master"}
+	if name, err := r.run("config", "--get", "init.defaultBranch"); err == nil && name != "" {
+		candidates = append([]string{name}, candidates...)
+	}
+	for _, name := range candidates {
+		if _, err := r.run("rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// run executes git with the given arguments and returns its trimmed stdout.
+//
+// On failure, the returned error includes git's stderr output.
+func (r *Repo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
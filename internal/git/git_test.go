@@ -0,0 +1,314 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initRepo creates a git repository with a single commit in a temp directory.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		require.NoError(t, cmd.Run())
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0644))
+	for _, args := range [][]string{
+		{"add", "README.md"},
+		{"commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		require.NoError(t, cmd.Run())
+	}
+
+	return dir
+}
+
+func TestNewRepo(t *testing.T) {
+	repo := NewRepo("/some/path")
+
+	assert.Equal(t, "/some/path", repo.Dir())
+}
+
+func TestIgnoreAll(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, IgnoreAll(dir))
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*\n", string(data))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.jsonl\n"), 0644))
+	require.NoError(t, IgnoreAll(dir))
+	data, err = os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.jsonl\n", string(data), "an existing .gitignore is left alone")
+
+	assert.Error(t, IgnoreAll(filepath.Join(dir, "missing")))
+}
+
+func TestRepo_AddAndRemoveWorktree(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+	wtPath := filepath.Join(t.TempDir(), "story-1")
+
+	require.NoError(t, repo.AddWorktree(wtPath, "bmad/story-1"))
+
+	data, err := os.ReadFile(filepath.Join(wtPath, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(data))
+
+	branch, err := NewRepo(wtPath).run("rev-parse", "--abbrev-ref", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "bmad/story-1", branch)
+
+	require.NoError(t, repo.RemoveWorktree(wtPath))
+	_, err = os.Stat(wtPath)
+	assert.True(t, os.IsNotExist(err), "worktree directory should be removed")
+
+	// Branch is kept after the worktree is removed
+	_, err = repo.run("rev-parse", "--verify", "bmad/story-1")
+	assert.NoError(t, err)
+}
+
+func TestRepo_AddWorktree_ReusesBranch(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+	wtPath := filepath.Join(t.TempDir(), "story-1")
+
+	require.NoError(t, repo.AddWorktree(wtPath, "bmad/story-1"))
+	require.NoError(t, os.WriteFile(filepath.Join(wtPath, "story.md"), []byte("work\n"), 0644))
+	runGit(t, wtPath, "add", "story.md")
+	runGit(t, wtPath, "commit", "-q", "-m", "work")
+	head, err := NewRepo(wtPath).run("rev-parse", "HEAD")
+	require.NoError(t, err)
+	require.NoError(t, repo.RemoveWorktree(wtPath))
+
+	require.NoError(t, repo.AddWorktree(wtPath, "bmad/story-1"))
+
+	reused, err := NewRepo(wtPath).run("rev-parse", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, head, reused, "existing branch is not reset")
+	assert.FileExists(t, filepath.Join(wtPath, "story.md"))
+}
+
+func TestRepo_AddWorktree_Exists(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+	wtPath := filepath.Join(t.TempDir(), "story-1")
+	require.NoError(t, repo.AddWorktree(wtPath, "bmad/story-1"))
+
+	err := repo.AddWorktree(wtPath, "bmad/story-1")
+
+	require.ErrorIs(t, err, ErrWorktreeExists)
+	assert.Contains(t, err.Error(), "git worktree remove --force "+wtPath)
+	assert.Contains(t, err.Error(), "git branch -D bmad/story-1")
+}
+
+func TestRepo_AddWorktree_DeletedWorktree(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+	wtPath := filepath.Join(t.TempDir(), "story-1")
+	require.NoError(t, repo.AddWorktree(wtPath, "bmad/story-1"))
+	require.NoError(t, os.RemoveAll(wtPath))
+
+	assert.NoError(t, repo.AddWorktree(wtPath, "bmad/story-1"), "stale worktree is pruned")
+}
+
+func TestRepo_RunError(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+
+	err := repo.RemoveWorktree(filepath.Join(dir, "does-not-exist"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to remove worktree")
+}
+
+// runGit runs git in dir and fails the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+// addRemote creates a bare repository, adds it as origin of dir and pushes
+// main to it with upstream tracking.
+func addRemote(t *testing.T, dir string) {
+	t.Helper()
+	remote := t.TempDir()
+	runGit(t, remote, "init", "-q", "--bare")
+	runGit(t, dir, "remote", "add", "origin", remote)
+	runGit(t, dir, "push", "-q", "-u", "origin", "main")
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want Snapshot
+	}{
+		{
+			name: "clean branch with upstream",
+			out: "# branch.oid 1234567890abcdef\x00# branch.head main\x00" +
+				"# branch.upstream origin/main\x00# branch.ab +2 -1\x00",
+			want: Snapshot{Head: "1234567890abcdef", Branch: "main", Upstream: "origin/main", Ahead: 2, Behind: 1},
+		},
+		{
+			name: "detached head without commits",
+			out:  "# branch.oid (initial)\x00# branch.head (detached)\x00",
+			want: Snapshot{},
+		},
+		{
+			name: "changes",
+			out: "# branch.oid abc\x00# branch.head main\x00" +
+				"1 .M N... 100644 100644 100644 aaa bbb src/main.go\x00" +
+				"2 R. N... 100644 100644 100644 aaa bbb R100 docs/new name.md\x00docs/old.md\x00" +
+				"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.txt\x00" +
+				"? notes.txt\x00",
+			want: Snapshot{
+				Head:    "abc",
+				Branch:  "main",
+				Changes: []string{"src/main.go", "docs/new name.md", "conflict.txt", "notes.txt"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseStatus(tt.out))
+		})
+	}
+}
+
+func TestRepo_Snapshot(t *testing.T) {
+	dir := initRepo(t)
+	repo := NewRepo(dir)
+
+	snapshot, err := repo.Snapshot()
+	require.NoError(t, err)
+	assert.Len(t, snapshot.Head, 40)
+	assert.Equal(t, "main", snapshot.Branch)
+	assert.Empty(t, snapshot.Upstream)
+	assert.True(t, snapshot.Clean())
+	assert.False(t, snapshot.Pushed())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "logs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "run.log"), []byte("log\n"), 0644))
+
+	snapshot, err = repo.Snapshot()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"README.md", "logs/"}, snapshot.Changes)
+
+	snapshot, err = repo.Snapshot("logs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, snapshot.Changes)
+}
+
+func TestRepo_Snapshot_Upstream(t *testing.T) {
+	dir := initRepo(t)
+	addRemote(t, dir)
+	repo := NewRepo(dir)
+
+	snapshot, err := repo.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, "origin/main", snapshot.Upstream)
+	assert.True(t, snapshot.Pushed())
+
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "local")
+
+	snapshot, err = repo.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, 1, snapshot.Ahead)
+	assert.False(t, snapshot.Pushed())
+}
+
+func TestRepo_DefaultBranch(t *testing.T) {
+	t.Run("local branch", func(t *testing.T) {
+		dir := initRepo(t)
+
+		assert.Equal(t, "main", NewRepo(dir).DefaultBranch())
+	})
+
+	t.Run("origin HEAD", func(t *testing.T) {
+		dir := initRepo(t)
+		addRemote(t, dir)
+		runGit(t, dir, "branch", "-q", "-m", "main", "trunk")
+		runGit(t, dir, "remote", "set-head", "origin", "main")
+
+		assert.Equal(t, "main", NewRepo(dir).DefaultBranch())
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		dir := initRepo(t)
+		runGit(t, dir, "branch", "-q", "-m", "main", "trunk")
+		runGit(t, dir, "config", "init.defaultBranch", "develop")
+
+		assert.Empty(t, NewRepo(dir).DefaultBranch())
+	})
+}
+
+func TestRepo_Snapshot_ExcludeOutsideRepo(t *testing.T) {
+	dir := initRepo(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub", "logs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "logs", "run.log"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644))
+	repo := NewRepo(filepath.Join(dir, "sub"))
+
+	snapshot, err := repo.Snapshot("logs", filepath.Join(t.TempDir(), "outside.lock"), "../../elsewhere")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notes.txt"}, snapshot.Changes, "paths are relative to the repository root")
+}
+
+func TestRepo_Prefix(t *testing.T) {
+	dir := initRepo(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "services", "shop"), 0755))
+
+	prefix, err := NewRepo(dir).Prefix()
+	require.NoError(t, err)
+	assert.Empty(t, prefix)
+
+	prefix, err = NewRepo(filepath.Join(dir, "services", "shop")).Prefix()
+	require.NoError(t, err)
+	assert.Equal(t, "services/shop/", prefix)
+}
+
+func TestRepo_GitDir(t *testing.T) {
+	dir := initRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "story-1")
+	require.NoError(t, NewRepo(dir).AddWorktree(wtPath, "bmad/story-1"))
+
+	gitDir, err := NewRepo(dir).GitDir()
+	require.NoError(t, err)
+	want, err := filepath.EvalSymlinks(filepath.Join(dir, ".git"))
+	require.NoError(t, err)
+	assert.Equal(t, want, gitDir)
+
+	gitDir, err = NewRepo(wtPath).GitDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(want, "worktrees", "story-1"), gitDir, "each worktree has its own")
+
+	_, err = NewRepo(t.TempDir()).GitDir()
+	assert.Error(t, err, "not a repository")
+}
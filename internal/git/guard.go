@@ -0,0 +1,159 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Errors reported by [Guard], wrapped with the details of the repository.
+var (
+	// ErrDirtyTree reports uncommitted changes: before a story starts, or
+	// left behind by the commit workflow.
+	ErrDirtyTree = errors.New("working tree has uncommitted changes")
+
+	// ErrDefaultBranch reports that a story was about to run on the
+	// repository's default branch.
+	ErrDefaultBranch = errors.New("refusing to run on the default branch")
+
+	// ErrNoNewCommit reports that the commit workflow did not commit.
+	ErrNoNewCommit = errors.New("no new commit")
+
+	// ErrNotPushed reports that the commit workflow did not push its commit.
+	ErrNotPushed = errors.New("commit not pushed")
+)
+
+// GuardOptions configure a [Guard].
+type GuardOptions struct {
+	// AllowDirty lets stories start with uncommitted changes. The commit
+	// workflow must still leave no changes besides those.
+	AllowDirty bool
+
+	// AllowDefaultBranch lets stories run on the default branch, see
+	// [Repo.DefaultBranch].
+	AllowDefaultBranch bool
+
+	// CommitWorkflow is the workflow that commits and pushes the work of a
+	// story. Empty skips the checks after it.
+	CommitWorkflow string
+
+	// Exclude lists paths whose changes are ignored, such as the tool's own
+	// logs; see [Repo.Snapshot].
+	Exclude []string
+}
+
+// Guard checks a repository before and after the workflows of stories.
+//
+// BeforeStory refuses to start a story on a dirty working tree or on the
+// default branch, and records a [Snapshot]. AfterStep verifies, once the
+// commit workflow succeeded, that HEAD moved since the story started, that the
+// working tree is clean and that the branch is pushed to its upstream.
+//
+// A Guard is safe for concurrent use. Use [NewGuard] to create an instance.
+type Guard struct {
+	repo *Repo
+	opts GuardOptions
+
+	mu      sync.Mutex
+	started map[string]Snapshot // Snapshot of each story when it started
+}
+
+// NewGuard creates a [Guard] for repo.
+func NewGuard(repo *Repo, opts GuardOptions) *Guard {
+	return &Guard{repo: repo, opts: opts, started: make(map[string]Snapshot)}
+}
+
+// BeforeStory checks the repository before the first workflow of a story and
+// records its state.
+//
+// Returns an error wrapping [ErrDirtyTree] or [ErrDefaultBranch] unless the
+// options allow it, or the error of git if the state cannot be read.
+func (g *Guard) BeforeStory(storyKey string) error {
+	snapshot, err := g.repo.Snapshot(g.opts.Exclude...)
+	if err != nil {
+		return err
+	}
+	if !g.opts.AllowDirty && !snapshot.Clean() {
+		return fmt.Errorf("%w: %s", ErrDirtyTree, summarize(snapshot.Changes))
+	}
+	if !g.opts.AllowDefaultBranch && snapshot.Branch != "" && snapshot.Branch == g.repo.DefaultBranch() {
+		return fmt.Errorf("%w %s", ErrDefaultBranch, snapshot.Branch)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.started[storyKey] = snapshot
+	return nil
+}
+
+// AfterStep checks the repository after a workflow of a story succeeded.
+// Only the commit workflow is checked.
+//
+// Returns an error wrapping [ErrNoNewCommit] if HEAD has not moved since
+// [Guard.BeforeStory], [ErrDirtyTree] if files changed that were not changed
+// when the story started, or [ErrNotPushed] if the branch has no upstream or
+// commits its upstream lacks. Stories that did not start through BeforeStory,
+// such as resumed ones, skip the check for a new commit.
+func (g *Guard) AfterStep(storyKey, workflow string) error {
+	if g.opts.CommitWorkflow == "" || workflow != g.opts.CommitWorkflow {
+		return nil
+	}
+
+	snapshot, err := g.repo.Snapshot(g.opts.Exclude...)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	before, started := g.started[storyKey]
+	g.mu.Unlock()
+
+	if started && snapshot.Head == before.Head {
+		return fmt.Errorf("%w since the story started: HEAD is still %s", ErrNoNewCommit, short(before.Head))
+	}
+	if changes := newChanges(before.Changes, snapshot.Changes); len(changes) > 0 {
+		return fmt.Errorf("%w after %s: %s", ErrDirtyTree, workflow, summarize(changes))
+	}
+	switch {
+	case snapshot.Branch == "":
+		return fmt.Errorf("%w: HEAD is detached", ErrNotPushed)
+	case snapshot.Upstream == "":
+		return fmt.Errorf("%w: branch %s has no upstream", ErrNotPushed, snapshot.Branch)
+	case !snapshot.Pushed():
+		return fmt.Errorf("%w: %s is %d commit(s) ahead of %s", ErrNotPushed, snapshot.Branch, snapshot.Ahead, snapshot.Upstream)
+	}
+	return nil
+}
+
+// newChanges returns the files of after that are not in before.
+func newChanges(before, after []string) []string {
+	seen := make(map[string]bool, len(before))
+	for _, path := range before {
+		seen[path] = true
+	}
+	var changes []string
+	for _, path := range after {
+		if !seen[path] {
+			changes = append(changes, path)
+		}
+	}
+	return changes
+}
+
+// summarize lists the first few changed files.
+func summarize(changes []string) string {
+	const shown = 3
+	if len(changes) <= shown {
+		return strings.Join(changes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(changes[:shown], ", "), len(changes)-shown)
+}
+
+// short abbreviates a commit hash.
+func short(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,218 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storyRepo creates a repository pushed to a remote, with a story branch
+// checked out that tracks its own remote branch.
+func storyRepo(t *testing.T) string {
+	t.Helper()
+	dir := initRepo(t)
+	addRemote(t, dir)
+	runGit(t, dir, "checkout", "-q", "-b", "story")
+	runGit(t, dir, "push", "-q", "-u", "origin", "story")
+	return dir
+}
+
+// commit writes a file and commits it.
+func commit(t *testing.T, dir, name string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name+"\n"), 0644))
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-q", "-m", "add "+name)
+}
+
+func TestGuard_BeforeStory(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    GuardOptions
+		setup   func(t *testing.T, dir string)
+		wantErr error
+	}{
+		{
+			name: "clean story branch",
+		},
+		{
+			name: "dirty tree",
+			setup: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "wip.go"), nil, 0644))
+			},
+			wantErr: ErrDirtyTree,
+		},
+		{
+			name: "dirty tree allowed",
+			opts: GuardOptions{AllowDirty: true},
+			setup: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "wip.go"), nil, 0644))
+			},
+		},
+		{
+			name: "excluded changes",
+			opts: GuardOptions{Exclude: []string{".bmad-state.json"}},
+			setup: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, ".bmad-state.json"), nil, 0644))
+			},
+		},
+		{
+			name: "default branch",
+			setup: func(t *testing.T, dir string) {
+				runGit(t, dir, "checkout", "-q", "main")
+			},
+			wantErr: ErrDefaultBranch,
+		},
+		{
+			name: "default branch allowed",
+			opts: GuardOptions{AllowDefaultBranch: true},
+			setup: func(t *testing.T, dir string) {
+				runGit(t, dir, "checkout", "-q", "main")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := storyRepo(t)
+			if tt.setup != nil {
+				tt.setup(t, dir)
+			}
+			guard := NewGuard(NewRepo(dir), tt.opts)
+
+			err := guard.BeforeStory("7-1-schema")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGuard_BeforeStory_ListsChanges(t *testing.T) {
+	dir := storyRepo(t)
+	for _, name := range []string{"a.go", "b.go", "c.go", "d.go", "e.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	err := NewGuard(NewRepo(dir), GuardOptions{}).BeforeStory("7-1-schema")
+
+	require.Error(t, err)
+	assert.Equal(t, "working tree has uncommitted changes: a.go, b.go, c.go and 2 more", err.Error())
+}
+
+func TestGuard_AfterStep(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     GuardOptions
+		workflow string
+		before   func(t *testing.T, dir string)
+		after    func(t *testing.T, dir string)
+		wantErr  error
+	}{
+		{
+			name:     "committed and pushed",
+			workflow: "git-commit",
+			after: func(t *testing.T, dir string) {
+				commit(t, dir, "feature.go")
+				runGit(t, dir, "push", "-q")
+			},
+		},
+		{
+			name:     "other workflow",
+			workflow: "dev-story",
+		},
+		{
+			name:     "no commit",
+			workflow: "git-commit",
+			wantErr:  ErrNoNewCommit,
+		},
+		{
+			name:     "changes left",
+			workflow: "git-commit",
+			after: func(t *testing.T, dir string) {
+				commit(t, dir, "feature.go")
+				runGit(t, dir, "push", "-q")
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "feature_test.go"), nil, 0644))
+			},
+			wantErr: ErrDirtyTree,
+		},
+		{
+			name:     "changes from before the story",
+			opts:     GuardOptions{AllowDirty: true},
+			workflow: "git-commit",
+			before: func(t *testing.T, dir string) {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644))
+			},
+			after: func(t *testing.T, dir string) {
+				commit(t, dir, "feature.go")
+				runGit(t, dir, "push", "-q")
+			},
+		},
+		{
+			name:     "not pushed",
+			workflow: "git-commit",
+			after: func(t *testing.T, dir string) {
+				commit(t, dir, "feature.go")
+			},
+			wantErr: ErrNotPushed,
+		},
+		{
+			name:     "no upstream",
+			workflow: "git-commit",
+			before: func(t *testing.T, dir string) {
+				runGit(t, dir, "checkout", "-q", "-b", "local")
+			},
+			after: func(t *testing.T, dir string) {
+				commit(t, dir, "feature.go")
+			},
+			wantErr: ErrNotPushed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := storyRepo(t)
+			if tt.before != nil {
+				tt.before(t, dir)
+			}
+			opts := tt.opts
+			opts.CommitWorkflow = "git-commit"
+			guard := NewGuard(NewRepo(dir), opts)
+			require.NoError(t, guard.BeforeStory("7-1-schema"))
+			if tt.after != nil {
+				tt.after(t, dir)
+			}
+
+			err := guard.AfterStep("7-1-schema", tt.workflow)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGuard_AfterStep_ResumedStory(t *testing.T) {
+	dir := storyRepo(t)
+	guard := NewGuard(NewRepo(dir), GuardOptions{CommitWorkflow: "git-commit"})
+
+	// Without BeforeStory there is no HEAD to compare with, so a pushed
+	// branch passes even though nothing was committed.
+	assert.NoError(t, guard.AfterStep("7-1-schema", "git-commit"))
+}
+
+func TestGuard_AfterStep_NoCommitWorkflow(t *testing.T) {
+	dir := storyRepo(t)
+	guard := NewGuard(NewRepo(dir), GuardOptions{})
+	require.NoError(t, guard.BeforeStory("7-1-schema"))
+
+	assert.NoError(t, guard.AfterStep("7-1-schema", "git-commit"))
+}
@@ -5,19 +5,72 @@
 // updates the story status automatically after successful completion.
 //
 // Key concepts:
-//   - Lifecycle steps are determined by [router.GetLifecycle] based on current status
+//   - Lifecycle steps are determined by a [router.Router] based on current status
 //   - Each step runs a workflow then updates status via [StatusWriter]
 //   - Progress can be tracked via [ProgressCallback]
+//   - Checkpoints are persisted via [StateStore] so interrupted runs can be resumed
+//   - [ParallelExecutor] runs several stories at once, each in its own [Workspace]
+//   - [WithStopRequest] asks a running lifecycle to stop after the current step
 package lifecycle
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"bmad-automate/internal/output"
 	"bmad-automate/internal/router"
+	"bmad-automate/internal/state"
 	"bmad-automate/internal/status"
 )
 
+// ErrStatusDrift is a sentinel error returned by [Executor.Resume] when the story's
+// current status in sprint-status.yaml no longer matches the saved checkpoint.
+// This typically means the story was moved by hand after the run was interrupted;
+// callers should discard the checkpoint and start a fresh run instead.
+var ErrStatusDrift = errors.New("sprint status has drifted from saved state")
+
+// ErrNoSession is a sentinel error returned by [Executor.Retry] when the step to
+// retry has no recorded Claude session to continue, or the runner cannot resume
+// sessions.
+var ErrNoSession = errors.New("no Claude session to continue")
+
+// ErrRepoCheck is a sentinel error returned by [Executor.Execute] when the
+// [RepoGuard] refuses to start a story, for example because the working tree
+// has uncommitted changes.
+var ErrRepoCheck = errors.New("repository check failed")
+
+// StepError reports a workflow step that exited with a non-zero code.
+//
+// Callers can use errors.As to find out which workflow failed, for example to
+// fill in [output.StoryResult.FailedAt].
+type StepError struct {
+	// Workflow is the name of the workflow that failed.
+	Workflow string
+
+	// ExitCode is the non-zero exit code returned by the last attempt.
+	ExitCode int
+
+	// Attempts is the number of times the workflow ran, including retries.
+	Attempts int
+
+	// Reason explains why the workflow was stopped, for example because a
+	// budget limit was exceeded. Empty for ordinary failures.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *StepError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("workflow failed: %s stopped: %s", e.Workflow, e.Reason)
+	}
+	if e.Attempts > 1 {
+		return fmt.Sprintf("workflow failed: %s returned exit code %d after %d attempts", e.Workflow, e.ExitCode, e.Attempts)
+	}
+	return fmt.Sprintf("workflow failed: %s returned exit code %d", e.Workflow, e.ExitCode)
+}
+
 // WorkflowRunner is the interface for executing individual workflows.
 //
 // RunSingle executes a named workflow for a story and returns the exit code.
@@ -27,6 +80,27 @@ type WorkflowRunner interface {
 	RunSingle(ctx context.Context, workflowName, storyKey string) int
 }
 
+// StepRunner is an optional extension of [WorkflowRunner] for runners that report
+// the details of each step, such as retry attempts.
+//
+// When the runner passed to [NewExecutor] implements StepRunner, the executor uses
+// RunStep instead of RunSingle and includes the results in [output.StoryResult.Steps].
+// The [workflow.Runner] type implements this interface.
+type StepRunner interface {
+	RunStep(ctx context.Context, workflowName, storyKey string) output.StepResult
+}
+
+// SessionRunner is an optional extension of [StepRunner] for runners that can
+// continue an earlier Claude session.
+//
+// ResumeStep runs a workflow like RunStep, but in the session identified by
+// sessionID, so Claude keeps the context of the earlier run. It is used by
+// [Executor.Retry]. The [workflow.Runner] type implements this interface.
+type SessionRunner interface {
+	StepRunner
+	ResumeStep(ctx context.Context, workflowName, storyKey, sessionID string) output.StepResult
+}
+
 // StatusReader is the interface for looking up story status.
 //
 // GetStoryStatus retrieves the current [status.Status] for a story key.
@@ -43,6 +117,28 @@ type StatusWriter interface {
 	UpdateStatus(storyKey string, newStatus status.Status) error
 }
 
+// StateStore is the interface for persisting lifecycle checkpoints.
+//
+// Save records the step that is about to run, Load returns the last checkpoint
+// (or [state.ErrNoState] if there is none), and Clear removes it after the
+// lifecycle completes. The [state.Manager] type implements this interface.
+type StateStore interface {
+	Save(s state.State) error
+	Load() (state.State, error)
+	Clear() error
+}
+
+// RepoGuard checks the repository around a story's lifecycle.
+//
+// BeforeStory is called before the first step of a fresh run and refuses to
+// start the story by returning an error. AfterStep is called after each
+// successful workflow; an error fails the step and the story keeps its status.
+// The [git.Guard] type implements this interface.
+type RepoGuard interface {
+	BeforeStory(storyKey string) error
+	AfterStep(storyKey, workflow string) error
+}
+
 // ProgressCallback is invoked before each workflow step begins execution.
 //
 // The callback receives stepIndex (1-based), totalSteps count, and the workflow name.
@@ -60,18 +156,27 @@ type Executor struct {
 	statusReader     StatusReader
 	statusWriter     StatusWriter
 	progressCallback ProgressCallback
+	stateStore       StateStore
+	router           *router.Router
+	findings         FindingsCounter
+	tasks            TaskChecker
+	guard            RepoGuard
 }
 
 // NewExecutor creates a new Executor with the required dependencies.
 //
 // The runner executes workflows, reader looks up story status, and writer persists
 // status updates. Progress callback is not set by default; use [SetProgressCallback]
-// to enable progress reporting.
+// to enable progress reporting. Steps are routed with [router.Default] unless
+// [SetRouter] is called.
 func NewExecutor(runner WorkflowRunner, reader StatusReader, writer StatusWriter) *Executor {
 	return &Executor{
 		runner:       runner,
 		statusReader: reader,
 		statusWriter: writer,
+		router:       router.Default(),
+		findings:     ReviewFindings{},
+		tasks:        StoryTasks{},
 	}
 }
 
@@ -84,50 +189,531 @@ func (e *Executor) SetProgressCallback(cb ProgressCallback) {
 	e.progressCallback = cb
 }
 
+// SetStateStore configures where lifecycle checkpoints are persisted.
+//
+// When set, [Executor.Execute] saves a checkpoint before every step and clears it
+// once the lifecycle completes, and [Executor.Resume] can continue an interrupted
+// run. When not set, no checkpoints are written.
+func (e *Executor) SetStateStore(store StateStore) {
+	e.stateStore = store
+}
+
+// SetRouter configures the lifecycle state machine used to plan steps.
+//
+// Use this to run stories through a lifecycle loaded from configuration via
+// [router.FromConfig].
+func (e *Executor) SetRouter(r *router.Router) {
+	e.router = r
+}
+
+// SetFindingsCounter configures how the findings of review loops are counted.
+//
+// By default, [ReviewFindings] without a story directory is used, which only
+// reads the [FindingsMarker] in Claude's final text.
+func (e *Executor) SetFindingsCounter(c FindingsCounter) {
+	e.findings = c
+}
+
+// SetTaskChecker configures how the open tasks of a story are found before it
+// moves to review or to a terminal status.
+//
+// By default, [StoryTasks] without a story directory is used, which finds no
+// open tasks.
+func (e *Executor) SetTaskChecker(c TaskChecker) {
+	e.tasks = c
+}
+
+// SetRepoGuard configures checks of the repository before a story starts and
+// after each of its workflows. When not set, the repository is not checked.
+func (e *Executor) SetRepoGuard(g RepoGuard) {
+	e.guard = g
+}
+
 // Execute runs the complete story lifecycle from current status to done.
 //
 // Execute looks up the story's current status, determines the remaining workflow steps
-// via [router.GetLifecycle], and runs each workflow in sequence. After each successful
+// via [router.Router.GetLifecycle], and runs each workflow in sequence. After each successful
 // workflow, the story status is updated to the next state.
 //
+// A step with a fix workflow is a review loop: while the review reports findings,
+// counted by the [FindingsCounter], the fix workflow and the review run again, up
+// to the step's iteration limit. The status is only updated once a review reports
+// no findings; if findings remain after the last review, the step fails.
+//
+// Before a step that requires tasks, see [router.LifecycleStep.RequireTasks], or
+// one that moves the story to a terminal status, the [TaskChecker] confirms that
+// every task of the story is done. Otherwise the
+// step fails, its result lists the open tasks, and the status is not updated.
+//
+// If a [RepoGuard] is configured, a story it refuses to start fails with an
+// error wrapping [ErrRepoCheck] before any step runs, and a workflow it rejects
+// afterwards fails like a workflow with a non-zero exit code.
+//
 // Execute uses fail-fast behavior: it stops on the first error and returns immediately.
 // Errors can occur from status lookup failure, workflow execution failure (non-zero exit),
 // or status update failure. For stories already done, Execute returns [router.ErrStoryComplete].
+//
+// If a [StateStore] is configured, the checkpoint left behind by a failed step points
+// at that step so the run can be continued with [Executor.Resume].
 func (e *Executor) Execute(ctx context.Context, storyKey string) error {
+	_, err := e.execute(ctx, storyKey)
+	return err
+}
+
+// execute runs the lifecycle for storyKey and returns the results of the steps that ran.
+func (e *Executor) execute(ctx context.Context, storyKey string) ([]output.StepResult, error) {
 	// Get current story status
 	currentStatus, err := e.statusReader.GetStoryStatus(storyKey)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get lifecycle steps from current status
-	steps, err := router.GetLifecycle(currentStatus)
+	steps, err := e.router.GetLifecycle(currentStatus)
+	if err != nil {
+		return nil, err // Returns router.ErrStoryComplete for done stories
+	}
+
+	if e.guard != nil {
+		if err := e.guard.BeforeStory(storyKey); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRepoCheck, err)
+		}
+	}
+
+	return e.runSteps(ctx, storyKey, currentStatus, steps, 0, nil, "")
+}
+
+// ExecuteWithResult runs the story lifecycle like [Executor.Execute] and also
+// returns an [output.StoryResult] describing the outcome.
+//
+// Stories that are already done produce a skipped result together with
+// [router.ErrStoryComplete]. When a workflow fails, FailedAt holds its name;
+// when the status cannot be read or written, FailedAt is "status"; when the
+// [RepoGuard] refuses to start the story, FailedAt is "git". Steps holds
+// the result of every step that ran, including retry attempts. A lifecycle that
+// was stopped early returns an error wrapping [ErrInterrupted] and a result with
+// Interrupted set; FailedAt is only set if a running workflow was killed.
+func (e *Executor) ExecuteWithResult(ctx context.Context, storyKey string) (output.StoryResult, error) {
+	start := time.Now()
+	steps, err := e.execute(ctx, storyKey)
+	return storyResult(storyKey, start, steps, err), err
+}
+
+// storyResult describes the outcome of a lifecycle of storyKey that started at
+// start, ran steps and ended with err, as documented for
+// [Executor.ExecuteWithResult].
+func storyResult(storyKey string, start time.Time, steps []output.StepResult, err error) output.StoryResult {
+	result := output.StoryResult{
+		Key:      storyKey,
+		Success:  err == nil,
+		Duration: time.Since(start),
+		Steps:    steps,
+	}
+
+	var stepErr *StepError
+	switch {
+	case err == nil:
+	case errors.Is(err, router.ErrStoryComplete):
+		result.Success = true
+		result.Skipped = true
+	case errors.Is(err, ErrInterrupted):
+		result.Interrupted = true
+		if errors.As(err, &stepErr) {
+			result.FailedAt = stepErr.Workflow
+		}
+	case errors.As(err, &stepErr):
+		result.FailedAt = stepErr.Workflow
+	case errors.Is(err, ErrRepoCheck):
+		result.FailedAt = "git"
+	default:
+		result.FailedAt = "status"
+	}
+	return result
+}
+
+// Resume continues an interrupted lifecycle from the saved checkpoint.
+//
+// Resume loads the checkpoint from the [StateStore], rebuilds the lifecycle from the
+// saved start status, and verifies that the story's current status matches what the
+// checkpoint expects before running the remaining steps. If the status shows the
+// checkpointed step already finished (the process died between updating the status
+// and writing the next checkpoint), execution continues with the following step.
+//
+// Returns [state.ErrNoState] if there is nothing to resume, and an error wrapping
+// [ErrStatusDrift] if the story was moved since the checkpoint was written.
+func (e *Executor) Resume(ctx context.Context) error {
+	_, err := e.ResumeWithResult(ctx)
+	return err
+}
+
+// ResumeWithResult continues an interrupted lifecycle like [Executor.Resume] and
+// also returns an [output.StoryResult] for the story of the checkpoint, like
+// [Executor.ExecuteWithResult]. Steps holds only the steps run by the resume.
+func (e *Executor) ResumeWithResult(ctx context.Context) (output.StoryResult, error) {
+	start := time.Now()
+	storyKey, steps, err := e.resume(ctx)
+	return storyResult(storyKey, start, steps, err), err
+}
+
+// resume implements [Executor.ResumeWithResult] and returns the story of the
+// checkpoint, if one was loaded, and the results of the steps that ran.
+func (e *Executor) resume(ctx context.Context) (string, []output.StepResult, error) {
+	if e.stateStore == nil {
+		return "", nil, state.ErrNoState
+	}
+
+	saved, err := e.stateStore.Load()
 	if err != nil {
-		return err // Returns router.ErrStoryComplete for done stories
+		return "", nil, err
+	}
+
+	startIndex, steps, err := e.resumePoint(saved)
+	if err != nil {
+		return saved.StoryKey, nil, err
+	}
+
+	results, err := e.runSteps(ctx, saved.StoryKey, status.Status(saved.StartStatus), steps, startIndex, saved.Sessions, "")
+	return saved.StoryKey, results, err
+}
+
+// Retry runs the failed story again from its saved checkpoint, like [Executor.Resume],
+// but only if the checkpoint belongs to storyKey.
+//
+// If continueSession is true, the step at the checkpoint continues the Claude session
+// recorded for it instead of starting a new one, using [SessionRunner.ResumeStep].
+// The remaining steps start new sessions as usual.
+//
+// Returns an error wrapping [state.ErrNoState] if there is no checkpoint for the story,
+// [ErrStatusDrift] if the story was moved since the checkpoint was written, and
+// [ErrNoSession] if continueSession is set but there is no session to continue.
+func (e *Executor) Retry(ctx context.Context, storyKey string, continueSession bool) error {
+	_, err := e.RetryWithResult(ctx, storyKey, continueSession)
+	return err
+}
+
+// RetryWithResult runs the failed story again like [Executor.Retry] and also
+// returns an [output.StoryResult], like [Executor.ExecuteWithResult]. Steps holds
+// only the steps run by the retry.
+func (e *Executor) RetryWithResult(ctx context.Context, storyKey string, continueSession bool) (output.StoryResult, error) {
+	start := time.Now()
+	results, err := e.retry(ctx, storyKey, continueSession)
+	return storyResult(storyKey, start, results, err), err
+}
+
+// retry implements [Executor.RetryWithResult] and returns the results of the
+// steps that ran.
+func (e *Executor) retry(ctx context.Context, storyKey string, continueSession bool) ([]output.StepResult, error) {
+	if e.stateStore == nil {
+		return nil, fmt.Errorf("%w for story %s", state.ErrNoState, storyKey)
 	}
 
+	saved, err := e.stateStore.Load()
+	if err != nil {
+		if errors.Is(err, state.ErrNoState) {
+			return nil, fmt.Errorf("%w for story %s", state.ErrNoState, storyKey)
+		}
+		return nil, err
+	}
+	if saved.StoryKey != storyKey {
+		return nil, fmt.Errorf("%w for story %s (the checkpoint is for %s)", state.ErrNoState, storyKey, saved.StoryKey)
+	}
+
+	startIndex, steps, err := e.resumePoint(saved)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionID string
+	if continueSession {
+		workflow := steps[startIndex].Workflow
+		if _, ok := e.runner.(SessionRunner); !ok {
+			return nil, fmt.Errorf("%w: the workflow runner cannot resume sessions", ErrNoSession)
+		}
+		sessionID = saved.Sessions[workflow]
+		if sessionID == "" {
+			return nil, fmt.Errorf("%w: no session recorded for %s of story %s", ErrNoSession, workflow, storyKey)
+		}
+	}
+
+	return e.runSteps(ctx, storyKey, status.Status(saved.StartStatus), steps, startIndex, saved.Sessions, sessionID)
+}
+
+// resumePoint validates a checkpoint against the current sprint status and returns
+// the index of the next step to run together with the full lifecycle.
+func (e *Executor) resumePoint(saved state.State) (int, []router.LifecycleStep, error) {
+	steps, err := e.router.GetLifecycle(status.Status(saved.StartStatus))
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid saved state for story %s: %w", saved.StoryKey, err)
+	}
+	if len(steps) != saved.TotalSteps || saved.StepIndex < 0 || saved.StepIndex >= len(steps) {
+		return 0, nil, fmt.Errorf("%w: lifecycle for %s no longer matches saved step %d/%d",
+			ErrStatusDrift, saved.StoryKey, saved.StepIndex+1, saved.TotalSteps)
+	}
+
+	currentStatus, err := e.statusReader.GetStoryStatus(saved.StoryKey)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	expected := status.Status(saved.StartStatus)
+	if saved.StepIndex > 0 {
+		expected = steps[saved.StepIndex-1].NextStatus
+	}
+
+	switch {
+	case currentStatus == expected:
+		return saved.StepIndex, steps, nil
+	case currentStatus == steps[saved.StepIndex].NextStatus:
+		// The checkpointed step finished but the next checkpoint was never written.
+		return saved.StepIndex + 1, steps, nil
+	default:
+		return 0, nil, fmt.Errorf("%w: story %s is %s, expected %s",
+			ErrStatusDrift, saved.StoryKey, currentStatus, expected)
+	}
+}
+
+// runSteps executes steps[startIndex:] in order, checkpointing before each step
+// and clearing the checkpoint once the final step completes. It returns the
+// results of the steps that ran.
+//
+// Checkpoints carry the Claude session of every step that reported one, starting
+// from sessions. If resumeSession is set, the first step continues that session.
+//
+// If a stop is requested, runSteps returns [ErrInterrupted] before starting the
+// next step, leaving the checkpoint at that step. A step that fails after a stop
+// was requested, e.g. because ctx was canceled or it was not retried, is reported
+// as a [StepError] wrapped in [ErrInterrupted].
+func (e *Executor) runSteps(ctx context.Context, storyKey string, startStatus status.Status, steps []router.LifecycleStep, startIndex int, sessions map[string]string, resumeSession string) ([]output.StepResult, error) {
 	// Get total steps count for progress reporting
 	totalSteps := len(steps)
+	results := make([]output.StepResult, 0, totalSteps-startIndex)
 
 	// Execute each step in sequence
-	for i, step := range steps {
+	for i := startIndex; i < totalSteps; i++ {
+		step := steps[i]
+
+		if err := e.checkpoint(storyKey, startStatus, i, totalSteps, sessions); err != nil {
+			return results, err
+		}
+
+		if interrupted(ctx) {
+			return results, fmt.Errorf("%w before %s", ErrInterrupted, step.Workflow)
+		}
+
 		// Call progress callback if set
 		if e.progressCallback != nil {
 			e.progressCallback(i+1, totalSteps, step.Workflow)
 		}
 
+		fromStatus := startStatus
+		if i > 0 {
+			fromStatus = steps[i-1].NextStatus
+		}
+
+		// fail ends the step with the failed result of its last run.
+		fail := func(result output.StepResult) error {
+			// Record the failed step's session so a retry can continue it.
+			if result.SessionID != "" {
+				if err := e.checkpoint(storyKey, startStatus, i, totalSteps, sessions); err != nil {
+					return err
+				}
+			}
+			stepErr := &StepError{Workflow: result.Name, ExitCode: result.ExitCode, Attempts: len(result.Attempts), Reason: result.Reason}
+			if interrupted(ctx) {
+				return fmt.Errorf("%w: %w", ErrInterrupted, stepErr)
+			}
+			return stepErr
+		}
+
+		// record adds the result of a workflow run and fails the step if
+		// the run failed.
+		record := func(result output.StepResult) error {
+			result.FromStatus = string(fromStatus)
+			results = append(results, result)
+
+			if result.SessionID != "" {
+				sessions = withSession(sessions, result.Name, result.SessionID)
+			}
+			if result.ExitCode == 0 {
+				return nil
+			}
+			return fail(result)
+		}
+
 		// Run the workflow
-		exitCode := e.runner.RunSingle(ctx, step.Workflow, storyKey)
-		if exitCode != 0 {
-			return fmt.Errorf("workflow failed: %s returned exit code %d", step.Workflow, exitCode)
+		var result output.StepResult
+		if i == startIndex && resumeSession != "" {
+			result = e.runner.(SessionRunner).ResumeStep(ctx, step.Workflow, storyKey, resumeSession)
+		} else {
+			result = e.runStep(ctx, step.Workflow, storyKey)
+		}
+		if err := record(result); err != nil {
+			return results, err
+		}
+		if step.Fix != "" {
+			if err := e.reviewLoop(ctx, storyKey, step, i+1, totalSteps, &results, record); err != nil {
+				return results, err
+			}
+		}
+
+		if e.requiresTasks(step) {
+			last := &results[len(results)-1]
+			if err := e.checkTasks(storyKey, last); err != nil {
+				return results, err
+			}
+			if !last.Success {
+				return results, fail(*last)
+			}
+		}
+
+		if e.guard != nil {
+			if err := e.guard.AfterStep(storyKey, step.Workflow); err != nil {
+				last := &results[len(results)-1]
+				last.Success = false
+				last.ExitCode = 1
+				last.Reason = err.Error()
+				return results, fail(*last)
+			}
 		}
 
 		// Update status after successful workflow
 		if err := e.statusWriter.UpdateStatus(storyKey, step.NextStatus); err != nil {
+			return results, err
+		}
+		results[len(results)-1].ToStatus = string(step.NextStatus)
+	}
+
+	if e.stateStore != nil {
+		if err := e.stateStore.Clear(); err != nil {
+			return results, fmt.Errorf("failed to clear lifecycle state: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// reviewLoop repeats the review step that produced the last of results while
+// its review reports findings: the fix workflow runs, then the review again.
+// Each run is added to results with record, and the number of findings of
+// each review is stored in its result.
+//
+// The step fails with a [StepError] for the review if findings remain after
+// [router.LifecycleStep.Iterations] reviews.
+func (e *Executor) reviewLoop(ctx context.Context, storyKey string, step router.LifecycleStep, stepIndex, totalSteps int, results *[]output.StepResult, record func(output.StepResult) error) error {
+	for iteration := 1; ; iteration++ {
+		review := &(*results)[len(*results)-1]
+		findings, err := e.findings.CountFindings(storyKey, *review)
+		if err != nil {
 			return err
 		}
+		review.Findings = findings
+		if findings == 0 {
+			return nil
+		}
+
+		if iteration >= step.Iterations() {
+			review.Success = false
+			review.ExitCode = 1
+			review.Reason = fmt.Sprintf("%s still open after %s",
+				pluralize(findings, "finding", "findings"), pluralize(iteration, "review", "reviews"))
+			return &StepError{Workflow: step.Workflow, ExitCode: review.ExitCode, Attempts: len(review.Attempts), Reason: review.Reason}
+		}
+
+		for _, workflow := range []string{step.Fix, step.Workflow} {
+			if interrupted(ctx) {
+				return fmt.Errorf("%w before %s", ErrInterrupted, workflow)
+			}
+			if e.progressCallback != nil {
+				e.progressCallback(stepIndex, totalSteps, workflow)
+			}
+			if err := record(e.runStep(ctx, workflow, storyKey)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// requiresTasks reports whether every task of a story must be done before
+// step sets its next status: if the step requires it, or the status is
+// terminal.
+func (e *Executor) requiresTasks(step router.LifecycleStep) bool {
+	return step.RequireTasks || e.router.IsTerminal(step.NextStatus)
+}
+
+// checkTasks marks result as failed, listing the open tasks, if the story
+// has any.
+func (e *Executor) checkTasks(storyKey string, result *output.StepResult) error {
+	open, err := e.tasks.IncompleteTasks(storyKey)
+	if err != nil {
+		return err
+	}
+	if len(open) == 0 {
+		return nil
+	}
+
+	result.Success = false
+	result.ExitCode = 1
+	result.Reason = fmt.Sprintf("%s not done in the story file", pluralize(len(open), "task", "tasks"))
+	result.IncompleteTasks = make([]string, len(open))
+	for i, task := range open {
+		result.IncompleteTasks[i] = task.Text
+	}
+	return nil
+}
+
+// pluralize formats n with the singular or plural noun.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// runStep runs a single workflow, using [StepRunner] when the runner supports it.
+func (e *Executor) runStep(ctx context.Context, workflowName, storyKey string) output.StepResult {
+	if runner, ok := e.runner.(StepRunner); ok {
+		return runner.RunStep(ctx, workflowName, storyKey)
 	}
 
+	start := time.Now()
+	exitCode := e.runner.RunSingle(ctx, workflowName, storyKey)
+	duration := time.Since(start)
+
+	return output.StepResult{
+		Name:     workflowName,
+		Duration: duration,
+		Success:  exitCode == 0,
+		ExitCode: exitCode,
+		Attempts: []output.Attempt{{ExitCode: exitCode, Duration: duration}},
+	}
+}
+
+// withSession returns a copy of sessions with the session of workflow set.
+func withSession(sessions map[string]string, workflow, sessionID string) map[string]string {
+	updated := make(map[string]string, len(sessions)+1)
+	for k, v := range sessions {
+		updated[k] = v
+	}
+	updated[workflow] = sessionID
+	return updated
+}
+
+// checkpoint records that the step at stepIndex is about to run.
+func (e *Executor) checkpoint(storyKey string, startStatus status.Status, stepIndex, totalSteps int, sessions map[string]string) error {
+	if e.stateStore == nil {
+		return nil
+	}
+
+	err := e.stateStore.Save(state.State{
+		StoryKey:    storyKey,
+		StepIndex:   stepIndex,
+		TotalSteps:  totalSteps,
+		StartStatus: string(startStatus),
+		Sessions:    sessions,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save lifecycle state: %w", err)
+	}
 	return nil
 }
 
@@ -147,7 +733,7 @@ func (e *Executor) GetSteps(storyKey string) ([]router.LifecycleStep, error) {
 	}
 
 	// Get lifecycle steps from current status
-	steps, err := router.GetLifecycle(currentStatus)
+	steps, err := e.router.GetLifecycle(currentStatus)
 	if err != nil {
 		return nil, err // Returns router.ErrStoryComplete for done stories
 	}
@@ -3,10 +3,14 @@ package lifecycle
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"bmad-automate/internal/output"
 	"bmad-automate/internal/router"
+	"bmad-automate/internal/state"
 	"bmad-automate/internal/status"
+	"bmad-automate/internal/story"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -71,6 +75,35 @@ func (m *MockStatusWriter) UpdateStatus(storyKey string, newStatus status.Status
 	return nil // success by default
 }
 
+// MockStateStore implements StateStore for testing.
+type MockStateStore struct {
+	// Saved is the current checkpoint, nil when cleared.
+	Saved *state.State
+	// History records every saved checkpoint in order.
+	History []state.State
+	// Cleared counts Clear calls.
+	Cleared int
+}
+
+func (m *MockStateStore) Save(s state.State) error {
+	m.Saved = &s
+	m.History = append(m.History, s)
+	return nil
+}
+
+func (m *MockStateStore) Load() (state.State, error) {
+	if m.Saved == nil {
+		return state.State{}, state.ErrNoState
+	}
+	return *m.Saved, nil
+}
+
+func (m *MockStateStore) Clear() error {
+	m.Saved = nil
+	m.Cleared++
+	return nil
+}
+
 func TestNewExecutor(t *testing.T) {
 	runner := &MockWorkflowRunner{}
 	reader := &MockStatusReader{}
@@ -323,7 +356,7 @@ func TestGetSteps(t *testing.T) {
 			currentStatus: status.StatusBacklog,
 			wantSteps: []router.LifecycleStep{
 				{Workflow: "create-story", NextStatus: status.StatusReadyForDev},
-				{Workflow: "dev-story", NextStatus: status.StatusReview},
+				{Workflow: "dev-story", NextStatus: status.StatusReview, RequireTasks: true},
 				{Workflow: "code-review", NextStatus: status.StatusDone},
 				{Workflow: "git-commit", NextStatus: status.StatusDone},
 			},
@@ -333,7 +366,7 @@ func TestGetSteps(t *testing.T) {
 			storyKey:      "EPIC-1-story",
 			currentStatus: status.StatusReadyForDev,
 			wantSteps: []router.LifecycleStep{
-				{Workflow: "dev-story", NextStatus: status.StatusReview},
+				{Workflow: "dev-story", NextStatus: status.StatusReview, RequireTasks: true},
 				{Workflow: "code-review", NextStatus: status.StatusDone},
 				{Workflow: "git-commit", NextStatus: status.StatusDone},
 			},
@@ -400,3 +433,828 @@ func TestGetSteps(t *testing.T) {
 		})
 	}
 }
+
+func TestExecute_Checkpoints(t *testing.T) {
+	t.Run("checkpoint before each step and clear on success", func(t *testing.T) {
+		runner := &MockWorkflowRunner{}
+		reader := &MockStatusReader{
+			GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+				return status.StatusReview, nil
+			},
+		}
+		store := &MockStateStore{}
+
+		executor := NewExecutor(runner, reader, &MockStatusWriter{})
+		executor.SetStateStore(store)
+
+		err := executor.Execute(context.Background(), "EPIC-1-story")
+		require.NoError(t, err)
+
+		assert.Equal(t, []state.State{
+			{StoryKey: "EPIC-1-story", StepIndex: 0, TotalSteps: 2, StartStatus: "review"},
+			{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 2, StartStatus: "review"},
+		}, store.History)
+		assert.Nil(t, store.Saved, "checkpoint should be cleared after completion")
+		assert.Equal(t, 1, store.Cleared)
+	})
+
+	t.Run("failed step leaves checkpoint at that step", func(t *testing.T) {
+		runner := &MockWorkflowRunner{
+			RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+				if workflowName == "dev-story" {
+					return 1
+				}
+				return 0
+			},
+		}
+		reader := &MockStatusReader{}
+		store := &MockStateStore{}
+
+		executor := NewExecutor(runner, reader, &MockStatusWriter{})
+		executor.SetStateStore(store)
+
+		err := executor.Execute(context.Background(), "EPIC-1-story")
+		require.Error(t, err)
+
+		require.NotNil(t, store.Saved)
+		assert.Equal(t, state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"}, *store.Saved)
+		assert.Zero(t, store.Cleared)
+	})
+}
+
+func TestExecute_CustomRouter(t *testing.T) {
+	runner := &MockWorkflowRunner{}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetRouter(router.New(
+		[]status.Status{status.StatusReview, "qa", status.StatusDone},
+		[]status.Status{status.StatusDone},
+		map[status.Status][]router.LifecycleStep{
+			status.StatusReview: {{Workflow: "code-review", NextStatus: "qa"}},
+			"qa":                {{Workflow: "security-review", NextStatus: status.StatusDone}},
+		},
+	))
+
+	err := executor.Execute(context.Background(), "EPIC-1-story")
+	require.NoError(t, err)
+
+	require.Len(t, runner.Calls, 2)
+	assert.Equal(t, "code-review", runner.Calls[0].WorkflowName)
+	assert.Equal(t, "security-review", runner.Calls[1].WorkflowName)
+	require.Len(t, writer.Calls, 2)
+	assert.Equal(t, status.Status("qa"), writer.Calls[0].NewStatus)
+	assert.Equal(t, status.StatusDone, writer.Calls[1].NewStatus)
+}
+
+// mockStepRunner implements StepRunner, reporting retry attempts per workflow.
+type mockStepRunner struct {
+	MockWorkflowRunner
+	attempts map[string][]int
+	reasons  map[string]string
+}
+
+func (m *mockStepRunner) RunStep(ctx context.Context, workflowName, storyKey string) output.StepResult {
+	result := output.StepResult{Name: workflowName}
+	codes, ok := m.attempts[workflowName]
+	if !ok {
+		codes = []int{0}
+	}
+	for _, code := range codes {
+		result.Attempts = append(result.Attempts, output.Attempt{ExitCode: code})
+		result.ExitCode = code
+	}
+	result.Reason = m.reasons[workflowName]
+	result.Success = result.ExitCode == 0
+	return result
+}
+
+func TestExecuteWithResult_Steps(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+
+	t.Run("step runner attempts are reported", func(t *testing.T) {
+		runner := &mockStepRunner{attempts: map[string][]int{"git-commit": {128, 0}}}
+		executor := NewExecutor(runner, reader, &MockStatusWriter{})
+
+		result, err := executor.ExecuteWithResult(context.Background(), "EPIC-1-story")
+
+		require.NoError(t, err)
+		require.Len(t, result.Steps, 2)
+		assert.Equal(t, "code-review", result.Steps[0].Name)
+		assert.Len(t, result.Steps[1].Attempts, 2)
+		assert.Equal(t, 1, result.Retries())
+		assert.Empty(t, runner.Calls, "RunSingle should not be used when RunStep is available")
+	})
+
+	t.Run("status transitions are reported", func(t *testing.T) {
+		executor := NewExecutor(&mockStepRunner{}, reader, &MockStatusWriter{})
+
+		result, err := executor.ExecuteWithResult(context.Background(), "EPIC-1-story")
+
+		require.NoError(t, err)
+		require.Len(t, result.Steps, 2)
+		assert.Equal(t, "review", result.Steps[0].FromStatus)
+		assert.Equal(t, "done", result.Steps[0].ToStatus)
+		assert.Equal(t, "done", result.Steps[1].FromStatus)
+		assert.Equal(t, "done", result.Steps[1].ToStatus)
+	})
+
+	t.Run("failed step applies no status", func(t *testing.T) {
+		runner := &mockStepRunner{attempts: map[string][]int{"code-review": {1}}}
+		executor := NewExecutor(runner, reader, &MockStatusWriter{})
+
+		result, err := executor.ExecuteWithResult(context.Background(), "EPIC-1-story")
+
+		require.Error(t, err)
+		require.Len(t, result.Steps, 1)
+		assert.Equal(t, "review", result.Steps[0].FromStatus)
+		assert.Empty(t, result.Steps[0].ToStatus)
+	})
+
+	t.Run("failed retries end in step error", func(t *testing.T) {
+		runner := &mockStepRunner{attempts: map[string][]int{"code-review": {1, 1, 1}}}
+		writer := &MockStatusWriter{}
+		executor := NewExecutor(runner, reader, writer)
+
+		result, err := executor.ExecuteWithResult(context.Background(), "EPIC-1-story")
+
+		var stepErr *StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.Equal(t, 3, stepErr.Attempts)
+		assert.Contains(t, err.Error(), "after 3 attempts")
+		assert.Equal(t, "code-review", result.FailedAt)
+		require.Len(t, result.Steps, 1)
+		assert.Empty(t, writer.Calls)
+	})
+
+	t.Run("stopped step reports its reason", func(t *testing.T) {
+		runner := &mockStepRunner{
+			attempts: map[string][]int{"code-review": {-1}},
+			reasons:  map[string]string{"code-review": "run budget exceeded: $5.10 spent (limit $5.00)"},
+		}
+		writer := &MockStatusWriter{}
+		executor := NewExecutor(runner, reader, writer)
+
+		result, err := executor.ExecuteWithResult(context.Background(), "EPIC-1-story")
+
+		var stepErr *StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.Equal(t, "workflow failed: code-review stopped: run budget exceeded: $5.10 spent (limit $5.00)", err.Error())
+		assert.Equal(t, "code-review", result.FailedAt)
+		assert.Equal(t, stepErr.Reason, result.Reason())
+		assert.Empty(t, writer.Calls, "status should not advance after a stopped step")
+	})
+
+	t.Run("plain runner gets a single attempt per step", func(t *testing.T) {
+		executor := NewExecutor(&MockWorkflowRunner{}, reader, &MockStatusWriter{})
+
+		result, err := executor.ExecuteWithResult(context.Background(), "EPIC-1-story")
+
+		require.NoError(t, err)
+		require.Len(t, result.Steps, 2)
+		for _, step := range result.Steps {
+			assert.True(t, step.Success)
+			assert.Len(t, step.Attempts, 1)
+		}
+	})
+}
+
+func TestResume(t *testing.T) {
+	tests := []struct {
+		name          string
+		saved         *state.State
+		currentStatus status.Status
+		wantErr       error
+		wantWorkflows []string
+	}{
+		{
+			name:    "no saved state returns ErrNoState",
+			wantErr: state.ErrNoState,
+		},
+		{
+			name:          "resumes from saved step",
+			saved:         &state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"},
+			currentStatus: status.StatusReadyForDev,
+			wantWorkflows: []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:          "skips step that finished before the next checkpoint",
+			saved:         &state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"},
+			currentStatus: status.StatusReview,
+			wantWorkflows: []string{"code-review", "git-commit"},
+		},
+		{
+			name:          "status drift is rejected",
+			saved:         &state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"},
+			currentStatus: status.StatusBacklog,
+			wantErr:       ErrStatusDrift,
+		},
+		{
+			name:          "mismatched step count is rejected",
+			saved:         &state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 7, StartStatus: "backlog"},
+			currentStatus: status.StatusReadyForDev,
+			wantErr:       ErrStatusDrift,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &MockWorkflowRunner{}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return tt.currentStatus, nil
+				},
+			}
+			store := &MockStateStore{Saved: tt.saved}
+
+			executor := NewExecutor(runner, reader, &MockStatusWriter{})
+			executor.SetStateStore(store)
+
+			err := executor.Resume(context.Background())
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, runner.Calls)
+				return
+			}
+			require.NoError(t, err)
+
+			workflows := make([]string, len(runner.Calls))
+			for i, call := range runner.Calls {
+				workflows[i] = call.WorkflowName
+				assert.Equal(t, "EPIC-1-story", call.StoryKey)
+			}
+			assert.Equal(t, tt.wantWorkflows, workflows)
+			assert.Nil(t, store.Saved, "checkpoint should be cleared after completion")
+		})
+	}
+}
+
+func TestResume_NoStateStore(t *testing.T) {
+	executor := NewExecutor(&MockWorkflowRunner{}, &MockStatusReader{}, &MockStatusWriter{})
+
+	err := executor.Resume(context.Background())
+
+	assert.ErrorIs(t, err, state.ErrNoState)
+}
+
+func TestResumeWithResult(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	store := &MockStateStore{Saved: &state.State{StoryKey: "EPIC-1-story", StepIndex: 2, TotalSteps: 4, StartStatus: "backlog"}}
+	executor := NewExecutor(&mockStepRunner{attempts: map[string][]int{"git-commit": {1}}}, reader, &MockStatusWriter{})
+	executor.SetStateStore(store)
+
+	result, err := executor.ResumeWithResult(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, "EPIC-1-story", result.Key)
+	assert.False(t, result.Success)
+	assert.Equal(t, "git-commit", result.FailedAt)
+	require.Len(t, result.Steps, 2, "only the resumed steps should be reported")
+	assert.Equal(t, "code-review", result.Steps[0].Name)
+	assert.Equal(t, "git-commit", result.Steps[1].Name)
+}
+
+// mockSessionRunner implements SessionRunner, reporting a session per run.
+type mockSessionRunner struct {
+	mockStepRunner
+	resumed  []string
+	sessions int
+}
+
+func (m *mockSessionRunner) RunStep(ctx context.Context, workflowName, storyKey string) output.StepResult {
+	m.sessions++
+	result := m.mockStepRunner.RunStep(ctx, workflowName, storyKey)
+	result.SessionID = fmt.Sprintf("session-%d", m.sessions)
+	return result
+}
+
+func (m *mockSessionRunner) ResumeStep(ctx context.Context, workflowName, storyKey, sessionID string) output.StepResult {
+	m.resumed = append(m.resumed, workflowName+":"+sessionID)
+	result := m.mockStepRunner.RunStep(ctx, workflowName, storyKey)
+	result.SessionID = sessionID
+	return result
+}
+
+func TestExecute_CheckpointSessions(t *testing.T) {
+	runner := &mockSessionRunner{mockStepRunner: mockStepRunner{attempts: map[string][]int{"dev-story": {1}}}}
+	store := &MockStateStore{}
+	executor := NewExecutor(runner, &MockStatusReader{}, &MockStatusWriter{})
+	executor.SetStateStore(store)
+
+	err := executor.Execute(context.Background(), "EPIC-1-story")
+	require.Error(t, err)
+
+	assert.Equal(t, []state.State{
+		{StoryKey: "EPIC-1-story", StepIndex: 0, TotalSteps: 4, StartStatus: "backlog"},
+		{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog", Sessions: map[string]string{"create-story": "session-1"}},
+		{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog", Sessions: map[string]string{"create-story": "session-1", "dev-story": "session-2"}},
+	}, store.History, "the failed step's session should be saved")
+}
+
+func TestRetry(t *testing.T) {
+	saved := state.State{
+		StoryKey:    "EPIC-1-story",
+		StepIndex:   1,
+		TotalSteps:  4,
+		StartStatus: "backlog",
+		Sessions:    map[string]string{"create-story": "session-a", "dev-story": "session-b"},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReadyForDev, nil
+		},
+	}
+
+	tests := []struct {
+		name            string
+		storyKey        string
+		continueSession bool
+		runner          WorkflowRunner
+		sessions        map[string]string
+		wantErr         error
+		wantResumed     []string
+	}{
+		{
+			name:            "continues the failed step's session",
+			storyKey:        "EPIC-1-story",
+			continueSession: true,
+			runner:          &mockSessionRunner{},
+			sessions:        saved.Sessions,
+			wantResumed:     []string{"dev-story:session-b"},
+		},
+		{
+			name:     "starts a new session by default",
+			storyKey: "EPIC-1-story",
+			runner:   &mockSessionRunner{},
+			sessions: saved.Sessions,
+		},
+		{
+			name:     "checkpoint for another story",
+			storyKey: "EPIC-2-story",
+			runner:   &mockSessionRunner{},
+			sessions: saved.Sessions,
+			wantErr:  state.ErrNoState,
+		},
+		{
+			name:            "no session recorded for the step",
+			storyKey:        "EPIC-1-story",
+			continueSession: true,
+			runner:          &mockSessionRunner{},
+			sessions:        map[string]string{"create-story": "session-a"},
+			wantErr:         ErrNoSession,
+		},
+		{
+			name:            "runner cannot resume sessions",
+			storyKey:        "EPIC-1-story",
+			continueSession: true,
+			runner:          &MockWorkflowRunner{},
+			sessions:        saved.Sessions,
+			wantErr:         ErrNoSession,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoint := saved
+			checkpoint.Sessions = tt.sessions
+			store := &MockStateStore{Saved: &checkpoint}
+			executor := NewExecutor(tt.runner, reader, &MockStatusWriter{})
+			executor.SetStateStore(store)
+
+			err := executor.Retry(context.Background(), tt.storyKey, tt.continueSession)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.NotNil(t, store.Saved, "checkpoint should be kept")
+				return
+			}
+			require.NoError(t, err)
+			if runner, ok := tt.runner.(*mockSessionRunner); ok {
+				assert.Equal(t, tt.wantResumed, runner.resumed)
+			}
+			assert.Nil(t, store.Saved, "checkpoint should be cleared after completion")
+		})
+	}
+}
+
+// reviewLoopRouter returns a router whose review status runs a review loop.
+func reviewLoopRouter(maxIterations int) *router.Router {
+	return router.New(
+		[]status.Status{status.StatusReview, status.StatusDone},
+		[]status.Status{status.StatusDone},
+		map[status.Status][]router.LifecycleStep{
+			status.StatusReview: {
+				{Workflow: "code-review", NextStatus: status.StatusDone, Fix: "dev-story", MaxIterations: maxIterations},
+				{Workflow: "git-commit", NextStatus: status.StatusDone},
+			},
+		},
+	)
+}
+
+// reviewRunner implements StepRunner, reporting the next of findings in the
+// final text of each code-review run.
+type reviewRunner struct {
+	MockWorkflowRunner
+	findings []int
+	fixCode  int
+}
+
+func (m *reviewRunner) RunStep(ctx context.Context, workflowName, storyKey string) output.StepResult {
+	exitCode := m.RunSingle(ctx, workflowName, storyKey)
+	result := output.StepResult{Name: workflowName, SessionID: "session-" + workflowName}
+	switch workflowName {
+	case "code-review":
+		result.FinalText = fmt.Sprintf("Done.\n%s %d", FindingsMarker, m.findings[0])
+		m.findings = m.findings[1:]
+	case "dev-story":
+		exitCode = m.fixCode
+	}
+	result.ExitCode = exitCode
+	result.Success = exitCode == 0
+	result.Attempts = []output.Attempt{{ExitCode: exitCode}}
+	return result
+}
+
+func TestExecute_ReviewLoop(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxIterations int
+		findings      []int
+		fixCode       int
+		wantWorkflows []string
+		wantFindings  []int
+		wantErr       string
+		wantStatuses  []status.Status
+	}{
+		{
+			name:          "clean review runs once",
+			findings:      []int{0},
+			wantWorkflows: []string{"code-review", "git-commit"},
+			wantFindings:  []int{0, 0},
+			wantStatuses:  []status.Status{status.StatusDone, status.StatusDone},
+		},
+		{
+			name:          "findings are fixed and reviewed again",
+			findings:      []int{2, 1, 0},
+			wantWorkflows: []string{"code-review", "dev-story", "code-review", "dev-story", "code-review", "git-commit"},
+			wantFindings:  []int{2, 0, 1, 0, 0, 0},
+			wantStatuses:  []status.Status{status.StatusDone, status.StatusDone},
+		},
+		{
+			name:          "findings remain after the last review",
+			maxIterations: 2,
+			findings:      []int{2, 1},
+			wantWorkflows: []string{"code-review", "dev-story", "code-review"},
+			wantFindings:  []int{2, 0, 1},
+			wantErr:       "workflow failed: code-review stopped: 1 finding still open after 2 reviews",
+		},
+		{
+			name:          "failed fix fails the step",
+			findings:      []int{2},
+			fixCode:       3,
+			wantWorkflows: []string{"code-review", "dev-story"},
+			wantFindings:  []int{2, 0},
+			wantErr:       "workflow failed: dev-story returned exit code 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &reviewRunner{findings: tt.findings, fixCode: tt.fixCode}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return status.StatusReview, nil
+				},
+			}
+			writer := &MockStatusWriter{}
+			var progress []string
+
+			executor := NewExecutor(runner, reader, writer)
+			executor.SetRouter(reviewLoopRouter(tt.maxIterations))
+			executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+				progress = append(progress, fmt.Sprintf("%d/%d %s", stepIndex, totalSteps, workflow))
+			})
+
+			result, err := executor.ExecuteWithResult(context.Background(), "7-1-schema")
+
+			var workflows []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			assert.Equal(t, tt.wantWorkflows, workflows)
+			assert.Len(t, progress, len(tt.wantWorkflows), "progress is reported for every run")
+
+			require.Len(t, result.Steps, len(tt.wantWorkflows))
+			for i, step := range result.Steps {
+				assert.Equal(t, tt.wantFindings[i], step.Findings, "step %d", i)
+				if step.Name != "git-commit" {
+					assert.Equal(t, "review", step.FromStatus, "every run of the loop starts from the step's status")
+				}
+			}
+
+			var statuses []status.Status
+			for _, call := range writer.Calls {
+				statuses = append(statuses, call.NewStatus)
+			}
+			assert.Equal(t, tt.wantStatuses, statuses)
+
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantWorkflows[len(tt.wantWorkflows)-1], result.FailedAt)
+			assert.False(t, result.Steps[len(result.Steps)-1].Success)
+		})
+	}
+}
+
+func TestExecute_ReviewLoopInterrupted(t *testing.T) {
+	stop := make(chan struct{})
+	ctx := WithStopRequest(context.Background(), stop)
+	runner := &reviewRunner{findings: []int{2}}
+	runner.RunSingleFunc = func(ctx context.Context, workflowName, storyKey string) int {
+		close(stop)
+		return 0
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetRouter(reviewLoopRouter(0))
+
+	err := executor.Execute(ctx, "7-1-schema")
+
+	require.ErrorIs(t, err, ErrInterrupted)
+	assert.Contains(t, err.Error(), "before dev-story")
+	assert.Len(t, runner.Calls, 1)
+	assert.Empty(t, writer.Calls, "the status is not updated while findings are open")
+}
+
+// failingCounter implements FindingsCounter, always failing.
+type failingCounter struct{}
+
+func (failingCounter) CountFindings(storyKey string, result output.StepResult) (int, error) {
+	return 0, errors.New("failed to read story file: permission denied")
+}
+
+func TestExecute_ReviewLoopCounterError(t *testing.T) {
+	runner := &reviewRunner{findings: []int{0}}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetRouter(reviewLoopRouter(0))
+	executor.SetFindingsCounter(failingCounter{})
+
+	result, err := executor.ExecuteWithResult(context.Background(), "7-1-schema")
+
+	require.EqualError(t, err, "failed to read story file: permission denied")
+	assert.Equal(t, "status", result.FailedAt)
+	assert.Empty(t, writer.Calls)
+}
+
+// staticTasks implements TaskChecker, always returning the same open tasks.
+type staticTasks struct {
+	open []story.Task
+	err  error
+}
+
+func (s staticTasks) IncompleteTasks(storyKey string) ([]story.Task, error) {
+	return s.open, s.err
+}
+
+func TestExecute_IncompleteTasks(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         status.Status
+		tasks         staticTasks
+		wantErr       string
+		wantFailedAt  string
+		wantWorkflows []string
+		wantStatuses  []status.Status
+		wantOpen      []string
+	}{
+		{
+			name:          "all tasks done",
+			start:         status.StatusReadyForDev,
+			wantWorkflows: []string{"dev-story", "code-review", "git-commit"},
+			wantStatuses:  []status.Status{status.StatusReview, status.StatusDone, status.StatusDone},
+		},
+		{
+			name:          "open tasks stop the move to review",
+			start:         status.StatusBacklog,
+			tasks:         staticTasks{open: []story.Task{{Text: "Add indexes"}, {Text: "Document the schema"}}},
+			wantErr:       "workflow failed: dev-story stopped: 2 tasks not done in the story file",
+			wantFailedAt:  "dev-story",
+			wantWorkflows: []string{"create-story", "dev-story"},
+			wantStatuses:  []status.Status{status.StatusReadyForDev},
+			wantOpen:      []string{"Add indexes", "Document the schema"},
+		},
+		{
+			name:          "open tasks stop the move to done",
+			start:         status.StatusReview,
+			tasks:         staticTasks{open: []story.Task{{Text: "[AI-Review][High] Validate input"}}},
+			wantErr:       "workflow failed: code-review stopped: 1 task not done in the story file",
+			wantFailedAt:  "code-review",
+			wantWorkflows: []string{"code-review"},
+			wantOpen:      []string{"[AI-Review][High] Validate input"},
+		},
+		{
+			name:          "story file cannot be read",
+			start:         status.StatusReview,
+			tasks:         staticTasks{err: errors.New("failed to read story file: permission denied")},
+			wantErr:       "failed to read story file: permission denied",
+			wantFailedAt:  "status",
+			wantWorkflows: []string{"code-review"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &MockWorkflowRunner{}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return tt.start, nil
+				},
+			}
+			writer := &MockStatusWriter{}
+
+			executor := NewExecutor(runner, reader, writer)
+			executor.SetTaskChecker(tt.tasks)
+
+			result, err := executor.ExecuteWithResult(context.Background(), "7-1-schema")
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantFailedAt, result.FailedAt)
+
+			var workflows []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			assert.Equal(t, tt.wantWorkflows, workflows)
+
+			var statuses []status.Status
+			for _, call := range writer.Calls {
+				statuses = append(statuses, call.NewStatus)
+			}
+			assert.Equal(t, tt.wantStatuses, statuses)
+
+			last := result.Steps[len(result.Steps)-1]
+			assert.Equal(t, tt.wantOpen, last.IncompleteTasks)
+			if tt.wantOpen != nil {
+				assert.False(t, last.Success)
+				assert.Empty(t, last.ToStatus)
+			}
+		})
+	}
+}
+
+func TestExecute_IncompleteTasks_CustomLifecycle(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReadyForDev, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+	executor := NewExecutor(&MockWorkflowRunner{}, reader, writer)
+	executor.SetTaskChecker(staticTasks{open: []story.Task{{Text: "Add indexes"}}})
+	executor.SetRouter(router.New(
+		[]status.Status{"ready-for-dev", "review", "qa", "done"},
+		[]status.Status{"done"},
+		map[status.Status][]router.LifecycleStep{
+			"ready-for-dev": {{Workflow: "dev-story", NextStatus: "review"}},
+			"review":        {{Workflow: "code-review", NextStatus: "qa", RequireTasks: true}},
+			"qa":            {{Workflow: "git-commit", NextStatus: "done"}},
+		},
+	))
+
+	result, err := executor.ExecuteWithResult(context.Background(), "7-1-schema")
+
+	require.EqualError(t, err, "workflow failed: code-review stopped: 1 task not done in the story file")
+	assert.Equal(t, "code-review", result.FailedAt)
+	require.Len(t, writer.Calls, 1, "the move to review should not require tasks")
+	assert.Equal(t, status.Status("review"), writer.Calls[0].NewStatus)
+}
+
+// stubGuard implements RepoGuard, failing BeforeStory with before and
+// AfterStep of the workflows in after.
+type stubGuard struct {
+	before error
+	after  map[string]error
+	checks []string
+}
+
+func (g *stubGuard) BeforeStory(storyKey string) error {
+	g.checks = append(g.checks, "before")
+	return g.before
+}
+
+func (g *stubGuard) AfterStep(storyKey, workflow string) error {
+	g.checks = append(g.checks, workflow)
+	return g.after[workflow]
+}
+
+func TestExecute_RepoGuard(t *testing.T) {
+	tests := []struct {
+		name          string
+		guard         *stubGuard
+		wantErr       string
+		wantErrIs     error
+		wantFailedAt  string
+		wantWorkflows []string
+		wantStatuses  []status.Status
+		wantChecks    []string
+	}{
+		{
+			name:          "checks pass",
+			guard:         &stubGuard{},
+			wantWorkflows: []string{"code-review", "git-commit"},
+			wantStatuses:  []status.Status{status.StatusDone, status.StatusDone},
+			wantChecks:    []string{"before", "code-review", "git-commit"},
+		},
+		{
+			name:         "refused before the story",
+			guard:        &stubGuard{before: errors.New("working tree has uncommitted changes: main.go")},
+			wantErr:      "repository check failed: working tree has uncommitted changes: main.go",
+			wantErrIs:    ErrRepoCheck,
+			wantFailedAt: "git",
+			wantChecks:   []string{"before"},
+		},
+		{
+			name:          "rejected after a workflow",
+			guard:         &stubGuard{after: map[string]error{"git-commit": errors.New("commit not pushed: story is 1 commit(s) ahead of origin/story")}},
+			wantErr:       "workflow failed: git-commit stopped: commit not pushed: story is 1 commit(s) ahead of origin/story",
+			wantFailedAt:  "git-commit",
+			wantWorkflows: []string{"code-review", "git-commit"},
+			wantStatuses:  []status.Status{status.StatusDone},
+			wantChecks:    []string{"before", "code-review", "git-commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &MockWorkflowRunner{}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return status.StatusReview, nil
+				},
+			}
+			writer := &MockStatusWriter{}
+
+			executor := NewExecutor(runner, reader, writer)
+			executor.SetRepoGuard(tt.guard)
+
+			result, err := executor.ExecuteWithResult(context.Background(), "7-1-schema")
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+			assert.Equal(t, tt.wantFailedAt, result.FailedAt)
+			assert.Equal(t, tt.wantChecks, tt.guard.checks)
+
+			var workflows []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			assert.Equal(t, tt.wantWorkflows, workflows)
+
+			var statuses []status.Status
+			for _, call := range writer.Calls {
+				statuses = append(statuses, call.NewStatus)
+			}
+			assert.Equal(t, tt.wantStatuses, statuses)
+		})
+	}
+}
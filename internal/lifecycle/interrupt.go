@@ -0,0 +1,49 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInterrupted is a sentinel error returned when a lifecycle stops early because
+// a stop was requested with [WithStopRequest] or its context was canceled.
+//
+// After a graceful stop the checkpoint points at the next step, so the story can
+// be continued with [Executor.Resume].
+var ErrInterrupted = errors.New("lifecycle interrupted")
+
+// stopRequestKey is the context key for the stop request channel.
+type stopRequestKey struct{}
+
+// WithStopRequest returns a copy of ctx that carries a stop request.
+//
+// Closing stop asks executors to stop at the next safe point: once the running
+// workflow step has finished and its status has been written, no further step or
+// story is started. Canceling the context instead stops the running step at once.
+func WithStopRequest(ctx context.Context, stop <-chan struct{}) context.Context {
+	return context.WithValue(ctx, stopRequestKey{}, stop)
+}
+
+// StopRequested reports whether the stop request carried by ctx has been made.
+// It returns false if ctx carries no stop request.
+func StopRequested(ctx context.Context) bool {
+	select {
+	case <-StopRequest(ctx):
+		return true
+	default:
+		return false
+	}
+}
+
+// StopRequest returns the channel that is closed when a stop is requested, for
+// commands that have no step to finish and should stop right away. It returns
+// nil, which blocks forever, if ctx carries no stop request.
+func StopRequest(ctx context.Context) <-chan struct{} {
+	stop, _ := ctx.Value(stopRequestKey{}).(<-chan struct{})
+	return stop
+}
+
+// interrupted reports whether execution should stop before the next step.
+func interrupted(ctx context.Context) bool {
+	return StopRequested(ctx) || ctx.Err() != nil
+}
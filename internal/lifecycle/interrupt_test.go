@@ -0,0 +1,128 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"bmad-automate/internal/state"
+	"bmad-automate/internal/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopRequested(t *testing.T) {
+	assert.False(t, StopRequested(context.Background()))
+
+	stop := make(chan struct{})
+	ctx := WithStopRequest(context.Background(), stop)
+	assert.False(t, StopRequested(ctx))
+
+	close(stop)
+	assert.True(t, StopRequested(ctx))
+}
+
+func TestExecuteWithResult_Interrupted(t *testing.T) {
+	t.Run("stop request finishes the current step", func(t *testing.T) {
+		stop := make(chan struct{})
+		runner := &MockWorkflowRunner{
+			RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+				close(stop)
+				return 0
+			},
+		}
+		writer := &MockStatusWriter{}
+		store := &MockStateStore{}
+		executor := NewExecutor(runner, &MockStatusReader{}, writer)
+		executor.SetStateStore(store)
+
+		ctx := WithStopRequest(context.Background(), stop)
+		result, err := executor.ExecuteWithResult(ctx, "EPIC-1-story")
+
+		require.ErrorIs(t, err, ErrInterrupted)
+		assert.True(t, result.Interrupted)
+		assert.False(t, result.Success)
+		assert.Empty(t, result.FailedAt)
+		require.Len(t, runner.Calls, 1, "no step should start after the stop request")
+		require.Len(t, writer.Calls, 1, "the finished step should update the status")
+		assert.Equal(t, status.StatusReadyForDev, writer.Calls[0].NewStatus)
+		require.NotNil(t, store.Saved, "checkpoint should be kept for resume")
+		assert.Equal(t, state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"}, *store.Saved)
+	})
+
+	t.Run("step failing after a stop request is interrupted", func(t *testing.T) {
+		stop := make(chan struct{})
+		runner := &MockWorkflowRunner{
+			RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+				close(stop)
+				return 1
+			},
+		}
+		store := &MockStateStore{}
+		executor := NewExecutor(runner, &MockStatusReader{}, &MockStatusWriter{})
+		executor.SetStateStore(store)
+
+		ctx := WithStopRequest(context.Background(), stop)
+		result, err := executor.ExecuteWithResult(ctx, "EPIC-1-story")
+
+		require.ErrorIs(t, err, ErrInterrupted)
+		assert.True(t, result.Interrupted)
+		assert.Equal(t, "create-story", result.FailedAt)
+		require.NotNil(t, store.Saved)
+		assert.Equal(t, 0, store.Saved.StepIndex, "resume should run the failed step again")
+	})
+
+	t.Run("cancel stops the running step", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		runner := &MockWorkflowRunner{
+			RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+				cancel()
+				return -1
+			},
+		}
+		writer := &MockStatusWriter{}
+		store := &MockStateStore{}
+		executor := NewExecutor(runner, &MockStatusReader{}, writer)
+		executor.SetStateStore(store)
+
+		result, err := executor.ExecuteWithResult(ctx, "EPIC-1-story")
+
+		require.ErrorIs(t, err, ErrInterrupted)
+		var stepErr *StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.True(t, result.Interrupted)
+		assert.Equal(t, "create-story", result.FailedAt)
+		assert.Empty(t, writer.Calls)
+		require.NotNil(t, store.Saved)
+		assert.Equal(t, 0, store.Saved.StepIndex)
+	})
+}
+
+func TestResumeWithResult_Interrupted(t *testing.T) {
+	stop := make(chan struct{})
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			close(stop)
+			return 0
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReadyForDev, nil
+		},
+	}
+	store := &MockStateStore{Saved: &state.State{StoryKey: "EPIC-1-story", StepIndex: 1, TotalSteps: 4, StartStatus: "backlog"}}
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	executor.SetStateStore(store)
+
+	ctx := WithStopRequest(context.Background(), stop)
+	result, err := executor.ResumeWithResult(ctx)
+
+	require.ErrorIs(t, err, ErrInterrupted)
+	assert.Equal(t, "EPIC-1-story", result.Key)
+	assert.True(t, result.Interrupted)
+	require.Len(t, result.Steps, 1, "the finished step should be reported")
+	assert.Equal(t, "dev-story", result.Steps[0].Name)
+	require.NotNil(t, store.Saved, "checkpoint should be kept for the next resume")
+	assert.Equal(t, 2, store.Saved.StepIndex)
+}
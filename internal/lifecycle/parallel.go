@@ -0,0 +1,256 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/router"
+	"bmad-automate/internal/status"
+)
+
+// Workspace is an isolated environment in which a single story lifecycle runs.
+//
+// In production each workspace is a separate git worktree with its own Claude
+// executor, so stories running at the same time do not see each other's file
+// changes. Output produced inside the workspace is buffered and written out in
+// one piece when the story finishes, so logs from different stories do not
+// interleave.
+type Workspace struct {
+	// Runner executes workflows inside the workspace.
+	Runner WorkflowRunner
+
+	// Progress is called before each workflow step. Optional.
+	Progress ProgressCallback
+
+	// Findings counts the findings of review loops in the workspace, whose
+	// story files may differ from the main working tree. Optional; the
+	// executor's default is used when nil.
+	Findings FindingsCounter
+
+	// Tasks finds the open tasks of the story in the workspace. Optional;
+	// the executor's default is used when nil.
+	Tasks TaskChecker
+
+	// Guard checks the repository of the workspace around the story.
+	// Optional; the repository is not checked when nil.
+	Guard RepoGuard
+
+	// Output holds the buffered output of the story. It is written to the
+	// [ParallelExecutor] output once the story finishes.
+	Output io.WriterTo
+
+	// Release is called once the story finishes. success reports whether the
+	// lifecycle completed, so failed workspaces can be kept for inspection.
+	// Optional.
+	Release func(success bool) error
+}
+
+// WorkspaceFactory creates the [Workspace] for a story.
+//
+// The factory is called from worker goroutines and must be safe for concurrent use.
+type WorkspaceFactory func(storyKey string) (*Workspace, error)
+
+// ParallelExecutor runs several story lifecycles at once using a fixed-size worker pool.
+//
+// Each story runs in its own [Workspace] created by a [WorkspaceFactory]. Status
+// updates from all workers are serialized through a single lock before reaching
+// the [StatusWriter], so concurrent stories never race on sprint-status.yaml.
+//
+// Like the sequential commands, ParallelExecutor is fail-fast: once a story fails,
+// no new stories are started, although stories already in flight run to completion.
+// A stop request from [WithStopRequest] likewise prevents new stories from starting,
+// and stories in flight stop after their current step. Checkpoints for resume are
+// not written in parallel mode.
+//
+// Use [NewParallelExecutor] to create an instance.
+type ParallelExecutor struct {
+	workers      int
+	statusReader StatusReader
+	statusWriter StatusWriter
+	newWorkspace WorkspaceFactory
+	router       *router.Router
+
+	outMu   sync.Mutex
+	out     io.Writer
+	printer output.Printer
+}
+
+// NewParallelExecutor creates a [ParallelExecutor] with the given number of workers.
+//
+// The reader and writer are shared by all workers; the writer is wrapped so that
+// only one status update runs at a time. Buffered story output is written to out.
+// A workers value below 1 is treated as 1. Steps are routed with [router.Default]
+// unless [ParallelExecutor.SetRouter] is called, and progress messages are
+// printed as text to out unless [ParallelExecutor.SetPrinter] is called.
+func NewParallelExecutor(workers int, reader StatusReader, writer StatusWriter, factory WorkspaceFactory, out io.Writer) *ParallelExecutor {
+	if workers < 1 {
+		workers = 1
+	}
+	return &ParallelExecutor{
+		workers:      workers,
+		statusReader: reader,
+		statusWriter: &lockedStatusWriter{writer: writer},
+		newWorkspace: factory,
+		router:       router.Default(),
+		out:          out,
+		printer:      output.NewPrinterWithWriter(out),
+	}
+}
+
+// SetRouter configures the lifecycle state machine used for every story.
+func (p *ParallelExecutor) SetRouter(r *router.Router) {
+	p.router = r
+}
+
+// SetPrinter configures the printer for progress messages. It should write to
+// the same destination as out, in the format of the workspace output.
+func (p *ParallelExecutor) SetPrinter(printer output.Printer) {
+	p.printer = printer
+}
+
+// Execute runs the lifecycle for every story and returns one result per story
+// that was processed.
+//
+// Results are returned in the order of storyKeys. Stories that were never started
+// because an earlier story failed (or ctx was canceled) have no result, so they
+// show up as pending in [output.Printer.QueueSummary]. Stories that are already
+// done are reported as skipped without creating a workspace.
+func (p *ParallelExecutor) Execute(ctx context.Context, storyKeys []string) []output.StoryResult {
+	results := make([]*output.StoryResult, len(storyKeys))
+
+	var (
+		mu      sync.Mutex
+		stopped bool
+	)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < p.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// A job may have been handed out just before another story failed.
+				mu.Lock()
+				stop := stopped
+				mu.Unlock()
+				if stop || interrupted(ctx) {
+					continue
+				}
+
+				result := p.executeStory(ctx, storyKeys[i])
+
+				mu.Lock()
+				results[i] = &result
+				if !result.Success {
+					stopped = true
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range storyKeys {
+		mu.Lock()
+		stop := stopped
+		mu.Unlock()
+		if stop || interrupted(ctx) {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	processed := make([]output.StoryResult, 0, len(storyKeys))
+	for _, r := range results {
+		if r != nil {
+			processed = append(processed, *r)
+		}
+	}
+	return processed
+}
+
+// executeStory runs one story lifecycle in a fresh workspace.
+func (p *ParallelExecutor) executeStory(ctx context.Context, storyKey string) output.StoryResult {
+	// Check for done stories before paying for a workspace.
+	currentStatus, err := p.statusReader.GetStoryStatus(storyKey)
+	if err == nil {
+		_, err = p.router.GetLifecycle(currentStatus)
+	}
+	if err != nil {
+		if errors.Is(err, router.ErrStoryComplete) {
+			p.print(func(out output.Printer) { out.Info("Story %s is already complete, skipping", storyKey) })
+			return output.StoryResult{Key: storyKey, Success: true, Skipped: true}
+		}
+		p.print(func(out output.Printer) { out.Error("running lifecycle for story %s: %v", storyKey, err) })
+		return output.StoryResult{Key: storyKey, FailedAt: "status"}
+	}
+
+	ws, err := p.newWorkspace(storyKey)
+	if err != nil {
+		p.print(func(out output.Printer) { out.Error("preparing workspace for story %s: %v", storyKey, err) })
+		return output.StoryResult{Key: storyKey, FailedAt: "workspace"}
+	}
+
+	p.print(func(out output.Printer) { out.Info("Started story %s", storyKey) })
+
+	executor := NewExecutor(ws.Runner, p.statusReader, p.statusWriter)
+	executor.SetRouter(p.router)
+	if ws.Progress != nil {
+		executor.SetProgressCallback(ws.Progress)
+	}
+	if ws.Findings != nil {
+		executor.SetFindingsCounter(ws.Findings)
+	}
+	if ws.Tasks != nil {
+		executor.SetTaskChecker(ws.Tasks)
+	}
+	if ws.Guard != nil {
+		executor.SetRepoGuard(ws.Guard)
+	}
+
+	result, err := executor.ExecuteWithResult(ctx, storyKey)
+
+	p.outMu.Lock()
+	if ws.Output != nil {
+		_, _ = ws.Output.WriteTo(p.out) //nolint:errcheck // Best-effort log flush
+	}
+	if err != nil && !result.Skipped && !result.Interrupted {
+		p.printer.Error("running lifecycle for story %s: %v", storyKey, err)
+	}
+	p.outMu.Unlock()
+
+	if ws.Release != nil {
+		if err := ws.Release(result.Success); err != nil {
+			p.print(func(out output.Printer) { out.Warning("failed to release workspace for story %s: %v", storyKey, err) })
+		}
+	}
+
+	return result
+}
+
+// print calls fn with the printer while holding the output lock, so that the
+// message does not interleave with buffered story output.
+func (p *ParallelExecutor) print(fn func(out output.Printer)) {
+	p.outMu.Lock()
+	defer p.outMu.Unlock()
+	fn(p.printer)
+}
+
+// lockedStatusWriter serializes status updates from concurrent workers.
+type lockedStatusWriter struct {
+	mu     sync.Mutex
+	writer StatusWriter
+}
+
+// UpdateStatus forwards the update to the wrapped writer while holding the lock.
+func (w *lockedStatusWriter) UpdateStatus(storyKey string, newStatus status.Status) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writer.UpdateStatus(storyKey, newStatus)
+}
@@ -0,0 +1,231 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentRunner is a WorkflowRunner that is safe for concurrent use and
+// tracks the maximum number of workflows running at the same time.
+type concurrentRunner struct {
+	out      *bytes.Buffer
+	failOn   string
+	running  *int32
+	maxSeen  *int32
+	workflow []string
+}
+
+func (r *concurrentRunner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
+	n := atomic.AddInt32(r.running, 1)
+	for {
+		max := atomic.LoadInt32(r.maxSeen)
+		if n <= max || atomic.CompareAndSwapInt32(r.maxSeen, max, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	atomic.AddInt32(r.running, -1)
+
+	r.workflow = append(r.workflow, workflowName)
+	fmt.Fprintf(r.out, "%s: %s\n", storyKey, workflowName)
+
+	if workflowName+":"+storyKey == r.failOn {
+		return 1
+	}
+	return 0
+}
+
+// mapStatusReader returns statuses from a fixed map.
+type mapStatusReader map[string]status.Status
+
+func (m mapStatusReader) GetStoryStatus(storyKey string) (status.Status, error) {
+	s, ok := m[storyKey]
+	if !ok {
+		return "", fmt.Errorf("story not found: %s", storyKey)
+	}
+	return s, nil
+}
+
+// exclusiveStatusWriter fails the test if two updates overlap.
+type exclusiveStatusWriter struct {
+	t      *testing.T
+	active int32
+	mu     sync.Mutex
+	count  int
+}
+
+func (w *exclusiveStatusWriter) UpdateStatus(storyKey string, newStatus status.Status) error {
+	if !atomic.CompareAndSwapInt32(&w.active, 0, 1) {
+		w.t.Error("concurrent status updates detected")
+	}
+	time.Sleep(time.Millisecond)
+	atomic.StoreInt32(&w.active, 0)
+
+	w.mu.Lock()
+	w.count++
+	w.mu.Unlock()
+	return nil
+}
+
+type workspaceRecorder struct {
+	mu       sync.Mutex
+	created  []string
+	released map[string]bool
+	running  int32
+	maxSeen  int32
+	failOn   string
+}
+
+func (rec *workspaceRecorder) factory(storyKey string) (*Workspace, error) {
+	rec.mu.Lock()
+	rec.created = append(rec.created, storyKey)
+	rec.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	return &Workspace{
+		Runner: &concurrentRunner{out: buf, failOn: rec.failOn, running: &rec.running, maxSeen: &rec.maxSeen},
+		Output: buf,
+		Release: func(success bool) error {
+			rec.mu.Lock()
+			defer rec.mu.Unlock()
+			if rec.released == nil {
+				rec.released = map[string]bool{}
+			}
+			rec.released[storyKey] = success
+			return nil
+		},
+	}, nil
+}
+
+func TestParallelExecutor_RunsAllStories(t *testing.T) {
+	reader := mapStatusReader{
+		"1-1-a": status.StatusReview,
+		"1-2-b": status.StatusReview,
+		"1-3-c": status.StatusDone,
+		"1-4-d": status.StatusReview,
+	}
+	writer := &exclusiveStatusWriter{t: t}
+	rec := &workspaceRecorder{}
+	out := &bytes.Buffer{}
+
+	pool := NewParallelExecutor(2, reader, writer, rec.factory, out)
+	results := pool.Execute(context.Background(), []string{"1-1-a", "1-2-b", "1-3-c", "1-4-d"})
+
+	require.Len(t, results, 4)
+	for i, key := range []string{"1-1-a", "1-2-b", "1-3-c", "1-4-d"} {
+		assert.Equal(t, key, results[i].Key, "results should keep input order")
+		assert.True(t, results[i].Success)
+	}
+	assert.True(t, results[2].Skipped, "done story should be skipped")
+
+	// No workspace for the done story
+	assert.ElementsMatch(t, []string{"1-1-a", "1-2-b", "1-4-d"}, rec.created)
+	assert.Equal(t, map[string]bool{"1-1-a": true, "1-2-b": true, "1-4-d": true}, rec.released)
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&rec.maxSeen), int32(2), "should not exceed worker count")
+	assert.Equal(t, 6, writer.count, "two status updates per review story")
+
+	// Each story's buffered output is flushed as one contiguous block
+	for _, key := range []string{"1-1-a", "1-2-b", "1-4-d"} {
+		assert.Contains(t, out.String(), key+": code-review\n"+key+": git-commit\n")
+	}
+}
+
+func TestParallelExecutor_FailFast(t *testing.T) {
+	reader := mapStatusReader{
+		"1-1-a": status.StatusReview,
+		"1-2-b": status.StatusReview,
+		"1-3-c": status.StatusReview,
+	}
+	rec := &workspaceRecorder{failOn: "code-review:1-1-a"}
+
+	pool := NewParallelExecutor(1, reader, &exclusiveStatusWriter{t: t}, rec.factory, &bytes.Buffer{})
+	results := pool.Execute(context.Background(), []string{"1-1-a", "1-2-b", "1-3-c"})
+
+	require.Len(t, results, 1, "no new stories should start after a failure")
+	assert.False(t, results[0].Success)
+	assert.Equal(t, "code-review", results[0].FailedAt)
+	assert.Equal(t, map[string]bool{"1-1-a": false}, rec.released)
+}
+
+func TestParallelExecutor_StopRequest(t *testing.T) {
+	reader := mapStatusReader{
+		"1-1-a": status.StatusReview,
+		"1-2-b": status.StatusReview,
+	}
+	stop := make(chan struct{})
+	var calls []string
+	factory := func(storyKey string) (*Workspace, error) {
+		return &Workspace{
+			Runner: &MockWorkflowRunner{
+				RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+					calls = append(calls, workflowName+":"+storyKey)
+					close(stop)
+					return 0
+				},
+			},
+		}, nil
+	}
+
+	pool := NewParallelExecutor(1, reader, &exclusiveStatusWriter{t: t}, factory, &bytes.Buffer{})
+	results := pool.Execute(WithStopRequest(context.Background(), stop), []string{"1-1-a", "1-2-b"})
+
+	require.Len(t, results, 1, "no new stories should start after a stop request")
+	assert.True(t, results[0].Interrupted)
+	assert.Equal(t, []string{"code-review:1-1-a"}, calls, "the current step should finish")
+}
+
+func TestParallelExecutor_WorkspaceError(t *testing.T) {
+	reader := mapStatusReader{"1-1-a": status.StatusReview}
+	factory := func(storyKey string) (*Workspace, error) {
+		return nil, fmt.Errorf("worktree exists")
+	}
+	out := &bytes.Buffer{}
+
+	pool := NewParallelExecutor(2, reader, &exclusiveStatusWriter{t: t}, factory, out)
+	results := pool.Execute(context.Background(), []string{"1-1-a"})
+
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Success)
+	assert.Equal(t, "workspace", results[0].FailedAt)
+	assert.True(t, strings.Contains(out.String(), "worktree exists"))
+}
+
+func TestParallelExecutor_SetPrinter(t *testing.T) {
+	reader := mapStatusReader{"1-1-a": status.StatusDone, "1-1-b": status.StatusReview}
+	factory := func(storyKey string) (*Workspace, error) {
+		return nil, fmt.Errorf("worktree exists")
+	}
+	out := &bytes.Buffer{}
+
+	pool := NewParallelExecutor(1, reader, &exclusiveStatusWriter{t: t}, factory, out)
+	pool.SetPrinter(output.NewJSONPrinterWithWriter(out))
+	pool.Execute(context.Background(), []string{"1-1-a", "1-1-b"})
+
+	var types []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var event output.JSONEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event), "line: %s", line)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{output.EventInfo, output.EventError}, types)
+}
+
+func TestNewParallelExecutor_MinimumOneWorker(t *testing.T) {
+	pool := NewParallelExecutor(0, mapStatusReader{}, &exclusiveStatusWriter{t: t}, nil, &bytes.Buffer{})
+
+	assert.Equal(t, 1, pool.workers)
+}
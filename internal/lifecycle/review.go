@@ -0,0 +1,64 @@
+package lifecycle
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strconv"
+
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/story"
+)
+
+// FindingsMarker starts the line in which a review workflow reports how many
+// findings it left open, e.g. "REVIEW-FINDINGS: 2". The prompt of a review
+// loop's workflow should ask Claude to end its reply with this line.
+const FindingsMarker = "REVIEW-FINDINGS:"
+
+// findingsPattern matches the marker line, allowing Markdown emphasis, list
+// or quote characters before it.
+var findingsPattern = regexp.MustCompile(`(?m)^[\s*_#>-]*` + regexp.QuoteMeta(FindingsMarker) + `\**\s*(\d+)`)
+
+// FindingsCounter counts the open findings of a review.
+//
+// CountFindings is called after every successful run of the workflow of a
+// review loop (see [router.LifecycleStep.Fix]) with the run's result. While it
+// returns more than zero, the fix workflow runs and the review is repeated.
+type FindingsCounter interface {
+	CountFindings(storyKey string, result output.StepResult) (int, error)
+}
+
+// ReviewFindings is the default [FindingsCounter].
+//
+// It counts the findings reported with [FindingsMarker] in Claude's final
+// text and the open review follow-ups in the story file, and returns the
+// larger number. Review follow-ups are unchecked tasks tagged [AI-Review],
+// which the BMAD code-review workflow adds to the story file; see
+// [story.Story.OpenFindings]. A review that
+// reports neither has no findings.
+type ReviewFindings struct {
+	// StoryDir is the directory holding the story files, named
+	// <story-key>.md. Empty skips the story file.
+	StoryDir string
+}
+
+// CountFindings implements [FindingsCounter].
+func (f ReviewFindings) CountFindings(storyKey string, result output.StepResult) (int, error) {
+	count := 0
+	if matches := findingsPattern.FindAllStringSubmatch(result.FinalText, -1); len(matches) > 0 {
+		// The last marker wins, in case Claude quoted the instruction first.
+		count, _ = strconv.Atoi(matches[len(matches)-1][1])
+	}
+
+	if f.StoryDir == "" {
+		return count, nil
+	}
+	s, err := story.Load(story.Path(f.StoryDir, storyKey))
+	if errors.Is(err, os.ErrNotExist) {
+		return count, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return max(count, len(s.OpenFindings())), nil
+}
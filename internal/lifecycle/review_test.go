@@ -0,0 +1,68 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+func TestReviewFindings_Marker(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"no marker", "Review complete, all issues fixed.", 0},
+		{"marker", "Fixed 3 issues.\nREVIEW-FINDINGS: 2", 2},
+		{"clean", "REVIEW-FINDINGS: 0", 0},
+		{"markdown emphasis", "Summary\n\n**REVIEW-FINDINGS:** 4", 4},
+		{"list item", "- REVIEW-FINDINGS: 1", 1},
+		{"last marker wins", "You asked for REVIEW-FINDINGS: <n>.\nREVIEW-FINDINGS: 5\n...\nREVIEW-FINDINGS: 1", 1},
+		{"marker inside a sentence is ignored", "I will report REVIEW-FINDINGS: 3 at the end.", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReviewFindings{}.CountFindings("7-1-schema", output.StepResult{FinalText: tt.text})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReviewFindings_StoryFile(t *testing.T) {
+	dir := t.TempDir()
+	story := `# Story 7.1: Define schema
+
+## Tasks / Subtasks
+
+- [x] Create the schema (AC: 1)
+- [ ] Document the schema
+
+### Review Follow-ups (AI)
+
+- [ ] [AI-Review][High] Validate input lengths [schema.go:42]
+- [x] [AI-Review][Med] Rename the table
+  * [ ] [ai-review][Low] Add a comment
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "7-1-schema.md"), []byte(story), 0644))
+	counter := ReviewFindings{StoryDir: dir}
+
+	got, err := counter.CountFindings("7-1-schema", output.StepResult{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, got, "unchecked review follow-ups are findings")
+
+	got, err = counter.CountFindings("7-1-schema", output.StepResult{FinalText: "REVIEW-FINDINGS: 3"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, got, "the larger count wins")
+
+	got, err = counter.CountFindings("7-2-api", output.StepResult{FinalText: "REVIEW-FINDINGS: 1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, got, "a missing story file has no findings")
+}
@@ -0,0 +1,43 @@
+package lifecycle
+
+import (
+	"errors"
+	"os"
+
+	"bmad-automate/internal/story"
+)
+
+// TaskChecker finds the tasks of a story that are not done yet.
+//
+// IncompleteTasks is called before a step moves a story to review or to a
+// terminal status. If it returns any tasks, the step fails instead and the
+// story keeps its status.
+type TaskChecker interface {
+	IncompleteTasks(storyKey string) ([]story.Task, error)
+}
+
+// StoryTasks is the default [TaskChecker].
+//
+// It reads the unchecked tasks and subtasks of the "Tasks / Subtasks" section
+// of the story file, which dev-story checks off as it works; see
+// [story.Story.Incomplete]. A story without a story file has no open tasks.
+type StoryTasks struct {
+	// StoryDir is the directory holding the story files, named
+	// <story-key>.md. Empty skips the check.
+	StoryDir string
+}
+
+// IncompleteTasks implements [TaskChecker].
+func (t StoryTasks) IncompleteTasks(storyKey string) ([]story.Task, error) {
+	if t.StoryDir == "" {
+		return nil, nil
+	}
+	s, err := story.Load(story.Path(t.StoryDir, storyKey))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Incomplete(), nil
+}
@@ -0,0 +1,43 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoryTasks_IncompleteTasks(t *testing.T) {
+	dir := t.TempDir()
+	story := `# Story 7.1: Define schema
+
+Status: in-progress
+
+## Tasks / Subtasks
+
+- [x] Create the schema (AC: 1)
+  - [ ] Add indexes
+- [ ] Document the schema
+
+## Dev Notes
+
+- [ ] Not a task
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "7-1-schema.md"), []byte(story), 0644))
+
+	open, err := StoryTasks{StoryDir: dir}.IncompleteTasks("7-1-schema")
+	require.NoError(t, err)
+	require.Len(t, open, 2)
+	assert.Equal(t, "Add indexes", open[0].Text)
+	assert.Equal(t, "Document the schema", open[1].Text)
+
+	open, err = StoryTasks{StoryDir: dir}.IncompleteTasks("7-2-api")
+	require.NoError(t, err)
+	assert.Empty(t, open, "a missing story file has no open tasks")
+
+	open, err = StoryTasks{}.IncompleteTasks("7-1-schema")
+	require.NoError(t, err)
+	assert.Empty(t, open, "without a story directory nothing is checked")
+}
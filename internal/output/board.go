@@ -0,0 +1,160 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Board is the sprint board shown by the status command.
+type Board struct {
+	// Epics holds the epics to show, in order.
+	Epics []BoardEpic
+	// Statuses lists the lifecycle statuses in order. Counts follow this
+	// order; statuses that are not listed are counted after them.
+	Statuses []string
+}
+
+// BoardEpic is an epic on the [Board].
+type BoardEpic struct {
+	// ID is the epic identifier. Empty for stories that belong to no epic.
+	ID string `json:"id"`
+	// Status and Retrospective are the statuses of the epic's own entry and
+	// of its retrospective, if the status file has them.
+	Status        string `json:"status,omitempty"`
+	Retrospective string `json:"retrospective,omitempty"`
+	// Done and Total count the finished and all stories of the epic. They
+	// cover the whole epic even when Stories is filtered.
+	Done  int `json:"done"`
+	Total int `json:"total"`
+	// Stories holds the stories to show.
+	Stories []BoardStory `json:"stories"`
+}
+
+// BoardStory is a story on the [Board].
+type BoardStory struct {
+	Key    string `json:"key"`
+	Status string `json:"status"`
+	// Done is set when the status is terminal.
+	Done bool `json:"done"`
+}
+
+// StatusCount is the number of stories with a status.
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// Counts returns the number of shown stories per status, in the order of
+// Statuses. Statuses without stories are left out.
+func (b Board) Counts() []StatusCount {
+	counts := make(map[string]int)
+	order := append([]string(nil), b.Statuses...)
+	for _, epic := range b.Epics {
+		for _, story := range epic.Stories {
+			if _, ok := counts[story.Status]; !ok && !contains(order, story.Status) {
+				order = append(order, story.Status)
+			}
+			counts[story.Status]++
+		}
+	}
+
+	var result []StatusCount
+	for _, status := range order {
+		if counts[status] > 0 {
+			result = append(result, StatusCount{Status: status, Count: counts[status]})
+		}
+	}
+	return result
+}
+
+// contains reports whether list contains s.
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// boardStatusColors colors the statuses of the default BMAD lifecycle.
+// Terminal statuses are always green; other statuses are not colored.
+var boardStatusColors = map[string]lipgloss.Color{
+	"backlog":       colorMuted,
+	"ready-for-dev": colorPrimary,
+	"in-progress":   colorWarning,
+	"review":        colorHighlight,
+}
+
+// progressWidth is the width of the per-epic progress bars.
+const progressWidth = 20
+
+// Board prints the stories of every epic, colored by status, with the epic's
+// status, a progress bar per epic, the retrospective status and the number of
+// stories per status.
+func (p *DefaultPrinter) Board(board Board) {
+	width := 0
+	for _, epic := range board.Epics {
+		for _, story := range epic.Stories {
+			width = max(width, len(story.Key))
+		}
+	}
+
+	var sb strings.Builder
+	total := 0
+	for _, epic := range board.Epics {
+		title := "Epic " + epic.ID
+		if epic.ID == "" {
+			title = "Other stories"
+		}
+		if epic.Status != "" {
+			title += " (" + epic.Status + ")"
+		}
+		sb.WriteString(fmt.Sprintf("%s  %s  %d/%d done\n", labelStyle.Render(title), progressBar(epic.Done, epic.Total), epic.Done, epic.Total))
+
+		for _, story := range epic.Stories {
+			style := lipgloss.NewStyle()
+			icon := iconInProgress
+			switch {
+			case story.Done:
+				style = successStyle
+				icon = iconSuccess
+			case story.Status == "backlog":
+				icon = iconPending
+			}
+			if color, ok := boardStatusColors[story.Status]; ok && !story.Done {
+				style = style.Foreground(color)
+			}
+			sb.WriteString(fmt.Sprintf("  %s %-*s  %s\n", style.Render(icon), width, story.Key, style.Render(story.Status)))
+			total++
+		}
+		if epic.Retrospective != "" {
+			sb.WriteString(mutedStyle.Render("  retrospective: "+epic.Retrospective) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	counts := board.Counts()
+	parts := make([]string, len(counts))
+	for i, c := range counts {
+		parts[i] = fmt.Sprintf("%d %s", c.Count, c.Status)
+	}
+	summary := pluralize(total, "story", "stories")
+	if len(parts) > 0 {
+		summary += ": " + strings.Join(parts, " · ")
+	}
+	sb.WriteString(summary + "\n")
+
+	fmt.Fprint(p.out, sb.String())
+}
+
+// progressBar renders done out of total as a bar of [progressWidth] cells.
+func progressBar(done, total int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * progressWidth / total
+	}
+	return successStyle.Render(strings.Repeat("█", filled)) + mutedStyle.Render(strings.Repeat("░", progressWidth-filled))
+}
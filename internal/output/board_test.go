@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testBoard = Board{
+	Statuses: []string{"backlog", "ready-for-dev", "review", "done"},
+	Epics: []BoardEpic{
+		{ID: "7", Status: "in-progress", Retrospective: "optional", Done: 1, Total: 4, Stories: []BoardStory{
+			{Key: "7-1-schema", Status: "done", Done: true},
+			{Key: "7-2-api", Status: "review"},
+			{Key: "7-3-ui", Status: "backlog"},
+			{Key: "7-4-docs", Status: "blocked"},
+		}},
+		{Done: 0, Total: 1, Stories: []BoardStory{
+			{Key: "cleanup", Status: "backlog"},
+		}},
+	},
+}
+
+func TestBoard_Counts(t *testing.T) {
+	assert.Equal(t, []StatusCount{
+		{Status: "backlog", Count: 2},
+		{Status: "review", Count: 1},
+		{Status: "done", Count: 1},
+		{Status: "blocked", Count: 1},
+	}, testBoard.Counts(), "unlisted statuses should be counted last")
+
+	assert.Empty(t, Board{Statuses: []string{"done"}}.Counts())
+}
+
+func TestDefaultPrinter_Board(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.Board(testBoard)
+
+	out := buf.String()
+	assert.Contains(t, out, "Epic 7 (in-progress)")
+	assert.Contains(t, out, "  retrospective: optional\n")
+	assert.Contains(t, out, "1/4 done")
+	assert.Contains(t, out, iconSuccess+" 7-1-schema  done\n")
+	assert.Contains(t, out, iconPending+" 7-3-ui      backlog\n")
+	assert.Contains(t, out, iconInProgress+" 7-2-api     review\n")
+	assert.Contains(t, out, "Other stories")
+	assert.Contains(t, out, "0/1 done")
+	assert.Contains(t, out, "5 stories: 2 backlog · 1 review · 1 done · 1 blocked\n")
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		name        string
+		done, total int
+		filled      int
+	}{
+		{"empty", 0, 4, 0},
+		{"half", 2, 4, progressWidth / 2},
+		{"complete", 3, 3, progressWidth},
+		{"no stories", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar := progressBar(tt.done, tt.total)
+			assert.Equal(t, tt.filled, bytes.Count([]byte(bar), []byte("█")))
+			assert.Equal(t, progressWidth-tt.filled, bytes.Count([]byte(bar), []byte("░")))
+		})
+	}
+}
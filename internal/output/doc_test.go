@@ -2,6 +2,7 @@ package output_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -170,3 +171,29 @@ func Example_cycleSummary() {
 	// Output:
 	// cycle summary captured
 }
+
+// Example_jsonPrinter demonstrates the NDJSON output used with --output json.
+//
+// Every Printer call becomes one JSON event per line; purely visual calls such
+// as Divider write nothing.
+func Example_jsonPrinter() {
+	var buf bytes.Buffer
+	printer := output.NewJSONPrinterWithWriter(&buf)
+
+	printer.StepStart(1, 4, "create-story")
+	printer.Divider()
+	printer.Text("Story file written")
+
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var event output.JSONEvent
+		if err := decoder.Decode(&event); err != nil {
+			fmt.Println("error:", err)
+			return
+		}
+		fmt.Println(event.Type)
+	}
+	// Output:
+	// step_start
+	// text
+}
@@ -0,0 +1,40 @@
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format is an output format, selected with the --output flag.
+type Format string
+
+// Supported output formats.
+const (
+	// FormatText is styled terminal output from [DefaultPrinter].
+	FormatText Format = "text"
+
+	// FormatJSON is newline-delimited JSON from [JSONPrinter].
+	FormatJSON Format = "json"
+)
+
+// ParseFormat returns the [Format] named by s. An empty string selects
+// [FormatText].
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (valid formats: text, json)", s)
+	}
+}
+
+// NewPrinter creates a [Printer] for the format that writes to w.
+// Unknown formats fall back to [FormatText].
+func (f Format) NewPrinter(w io.Writer) Printer {
+	if f == FormatJSON {
+		return NewJSONPrinterWithWriter(w)
+	}
+	return NewPrinterWithWriter(w)
+}
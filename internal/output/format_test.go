@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "", want: FormatText},
+		{input: "text", want: FormatText},
+		{input: "json", want: FormatJSON},
+		{input: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormat_NewPrinter(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.IsType(t, &DefaultPrinter{}, FormatText.NewPrinter(&buf))
+	assert.IsType(t, &JSONPrinter{}, FormatJSON.NewPrinter(&buf))
+	assert.IsType(t, &DefaultPrinter{}, Format("").NewPrinter(&buf))
+}
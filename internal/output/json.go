@@ -0,0 +1,440 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types written by [JSONPrinter], one per [Printer] method.
+const (
+	EventSessionStart    = "session_start"
+	EventSessionEnd      = "session_end"
+	EventStepStart       = "step_start"
+	EventStepEnd         = "step_end"
+	EventStepRetry       = "step_retry"
+	EventStepAborted     = "step_aborted"
+	EventVerify          = "verify"
+	EventToolUse         = "tool_use"
+	EventToolResult      = "tool_result"
+	EventText            = "text"
+	EventCycleStart      = "cycle_start"
+	EventCycleSummary    = "cycle_summary"
+	EventCycleFailed     = "cycle_failed"
+	EventQueueStart      = "queue_start"
+	EventQueueStoryStart = "queue_story_start"
+	EventQueueSummary    = "queue_summary"
+	EventCommandStart    = "command_start"
+	EventCommandEnd      = "command_end"
+	EventPlan            = "plan"
+	EventBoard           = "board"
+	EventStory           = "story"
+	EventInfo            = "info"
+	EventWarning         = "warning"
+	EventError           = "error"
+	EventStderr          = "stderr"
+)
+
+// JSONEvent is a single line of [JSONPrinter] output.
+//
+// Which fields are set depends on Type. Durations are in milliseconds.
+type JSONEvent struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	// Story is the story key of cycle, queue story, plan and story events.
+	Story string `json:"story,omitempty"`
+	// Name is the step name of step events or the tool name of tool_use.
+	Name string `json:"name,omitempty"`
+	// Index and Total number the step or story within its run.
+	Index int `json:"index,omitempty"`
+	Total int `json:"total,omitempty"`
+
+	// Label and Prompt describe the Claude command of command_start.
+	Label  string `json:"label,omitempty"`
+	Prompt string `json:"prompt,omitempty"`
+
+	// Message is the text of text, info, warning, error and stderr events.
+	Message string `json:"message,omitempty"`
+
+	// Description, Command and FilePath are the tool_use details. Command
+	// is also the verification command of verify, with its output in Stdout.
+	Description string `json:"description,omitempty"`
+	Command     string `json:"command,omitempty"`
+	FilePath    string `json:"file_path,omitempty"`
+
+	// Stdout and Stderr are the untruncated output of tool_result.
+	Stdout string `json:"stdout,omitempty"`
+	Stderr string `json:"stderr,omitempty"`
+
+	// Success, ExitCode and DurationMS describe the outcome of end and
+	// summary events.
+	Success    *bool `json:"success,omitempty"`
+	ExitCode   *int  `json:"exit_code,omitempty"`
+	DurationMS int64 `json:"duration_ms,omitempty"`
+
+	// Attempt, MaxAttempts and DelayMS describe a step_retry.
+	Attempt     int   `json:"attempt,omitempty"`
+	MaxAttempts int   `json:"max_attempts,omitempty"`
+	DelayMS     int64 `json:"delay_ms,omitempty"`
+
+	// Reason explains why a step was stopped.
+	Reason string `json:"reason,omitempty"`
+	// FailedStep is the step at which a cycle failed.
+	FailedStep string `json:"failed_step,omitempty"`
+	// Steps lists the steps of a cycle summary.
+	Steps []JSONStep `json:"steps,omitempty"`
+
+	// Stories lists the story keys of a queue_start.
+	Stories []string `json:"stories,omitempty"`
+	// Results, Pending and Counts describe a queue_summary.
+	Results []JSONStory `json:"results,omitempty"`
+	Pending []string    `json:"pending,omitempty"`
+	Counts  *QueueCount `json:"counts,omitempty"`
+
+	// Plan lists the steps of a plan event; Complete is set instead when
+	// the story has no steps left.
+	Plan     []PlanStep `json:"plan,omitempty"`
+	Complete bool       `json:"complete,omitempty"`
+
+	// Epics and StatusCounts describe a board; Total is its number of
+	// stories.
+	Epics        []BoardEpic   `json:"epics,omitempty"`
+	StatusCounts []StatusCount `json:"status_counts,omitempty"`
+
+	// Details describes the story of a story event.
+	Details *StoryDetails `json:"details,omitempty"`
+
+	// Usage is the combined usage of a step, cycle or queue.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// JSONStep is a [StepResult] in [JSONPrinter] output.
+type JSONStep struct {
+	Name       string `json:"name"`
+	Success    bool   `json:"success"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	Attempts   int    `json:"attempts"`
+	Usage      Usage  `json:"usage"`
+	Reason     string `json:"reason,omitempty"`
+	SessionID  string `json:"session_id,omitempty"`
+	Findings   int    `json:"findings,omitempty"`
+
+	IncompleteTasks []string `json:"incomplete_tasks,omitempty"`
+}
+
+// JSONStory is a [StoryResult] in [JSONPrinter] output.
+type JSONStory struct {
+	Key         string `json:"key"`
+	Success     bool   `json:"success"`
+	Skipped     bool   `json:"skipped,omitempty"`
+	Interrupted bool   `json:"interrupted,omitempty"`
+	FailedAt    string `json:"failed_at,omitempty"`
+	DurationMS  int64  `json:"duration_ms"`
+	Retries     int    `json:"retries"`
+	Usage       Usage  `json:"usage"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// QueueCount counts the stories of a queue by outcome.
+type QueueCount struct {
+	Completed   int `json:"completed"`
+	Skipped     int `json:"skipped"`
+	Failed      int `json:"failed"`
+	Interrupted int `json:"interrupted"`
+	Remaining   int `json:"remaining"`
+}
+
+// countResults counts results by outcome; stories without a result are remaining.
+func countResults(results []StoryResult, allKeys []string) QueueCount {
+	var c QueueCount
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			c.Skipped++
+		case r.Success:
+			c.Completed++
+		case r.Interrupted:
+			c.Interrupted++
+		default:
+			c.Failed++
+		}
+	}
+	c.Remaining = len(allKeys) - len(results)
+	return c
+}
+
+// JSONPrinter implements [Printer] by writing one [JSONEvent] per line
+// (NDJSON), so that CI pipelines can parse the output.
+//
+// Purely visual output such as dividers and empty lines is omitted, and
+// nothing is truncated. JSONPrinter is safe for concurrent use.
+type JSONPrinter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONPrinter creates a new [JSONPrinter] that writes to stdout.
+func NewJSONPrinter() *JSONPrinter {
+	return NewJSONPrinterWithWriter(os.Stdout)
+}
+
+// NewJSONPrinterWithWriter creates a new [JSONPrinter] with a custom writer.
+func NewJSONPrinterWithWriter(w io.Writer) *JSONPrinter {
+	return &JSONPrinter{enc: json.NewEncoder(w)}
+}
+
+// emit writes e as one line, stamping it with the current time.
+func (p *JSONPrinter) emit(e JSONEvent) {
+	e.Time = time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_ = p.enc.Encode(e) //nolint:errcheck // Output errors are ignored like in DefaultPrinter
+}
+
+// message writes an event whose Message is the formatted text, without the
+// indentation used for terminal layout.
+func (p *JSONPrinter) message(typ, format string, args ...interface{}) {
+	p.emit(JSONEvent{Type: typ, Message: strings.TrimSpace(fmt.Sprintf(format, args...))})
+}
+
+// SessionStart writes a session_start event.
+func (p *JSONPrinter) SessionStart() {
+	p.emit(JSONEvent{Type: EventSessionStart})
+}
+
+// SessionEnd writes a session_end event.
+func (p *JSONPrinter) SessionEnd(duration time.Duration, success bool) {
+	p.emit(JSONEvent{Type: EventSessionEnd, Success: &success, DurationMS: duration.Milliseconds()})
+}
+
+// StepStart writes a step_start event.
+func (p *JSONPrinter) StepStart(step, total int, name string) {
+	p.emit(JSONEvent{Type: EventStepStart, Index: step, Total: total, Name: name})
+}
+
+// StepEnd writes a step_end event.
+func (p *JSONPrinter) StepEnd(duration time.Duration, success bool) {
+	p.emit(JSONEvent{Type: EventStepEnd, Success: &success, DurationMS: duration.Milliseconds()})
+}
+
+// StepRetry writes a step_retry event.
+func (p *JSONPrinter) StepRetry(name string, attempt, maxAttempts, exitCode int, delay time.Duration) {
+	p.emit(JSONEvent{
+		Type:        EventStepRetry,
+		Name:        name,
+		Attempt:     attempt,
+		MaxAttempts: maxAttempts,
+		ExitCode:    &exitCode,
+		DelayMS:     delay.Milliseconds(),
+	})
+}
+
+// StepAborted writes a step_aborted event.
+func (p *JSONPrinter) StepAborted(name, reason string) {
+	p.emit(JSONEvent{Type: EventStepAborted, Name: name, Reason: reason})
+}
+
+// VerifyResult writes a verify event with the complete output of a failed
+// command.
+func (p *JSONPrinter) VerifyResult(command string, exitCode int, duration time.Duration, output string, truncateLines int) {
+	success := exitCode == 0
+	event := JSONEvent{Type: EventVerify, Command: command, Success: &success, ExitCode: &exitCode, DurationMS: duration.Milliseconds()}
+	if !success {
+		event.Stdout = output
+	}
+	p.emit(event)
+}
+
+// ToolUse writes a tool_use event.
+func (p *JSONPrinter) ToolUse(name, description, command, filePath string) {
+	p.emit(JSONEvent{Type: EventToolUse, Name: name, Description: description, Command: command, FilePath: filePath})
+}
+
+// ToolResult writes a tool_result event with the complete output.
+func (p *JSONPrinter) ToolResult(stdout, stderr string, truncateLines int) {
+	if stdout == "" && stderr == "" {
+		return
+	}
+	p.emit(JSONEvent{Type: EventToolResult, Stdout: stdout, Stderr: stderr})
+}
+
+// Text writes a text event.
+func (p *JSONPrinter) Text(message string) {
+	if message != "" {
+		p.emit(JSONEvent{Type: EventText, Message: message})
+	}
+}
+
+// Divider writes nothing.
+func (p *JSONPrinter) Divider() {}
+
+// CycleHeader writes a cycle_start event.
+func (p *JSONPrinter) CycleHeader(storyKey string) {
+	p.emit(JSONEvent{Type: EventCycleStart, Story: storyKey})
+}
+
+// CycleSummary writes a cycle_summary event.
+func (p *JSONPrinter) CycleSummary(storyKey string, steps []StepResult, totalDuration time.Duration) {
+	success := true
+	usage := StoryResult{Steps: steps}.Usage()
+	p.emit(JSONEvent{
+		Type:       EventCycleSummary,
+		Story:      storyKey,
+		Success:    &success,
+		DurationMS: totalDuration.Milliseconds(),
+		Steps:      jsonSteps(steps),
+		Usage:      &usage,
+	})
+}
+
+// CycleFailed writes a cycle_failed event.
+func (p *JSONPrinter) CycleFailed(storyKey string, failedStep string, steps []StepResult, duration time.Duration) {
+	success := false
+	result := StoryResult{Steps: steps}
+	usage := result.Usage()
+	p.emit(JSONEvent{
+		Type:       EventCycleFailed,
+		Story:      storyKey,
+		Success:    &success,
+		FailedStep: failedStep,
+		Reason:     result.Reason(),
+		DurationMS: duration.Milliseconds(),
+		Steps:      jsonSteps(steps),
+		Usage:      &usage,
+	})
+}
+
+// QueueHeader writes a queue_start event.
+func (p *JSONPrinter) QueueHeader(count int, stories []string) {
+	p.emit(JSONEvent{Type: EventQueueStart, Total: count, Stories: stories})
+}
+
+// QueueStoryStart writes a queue_story_start event.
+func (p *JSONPrinter) QueueStoryStart(index, total int, storyKey string) {
+	p.emit(JSONEvent{Type: EventQueueStoryStart, Index: index, Total: total, Story: storyKey})
+}
+
+// QueueSummary writes a queue_summary event.
+func (p *JSONPrinter) QueueSummary(results []StoryResult, allKeys []string, totalDuration time.Duration) {
+	counts := countResults(results, allKeys)
+	success := counts.Failed == 0 && counts.Interrupted == 0 && counts.Remaining == 0
+
+	processed := make(map[string]bool, len(results))
+	stories := make([]JSONStory, 0, len(results))
+	var usage Usage
+	for _, r := range results {
+		processed[r.Key] = true
+		usage = usage.Add(r.Usage())
+		stories = append(stories, JSONStory{
+			Key:         r.Key,
+			Success:     r.Success,
+			Skipped:     r.Skipped,
+			Interrupted: r.Interrupted,
+			FailedAt:    r.FailedAt,
+			DurationMS:  r.Duration.Milliseconds(),
+			Retries:     r.Retries(),
+			Usage:       r.Usage(),
+			Reason:      r.Reason(),
+		})
+	}
+	var pending []string
+	for _, key := range allKeys {
+		if !processed[key] {
+			pending = append(pending, key)
+		}
+	}
+
+	p.emit(JSONEvent{
+		Type:       EventQueueSummary,
+		Success:    &success,
+		DurationMS: totalDuration.Milliseconds(),
+		Results:    stories,
+		Pending:    pending,
+		Counts:     &counts,
+		Usage:      &usage,
+	})
+}
+
+// CommandHeader writes a command_start event with the complete prompt.
+func (p *JSONPrinter) CommandHeader(label, prompt string, truncateLength int) {
+	p.emit(JSONEvent{Type: EventCommandStart, Label: label, Prompt: prompt})
+}
+
+// CommandFooter writes a command_end event.
+func (p *JSONPrinter) CommandFooter(duration time.Duration, success bool, exitCode int) {
+	p.emit(JSONEvent{Type: EventCommandEnd, Success: &success, ExitCode: &exitCode, DurationMS: duration.Milliseconds()})
+}
+
+// Plan writes a plan event.
+func (p *JSONPrinter) Plan(storyKey string, steps []PlanStep) {
+	p.emit(JSONEvent{Type: EventPlan, Story: storyKey, Plan: steps, Complete: len(steps) == 0})
+}
+
+// Board writes a board event with every shown story and the counts per
+// status.
+func (p *JSONPrinter) Board(board Board) {
+	total := 0
+	for _, epic := range board.Epics {
+		total += len(epic.Stories)
+	}
+	p.emit(JSONEvent{Type: EventBoard, Epics: board.Epics, StatusCounts: board.Counts(), Total: total})
+}
+
+// Story writes a story event with the details of a story.
+func (p *JSONPrinter) Story(details StoryDetails) {
+	p.emit(JSONEvent{Type: EventStory, Story: details.Key, Details: &details})
+}
+
+// Info writes an info event.
+func (p *JSONPrinter) Info(format string, args ...interface{}) {
+	p.message(EventInfo, format, args...)
+}
+
+// Warning writes a warning event.
+func (p *JSONPrinter) Warning(format string, args ...interface{}) {
+	p.message(EventWarning, format, args...)
+}
+
+// Error writes an error event.
+func (p *JSONPrinter) Error(format string, args ...interface{}) {
+	p.message(EventError, format, args...)
+}
+
+// Stderr writes a stderr event.
+func (p *JSONPrinter) Stderr(line string) {
+	p.emit(JSONEvent{Type: EventStderr, Message: line})
+}
+
+// Newline writes nothing.
+func (p *JSONPrinter) Newline() {}
+
+// jsonSteps converts step results for JSON output.
+func jsonSteps(steps []StepResult) []JSONStep {
+	out := make([]JSONStep, 0, len(steps))
+	for _, s := range steps {
+		attempts := len(s.Attempts)
+		if attempts == 0 {
+			attempts = 1
+		}
+		out = append(out, JSONStep{
+			Name:       s.Name,
+			Success:    s.Success,
+			ExitCode:   s.ExitCode,
+			DurationMS: s.Duration.Milliseconds(),
+			Attempts:   attempts,
+			Usage:      s.Usage,
+			Reason:     s.Reason,
+			SessionID:  s.SessionID,
+			Findings:   s.Findings,
+
+			IncompleteTasks: s.IncompleteTasks,
+		})
+	}
+	return out
+}
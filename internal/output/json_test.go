@@ -0,0 +1,274 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeEvents decodes every line of NDJSON output, failing on any line that
+// is not a single JSON event.
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []JSONEvent {
+	t.Helper()
+	var events []JSONEvent
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var e JSONEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e), "line: %s", scanner.Text())
+		assert.False(t, e.Time.IsZero())
+		events = append(events, e)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestJSONPrinter_Events(t *testing.T) {
+	success := true
+	failure := false
+	exitCode := 0
+	retryExitCode := 128
+	verifyExitCode := 1
+
+	tests := []struct {
+		name  string
+		print func(p *JSONPrinter)
+		want  JSONEvent
+	}{
+		{
+			name:  "step start",
+			print: func(p *JSONPrinter) { p.StepStart(1, 4, "create-story") },
+			want:  JSONEvent{Type: EventStepStart, Index: 1, Total: 4, Name: "create-story"},
+		},
+		{
+			name:  "step retry",
+			print: func(p *JSONPrinter) { p.StepRetry("git-commit", 1, 3, 128, 10*time.Second) },
+			want:  JSONEvent{Type: EventStepRetry, Name: "git-commit", Attempt: 1, MaxAttempts: 3, ExitCode: &retryExitCode, DelayMS: 10000},
+		},
+		{
+			name:  "step aborted",
+			print: func(p *JSONPrinter) { p.StepAborted("dev-story", "timed out after 30m0s") },
+			want:  JSONEvent{Type: EventStepAborted, Name: "dev-story", Reason: "timed out after 30m0s"},
+		},
+		{
+			name:  "verify passed",
+			print: func(p *JSONPrinter) { p.VerifyResult("go test ./...", 0, 2*time.Second, "ok", 2) },
+			want:  JSONEvent{Type: EventVerify, Command: "go test ./...", Success: &success, ExitCode: &exitCode, DurationMS: 2000},
+		},
+		{
+			name:  "verify failed has the full output",
+			print: func(p *JSONPrinter) { p.VerifyResult("go test ./...", 1, time.Second, "1\n2\n3\nFAIL", 2) },
+			want:  JSONEvent{Type: EventVerify, Command: "go test ./...", Success: &failure, ExitCode: &verifyExitCode, DurationMS: 1000, Stdout: "1\n2\n3\nFAIL"},
+		},
+		{
+			name:  "tool use",
+			print: func(p *JSONPrinter) { p.ToolUse("Bash", "Run tests", "go test ./...", "") },
+			want:  JSONEvent{Type: EventToolUse, Name: "Bash", Description: "Run tests", Command: "go test ./..."},
+		},
+		{
+			name:  "tool result is not truncated",
+			print: func(p *JSONPrinter) { p.ToolResult("1\n2\n3\n4\n5", "warning", 2) },
+			want:  JSONEvent{Type: EventToolResult, Stdout: "1\n2\n3\n4\n5", Stderr: "warning"},
+		},
+		{
+			name:  "text",
+			print: func(p *JSONPrinter) { p.Text("Found 2 issues") },
+			want:  JSONEvent{Type: EventText, Message: "Found 2 issues"},
+		},
+		{
+			name:  "command start has the full prompt",
+			print: func(p *JSONPrinter) { p.CommandHeader("dev-story: 7-1", "Implement story 7-1 and run all tests", 10) },
+			want:  JSONEvent{Type: EventCommandStart, Label: "dev-story: 7-1", Prompt: "Implement story 7-1 and run all tests"},
+		},
+		{
+			name:  "command end",
+			print: func(p *JSONPrinter) { p.CommandFooter(1500*time.Millisecond, true, 0) },
+			want:  JSONEvent{Type: EventCommandEnd, Success: &success, ExitCode: &exitCode, DurationMS: 1500},
+		},
+		{
+			name:  "session end",
+			print: func(p *JSONPrinter) { p.SessionEnd(time.Second, false) },
+			want:  JSONEvent{Type: EventSessionEnd, Success: &failure, DurationMS: 1000},
+		},
+		{
+			name: "plan",
+			print: func(p *JSONPrinter) {
+				p.Plan("7-1", []PlanStep{{Workflow: "code-review", NextStatus: "done"}})
+			},
+			want: JSONEvent{Type: EventPlan, Story: "7-1", Plan: []PlanStep{{Workflow: "code-review", NextStatus: "done"}}},
+		},
+		{
+			name:  "plan of a complete story",
+			print: func(p *JSONPrinter) { p.Plan("7-1", nil) },
+			want:  JSONEvent{Type: EventPlan, Story: "7-1", Complete: true},
+		},
+		{
+			name:  "info without terminal indentation",
+			print: func(p *JSONPrinter) { p.Info("  ↷ Skipped (already done)") },
+			want:  JSONEvent{Type: EventInfo, Message: "↷ Skipped (already done)"},
+		},
+		{
+			name:  "warning",
+			print: func(p *JSONPrinter) { p.Warning("failed to write run log: %s", "disk full") },
+			want:  JSONEvent{Type: EventWarning, Message: "failed to write run log: disk full"},
+		},
+		{
+			name:  "error",
+			print: func(p *JSONPrinter) { p.Error("unknown status value: %s", "blocked") },
+			want:  JSONEvent{Type: EventError, Message: "unknown status value: blocked"},
+		},
+		{
+			name:  "stderr",
+			print: func(p *JSONPrinter) { p.Stderr("  rate limited") },
+			want:  JSONEvent{Type: EventStderr, Message: "  rate limited"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.print(NewJSONPrinterWithWriter(&buf))
+
+			events := decodeEvents(t, &buf)
+			require.Len(t, events, 1)
+			events[0].Time = time.Time{}
+			assert.Equal(t, tt.want, events[0])
+		})
+	}
+}
+
+func TestJSONPrinter_VisualOnly(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	p.Divider()
+	p.Newline()
+	p.Text("")
+	p.ToolResult("", "", 20)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestJSONPrinter_CycleFailed(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	p.CycleFailed("7-1", "dev-story", []StepResult{
+		{Name: "create-story", Success: true, Duration: 2 * time.Second, Usage: Usage{CostUSD: 0.25}},
+		{
+			Name:     "dev-story",
+			ExitCode: 1,
+			Duration: 5 * time.Second,
+			Attempts: []Attempt{{ExitCode: 1}, {ExitCode: 1}},
+			Usage:    Usage{CostUSD: 1.5},
+			Reason:   "run budget exceeded",
+		},
+	}, 7*time.Second)
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 1)
+	e := events[0]
+	assert.Equal(t, EventCycleFailed, e.Type)
+	assert.Equal(t, "dev-story", e.FailedStep)
+	assert.Equal(t, "run budget exceeded", e.Reason)
+	require.NotNil(t, e.Success)
+	assert.False(t, *e.Success)
+	assert.Equal(t, int64(7000), e.DurationMS)
+	assert.Equal(t, []JSONStep{
+		{Name: "create-story", Success: true, DurationMS: 2000, Attempts: 1, Usage: Usage{CostUSD: 0.25}},
+		{Name: "dev-story", ExitCode: 1, DurationMS: 5000, Attempts: 2, Usage: Usage{CostUSD: 1.5}, Reason: "run budget exceeded"},
+	}, e.Steps)
+	require.NotNil(t, e.Usage)
+	assert.InDelta(t, 1.75, e.Usage.CostUSD, 1e-9)
+}
+
+func TestJSONPrinter_CycleFailed_IncompleteTasks(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	p.CycleFailed("7-1", "dev-story", []StepResult{{
+		Name:            "dev-story",
+		ExitCode:        1,
+		Reason:          "1 task not done in the story file",
+		IncompleteTasks: []string{"Add indexes"},
+	}}, time.Second)
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 1)
+	require.Len(t, events[0].Steps, 1)
+	assert.Equal(t, []string{"Add indexes"}, events[0].Steps[0].IncompleteTasks)
+}
+
+func TestJSONPrinter_QueueSummary(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	p.QueueSummary([]StoryResult{
+		{Key: "7-1", Skipped: true, Success: true},
+		{Key: "7-3", Success: true, Duration: time.Minute},
+		{Key: "7-2", FailedAt: "dev-story", Duration: 30 * time.Second},
+	}, []string{"7-1", "7-2", "7-3", "7-4"}, 90*time.Second)
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 1)
+	e := events[0]
+	assert.Equal(t, EventQueueSummary, e.Type)
+	require.NotNil(t, e.Success)
+	assert.False(t, *e.Success)
+	assert.Equal(t, &QueueCount{Completed: 1, Skipped: 1, Failed: 1, Remaining: 1}, e.Counts)
+	assert.Equal(t, []string{"7-4"}, e.Pending)
+	require.Len(t, e.Results, 3)
+	assert.Equal(t, JSONStory{Key: "7-2", FailedAt: "dev-story", DurationMS: 30000}, e.Results[2])
+}
+
+func TestJSONPrinter_Board(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	p.Board(testBoard)
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventBoard, events[0].Type)
+	assert.Equal(t, 5, events[0].Total)
+	assert.Equal(t, testBoard.Epics, events[0].Epics)
+	assert.Equal(t, testBoard.Counts(), events[0].StatusCounts)
+}
+
+func TestJSONPrinter_Story(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	p.Story(testStory)
+
+	events := decodeEvents(t, &buf)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventStory, events[0].Type)
+	assert.Equal(t, "7-1-schema", events[0].Story)
+	require.NotNil(t, events[0].Details)
+	want := testStory
+	want.Key = ""
+	assert.Equal(t, want, *events[0].Details)
+}
+
+func TestJSONPrinter_Concurrent(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewJSONPrinterWithWriter(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Text("hello from a parallel story")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, decodeEvents(t, &buf), 20)
+}
@@ -15,10 +15,63 @@ import (
 type StepResult struct {
 	// Name is the step identifier (e.g., "create-story", "dev-story").
 	Name string
-	// Duration is how long the step took to execute.
+	// Duration is how long the step took to execute, including retries.
 	Duration time.Duration
 	// Success indicates whether the step completed successfully.
 	Success bool
+	// ExitCode is the exit code of the final attempt (0 = success).
+	ExitCode int
+	// Attempts records every run of the step, in order. Empty if the
+	// runner does not report attempts.
+	Attempts []Attempt
+	// Usage is the token usage and cost of all attempts combined.
+	Usage Usage
+	// Reason explains why the step was stopped, e.g. because a budget limit
+	// was exceeded. Empty for ordinary failures.
+	Reason string
+	// SessionID is the Claude session of the final attempt that reported one.
+	// Empty if the runner does not report sessions.
+	SessionID string
+	// FromStatus is the story status before the step ran, and ToStatus the
+	// status set after it succeeded. Both are set by the lifecycle executor;
+	// ToStatus is empty if the status was not updated.
+	FromStatus string
+	ToStatus   string
+	// Files lists the file paths Claude's tools used, in first-use order.
+	Files []string
+	// Commands lists the shell commands Claude ran, in order.
+	Commands []string
+	// FinalText is the last text Claude wrote, typically its summary of
+	// the work.
+	FinalText string
+	// Findings is the number of open findings a review step reported. Set
+	// by the lifecycle executor for steps of a review loop.
+	Findings int
+	// IncompleteTasks lists the story's tasks that were not done when the
+	// step finished. Set by the lifecycle executor when they failed the step.
+	IncompleteTasks []string
+}
+
+// Retries returns the number of attempts after the first one.
+func (r StepResult) Retries() int {
+	if len(r.Attempts) <= 1 {
+		return 0
+	}
+	return len(r.Attempts) - 1
+}
+
+// Attempt records a single run of a workflow step.
+type Attempt struct {
+	// ExitCode is the exit code of the run (0 = success).
+	ExitCode int
+	// Duration is how long the run took, excluding any backoff delay.
+	Duration time.Duration
+	// Usage is the token usage and cost reported by Claude for the run.
+	Usage Usage
+	// SessionID is the Claude session of the run, if Claude reported one.
+	SessionID string
+	// Stderr holds the lines Claude wrote to stderr during the run.
+	Stderr []string
 }
 
 // StoryResult represents the result of processing a story in queue or epic operations.
@@ -36,15 +89,61 @@ type StoryResult struct {
 	FailedAt string
 	// Skipped indicates the story was skipped because it was already done.
 	Skipped bool
+	// Interrupted indicates the story was stopped early, e.g. by Ctrl-C.
+	// The remaining steps can be run with the resume command.
+	Interrupted bool
+	// Steps holds the result of each workflow step that ran, in order.
+	Steps []StepResult
+}
+
+// Usage returns the combined token usage and cost of all steps.
+func (r StoryResult) Usage() Usage {
+	var total Usage
+	for _, step := range r.Steps {
+		total = total.Add(step.Usage)
+	}
+	return total
+}
+
+// Reason returns the reason the last step was stopped, if any.
+func (r StoryResult) Reason() string {
+	if len(r.Steps) == 0 {
+		return ""
+	}
+	return r.Steps[len(r.Steps)-1].Reason
+}
+
+// Retries returns the total number of retried attempts across all steps.
+func (r StoryResult) Retries() int {
+	retries := 0
+	for _, step := range r.Steps {
+		retries += step.Retries()
+	}
+	return retries
+}
+
+// PlanStep is a workflow step that a dry run would execute.
+type PlanStep struct {
+	// Workflow is the name of the workflow to run.
+	Workflow string `json:"workflow"`
+	// NextStatus is the story status after the workflow succeeds.
+	NextStatus string `json:"next_status"`
+	// Fix and MaxIterations describe a review loop, in which Fix addresses
+	// the findings of Workflow. Empty for ordinary steps.
+	Fix           string `json:"fix,omitempty"`
+	MaxIterations int    `json:"max_iterations,omitempty"`
 }
 
 // Printer defines the interface for structured terminal output operations.
 //
 // The interface enables output capture in tests via [NewPrinterWithWriter],
-// which accepts a custom io.Writer instead of writing to stdout.
+// which accepts a custom io.Writer instead of writing to stdout. Two
+// implementations exist: [DefaultPrinter] for terminals and [JSONPrinter]
+// for machine-readable output.
 //
 // Methods are grouped by operation type: session lifecycle, step progress,
-// tool usage display, content output, cycle summaries, and queue summaries.
+// tool usage display, content output, cycle summaries, queue summaries,
+// dry-run plans, and general messages.
 type Printer interface {
 	// SessionStart prints an indicator that a new execution session has begun.
 	SessionStart()
@@ -55,6 +154,16 @@ type Printer interface {
 	StepStart(step, total int, name string)
 	// StepEnd prints step completion status with duration.
 	StepEnd(duration time.Duration, success bool)
+	// StepRetry prints a notice that a failed attempt of a step will be
+	// retried after delay.
+	StepRetry(name string, attempt, maxAttempts, exitCode int, delay time.Duration)
+	// StepAborted prints a notice that a step was stopped, e.g. because a
+	// budget limit was exceeded.
+	StepAborted(name, reason string)
+	// VerifyResult prints the outcome of a verification command that ran
+	// after a step, with its output, truncated to the given number of lines,
+	// if it failed.
+	VerifyResult(command string, exitCode int, duration time.Duration, output string, truncateLines int)
 
 	// ToolUse displays Claude tool invocation details including name,
 	// description, command, and file path as applicable.
@@ -72,8 +181,9 @@ type Printer interface {
 	CycleHeader(storyKey string)
 	// CycleSummary prints the completion summary showing all steps and durations.
 	CycleSummary(storyKey string, steps []StepResult, totalDuration time.Duration)
-	// CycleFailed prints failure information when a cycle fails at a step.
-	CycleFailed(storyKey string, failedStep string, duration time.Duration)
+	// CycleFailed prints failure information when a cycle fails at a step,
+	// listing the steps that ran and their attempts.
+	CycleFailed(storyKey string, failedStep string, steps []StepResult, duration time.Duration)
 
 	// QueueHeader prints the header for a batch queue operation.
 	QueueHeader(count int, stories []string)
@@ -88,6 +198,27 @@ type Printer interface {
 	// CommandFooter prints the footer after a command completes with
 	// duration, success status, and exit code.
 	CommandFooter(duration time.Duration, success bool, exitCode int)
+
+	// Plan prints the steps a dry run would execute for a story. No steps
+	// means the story is already complete.
+	Plan(storyKey string, steps []PlanStep)
+
+	// Board prints the sprint board of the status command.
+	Board(board Board)
+
+	// Story prints the details of a story for the show command.
+	Story(details StoryDetails)
+
+	// Info prints a progress message.
+	Info(format string, args ...interface{})
+	// Warning prints a problem that does not stop the command.
+	Warning(format string, args ...interface{})
+	// Error prints a problem that stops the command or a story.
+	Error(format string, args ...interface{})
+	// Stderr prints a line that Claude wrote to stderr.
+	Stderr(line string)
+	// Newline prints an empty line between sections.
+	Newline()
 }
 
 // DefaultPrinter implements [Printer] with lipgloss terminal styling.
@@ -139,6 +270,33 @@ func (p *DefaultPrinter) StepEnd(duration time.Duration, success bool) {
 	// Step end is usually handled by CommandFooter
 }
 
+// StepRetry prints a notice that a failed step attempt will be retried.
+func (p *DefaultPrinter) StepRetry(name string, attempt, maxAttempts, exitCode int, delay time.Duration) {
+	p.writeln("%s %s failed with exit code %d (attempt %d/%d), retrying in %s\n",
+		iconRetry, name, exitCode, attempt, maxAttempts, delay.Round(time.Millisecond))
+}
+
+// StepAborted prints a notice that a step was stopped before it finished.
+func (p *DefaultPrinter) StepAborted(name, reason string) {
+	p.writeln("%s %s stopped: %s\n", errorStyle.Render(iconAborted), name, reason)
+}
+
+// VerifyResult prints the outcome of a verification command.
+func (p *DefaultPrinter) VerifyResult(command string, exitCode int, duration time.Duration, output string, truncateLines int) {
+	if exitCode == 0 {
+		p.writeln("%s Verified: %s (%s)", successStyle.Render(iconSuccess), command, duration.Round(time.Millisecond))
+		return
+	}
+
+	p.writeln("%s Verification failed: %s exited with %d (%s)",
+		errorStyle.Render(iconError), command, exitCode, duration.Round(time.Millisecond))
+	if output = strings.TrimRight(output, "\n"); output != "" {
+		indented := "   " + strings.ReplaceAll(truncateOutput(output, truncateLines), "\n", "\n   ")
+		p.writeln("%s", indented)
+	}
+	p.writeln("")
+}
+
 // ToolUse prints tool invocation details.
 func (p *DefaultPrinter) ToolUse(name, description, command, filePath string) {
 	p.writeln("%s Tool: %s", iconTool, toolNameStyle.Render(name))
@@ -197,24 +355,87 @@ func (p *DefaultPrinter) CycleSummary(storyKey string, steps []StepResult, total
 	sb.WriteString(fmt.Sprintf("Story: %s\n", storyKey))
 	sb.WriteString(strings.Repeat("─", 50) + "\n")
 
+	var usage Usage
 	for i, step := range steps {
-		sb.WriteString(fmt.Sprintf("[%d] %-15s %s\n", i+1, step.Name, step.Duration.Round(time.Millisecond)))
+		sb.WriteString(fmt.Sprintf("[%d] %-15s %s", i+1, step.Name, step.Duration.Round(time.Millisecond)))
+		if !step.Usage.IsZero() {
+			sb.WriteString("  " + formatCost(step.Usage.CostUSD))
+		}
+		if len(step.Attempts) > 1 {
+			sb.WriteString(fmt.Sprintf("  (%d attempts)", len(step.Attempts)))
+		}
+		if step.Findings > 0 {
+			sb.WriteString(fmt.Sprintf("  (%s)", pluralize(step.Findings, "finding", "findings")))
+		}
+		sb.WriteString("\n")
+		writeAttempts(&sb, step.Attempts)
+		usage = usage.Add(step.Usage)
 	}
 
 	sb.WriteString(strings.Repeat("─", 50) + "\n")
 	sb.WriteString(fmt.Sprintf("Total: %s", totalDuration.Round(time.Millisecond)))
+	if !usage.IsZero() {
+		sb.WriteString(fmt.Sprintf("\nUsage: %s", usage))
+	}
 
 	p.writeln(summaryStyle.Render(sb.String()))
 }
 
+// writeAttempts lists the individual attempts of a step that was retried.
+func writeAttempts(sb *strings.Builder, attempts []Attempt) {
+	if len(attempts) <= 1 {
+		return
+	}
+	for i, a := range attempts {
+		sb.WriteString(fmt.Sprintf("      attempt %d: exit %d  %s", i+1, a.ExitCode, a.Duration.Round(time.Millisecond)))
+		if !a.Usage.IsZero() {
+			sb.WriteString("  " + formatCost(a.Usage.CostUSD))
+		}
+		sb.WriteString("\n")
+	}
+}
+
 // CycleFailed prints failure information when a cycle fails.
-func (p *DefaultPrinter) CycleFailed(storyKey string, failedStep string, duration time.Duration) {
+func (p *DefaultPrinter) CycleFailed(storyKey string, failedStep string, steps []StepResult, duration time.Duration) {
 	var sb strings.Builder
 
 	sb.WriteString(errorStyle.Render(iconError+" CYCLE FAILED") + "\n")
 	sb.WriteString(fmt.Sprintf("Story: %s\n", storyKey))
 	sb.WriteString(fmt.Sprintf("Failed at: %s\n", failedStep))
+	if reason := (StoryResult{Steps: steps}).Reason(); reason != "" {
+		sb.WriteString(fmt.Sprintf("Reason: %s\n", reason))
+	}
+
+	if len(steps) > 0 {
+		sb.WriteString(strings.Repeat("─", 50) + "\n")
+		for i, step := range steps {
+			icon := successStyle.Render(iconSuccess)
+			if !step.Success {
+				icon = errorStyle.Render(iconError)
+			}
+			sb.WriteString(fmt.Sprintf("%s [%d] %-15s %s", icon, i+1, step.Name, step.Duration.Round(time.Millisecond)))
+			if !step.Usage.IsZero() {
+				sb.WriteString("  " + formatCost(step.Usage.CostUSD))
+			}
+			if len(step.Attempts) > 1 {
+				sb.WriteString(fmt.Sprintf("  (%d attempts)", len(step.Attempts)))
+			}
+			if step.Findings > 0 {
+				sb.WriteString(fmt.Sprintf("  (%s)", pluralize(step.Findings, "finding", "findings")))
+			}
+			sb.WriteString("\n")
+			writeAttempts(&sb, step.Attempts)
+			for _, task := range step.IncompleteTasks {
+				sb.WriteString(fmt.Sprintf("      [ ] %s\n", truncateString(task, 60)))
+			}
+		}
+		sb.WriteString(strings.Repeat("─", 50) + "\n")
+	}
+
 	sb.WriteString(fmt.Sprintf("Duration: %s", duration.Round(time.Millisecond)))
+	if usage := (StoryResult{Steps: steps}).Usage(); !usage.IsZero() {
+		sb.WriteString(fmt.Sprintf("\nUsage: %s", usage))
+	}
 
 	p.writeln(summaryStyle.Render(sb.String()))
 }
@@ -236,30 +457,27 @@ func (p *DefaultPrinter) QueueStoryStart(index, total int, storyKey string) {
 
 // QueueSummary prints the summary after a queue completes or fails.
 func (p *DefaultPrinter) QueueSummary(results []StoryResult, allKeys []string, totalDuration time.Duration) {
-	completed := 0
-	failed := 0
-	skipped := 0
-	for _, r := range results {
-		if r.Skipped {
-			skipped++
-		} else if r.Success {
-			completed++
-		} else {
-			failed++
-		}
-	}
-	remaining := len(allKeys) - len(results)
+	counts := countResults(results, allKeys)
+	completed, skipped, failed := counts.Completed, counts.Skipped, counts.Failed
+	interrupted, remaining := counts.Interrupted, counts.Remaining
 
 	var sb strings.Builder
 
-	if failed == 0 && remaining == 0 {
+	switch {
+	case interrupted > 0:
+		sb.WriteString(errorStyle.Render(iconAborted+" QUEUE INTERRUPTED") + "\n")
+	case failed == 0 && remaining == 0:
 		sb.WriteString(successStyle.Render(iconSuccess+" QUEUE COMPLETE") + "\n")
-	} else {
+	default:
 		sb.WriteString(errorStyle.Render(iconError+" QUEUE STOPPED") + "\n")
 	}
 
 	sb.WriteString(strings.Repeat("─", 50) + "\n")
-	sb.WriteString(fmt.Sprintf("Completed: %d | Skipped: %d | Failed: %d | Remaining: %d\n", completed, skipped, failed, remaining))
+	sb.WriteString(fmt.Sprintf("Completed: %d | Skipped: %d | Failed: %d | Remaining: %d", completed, skipped, failed, remaining))
+	if interrupted > 0 {
+		sb.WriteString(fmt.Sprintf(" | Interrupted: %d", interrupted))
+	}
+	sb.WriteString("\n")
 	sb.WriteString(strings.Repeat("─", 50) + "\n")
 
 	for _, r := range results {
@@ -271,26 +489,58 @@ func (p *DefaultPrinter) QueueSummary(results []StoryResult, allKeys []string, t
 		} else if r.Success {
 			status = successStyle.Render(iconSuccess)
 			suffix = ""
+		} else if r.Interrupted {
+			status = errorStyle.Render(iconAborted)
+			suffix = ""
 		} else {
 			status = errorStyle.Render(iconError)
 			suffix = ""
 		}
 		if suffix != "" {
 			sb.WriteString(fmt.Sprintf("%s %-30s %s\n", status, r.Key, suffix))
-		} else {
-			sb.WriteString(fmt.Sprintf("%s %-30s %s\n", status, r.Key, r.Duration.Round(time.Second)))
+			continue
 		}
+
+		sb.WriteString(fmt.Sprintf("%s %-30s %s", status, r.Key, r.Duration.Round(time.Second)))
+		if usage := r.Usage(); !usage.IsZero() {
+			sb.WriteString("  " + formatCost(usage.CostUSD))
+		}
+		if retries := r.Retries(); retries > 0 {
+			sb.WriteString(fmt.Sprintf("  (%s)", pluralize(retries, "retry", "retries")))
+		}
+		if reason := r.Reason(); reason != "" && !r.Success {
+			sb.WriteString("  " + reason)
+		}
+		if r.Interrupted {
+			sb.WriteString("  (interrupted)")
+		}
+		sb.WriteString("\n")
 	}
 
 	if remaining > 0 {
-		for i := len(results); i < len(allKeys); i++ {
-			sb.WriteString(fmt.Sprintf("%s %-30s (pending)\n", mutedStyle.Render(iconPending), allKeys[i]))
+		// Results may not be a prefix of allKeys when stories run in parallel
+		processed := make(map[string]bool, len(results))
+		for _, r := range results {
+			processed[r.Key] = true
+		}
+		for _, key := range allKeys {
+			if !processed[key] {
+				sb.WriteString(fmt.Sprintf("%s %-30s (pending)\n", mutedStyle.Render(iconPending), key))
+			}
 		}
 	}
 
 	sb.WriteString(strings.Repeat("─", 50) + "\n")
 	sb.WriteString(fmt.Sprintf("Total: %s", totalDuration.Round(time.Second)))
 
+	var usage Usage
+	for _, r := range results {
+		usage = usage.Add(r.Usage())
+	}
+	if !usage.IsZero() {
+		sb.WriteString(fmt.Sprintf("\nUsage: %s", usage))
+	}
+
 	p.writeln(summaryStyle.Render(sb.String()))
 }
 
@@ -315,6 +565,47 @@ func (p *DefaultPrinter) CommandFooter(duration time.Duration, success bool, exi
 	p.Divider()
 }
 
+// Plan prints the numbered steps of a dry run for a story.
+func (p *DefaultPrinter) Plan(storyKey string, steps []PlanStep) {
+	p.writeln("Story %s:", storyKey)
+	if len(steps) == 0 {
+		p.writeln("  (already complete)")
+	}
+	for i, step := range steps {
+		if step.Fix != "" {
+			p.writeln("  %d. %s → %s (fixed by %s, up to %d reviews)", i+1, step.Workflow, step.NextStatus, step.Fix, step.MaxIterations)
+			continue
+		}
+		p.writeln("  %d. %s → %s", i+1, step.Workflow, step.NextStatus)
+	}
+	p.writeln("")
+}
+
+// Info prints a progress message.
+func (p *DefaultPrinter) Info(format string, args ...interface{}) {
+	p.writeln(format, args...)
+}
+
+// Warning prints a message prefixed with "Warning:".
+func (p *DefaultPrinter) Warning(format string, args ...interface{}) {
+	p.writeln("Warning: "+format, args...)
+}
+
+// Error prints a message prefixed with "Error:".
+func (p *DefaultPrinter) Error(format string, args ...interface{}) {
+	p.writeln("Error: "+format, args...)
+}
+
+// Stderr prints a line from Claude's stderr.
+func (p *DefaultPrinter) Stderr(line string) {
+	p.writeln("[stderr] %s", line)
+}
+
+// Newline prints an empty line.
+func (p *DefaultPrinter) Newline() {
+	p.writeln("")
+}
+
 // truncateString truncates a string to maxLen, adding "..." if truncated.
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -342,3 +633,11 @@ func truncateOutput(output string, maxLines int) string {
 
 	return fmt.Sprintf("%s\n  ... (%d lines omitted) ...\n%s", first, omitted, last)
 }
+
+// pluralize formats n with the singular or plural noun.
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -177,11 +177,27 @@ func TestDefaultPrinter_CycleSummary(t *testing.T) {
 	assert.Contains(t, output, "dev-story")
 }
 
+func TestDefaultPrinter_CycleSummary_Findings(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	steps := []StepResult{
+		{Name: "code-review", Duration: 10 * time.Second, Success: true, Findings: 2},
+		{Name: "dev-story", Duration: 20 * time.Second, Success: true},
+		{Name: "code-review", Duration: 10 * time.Second, Success: true},
+	}
+
+	p.CycleSummary("test-story", steps, 40*time.Second)
+
+	assert.Contains(t, buf.String(), "[1] code-review     10s  (2 findings)")
+	assert.Equal(t, 1, strings.Count(buf.String(), "finding"))
+}
+
 func TestDefaultPrinter_CycleFailed(t *testing.T) {
 	var buf bytes.Buffer
 	p := NewPrinterWithWriter(&buf)
 
-	p.CycleFailed("test-story", "dev-story", 15*time.Second)
+	p.CycleFailed("test-story", "dev-story", nil, 15*time.Second)
 
 	output := buf.String()
 	assert.Contains(t, output, "CYCLE FAILED")
@@ -189,6 +205,192 @@ func TestDefaultPrinter_CycleFailed(t *testing.T) {
 	assert.Contains(t, output, "dev-story")
 }
 
+func TestDefaultPrinter_CycleFailed_IncompleteTasks(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	steps := []StepResult{{
+		Name:            "dev-story",
+		Duration:        20 * time.Second,
+		ExitCode:        1,
+		Reason:          "2 tasks not done in the story file",
+		IncompleteTasks: []string{"Add indexes", "Document the schema"},
+	}}
+
+	p.CycleFailed("test-story", "dev-story", steps, 20*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "Reason: 2 tasks not done in the story file")
+	assert.Contains(t, output, "[ ] Add indexes")
+	assert.Contains(t, output, "[ ] Document the schema")
+}
+
+func TestDefaultPrinter_StepRetry(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.StepRetry("git-commit", 1, 3, 128, 10*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "git-commit failed with exit code 128")
+	assert.Contains(t, output, "attempt 1/3")
+	assert.Contains(t, output, "retrying in 10s")
+}
+
+func TestDefaultPrinter_CycleSummary_Attempts(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	steps := []StepResult{
+		{Name: "code-review", Duration: 10 * time.Second, Success: true, Attempts: []Attempt{{Duration: 10 * time.Second}}},
+		{Name: "git-commit", Duration: 35 * time.Second, Success: true, Attempts: []Attempt{
+			{ExitCode: 128, Duration: 5 * time.Second},
+			{ExitCode: 0, Duration: 20 * time.Second},
+		}},
+	}
+
+	p.CycleSummary("test-story", steps, 45*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "(2 attempts)")
+	assert.Contains(t, output, "attempt 1: exit 128  5s")
+	assert.Contains(t, output, "attempt 2: exit 0  20s")
+	assert.NotContains(t, output, "(1 attempts)")
+}
+
+func TestDefaultPrinter_CycleFailed_Attempts(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	steps := []StepResult{
+		{Name: "dev-story", Duration: time.Minute, Success: true},
+		{Name: "code-review", Duration: time.Minute, ExitCode: 1, Attempts: []Attempt{{ExitCode: 1}, {ExitCode: 1}, {ExitCode: 1}}},
+	}
+
+	p.CycleFailed("test-story", "code-review", steps, 2*time.Minute)
+
+	output := buf.String()
+	assert.Contains(t, output, "Failed at: code-review")
+	assert.Contains(t, output, "(3 attempts)")
+	assert.Contains(t, output, "attempt 3: exit 1")
+}
+
+func TestStoryResult_Retries(t *testing.T) {
+	r := StoryResult{Steps: []StepResult{
+		{Attempts: []Attempt{{}}},
+		{Attempts: []Attempt{{ExitCode: 1}, {ExitCode: 1}, {}}},
+		{},
+	}}
+
+	assert.Equal(t, 2, r.Retries())
+}
+
+func TestDefaultPrinter_QueueSummary_Retries(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	results := []StoryResult{
+		{Key: "story-1", Success: true, Duration: time.Minute, Steps: []StepResult{
+			{Attempts: []Attempt{{ExitCode: 1}, {}}},
+		}},
+	}
+
+	p.QueueSummary(results, []string{"story-1"}, time.Minute)
+
+	assert.Contains(t, buf.String(), "(1 retry)")
+}
+
+func TestDefaultPrinter_CycleSummary_Usage(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	steps := []StepResult{
+		{Name: "code-review", Duration: 10 * time.Second, Success: true, Usage: Usage{CostUSD: 0.4, InputTokens: 1000, OutputTokens: 200, Turns: 3}},
+		{Name: "git-commit", Duration: 5 * time.Second, Success: true, Usage: Usage{CostUSD: 0.1, InputTokens: 500, OutputTokens: 100, Turns: 1}},
+	}
+
+	p.CycleSummary("test-story", steps, 15*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "$0.40")
+	assert.Contains(t, output, "$0.10")
+	assert.Contains(t, output, "Usage: $0.50 | 1.5k in / 300 out | 4 turns")
+}
+
+func TestDefaultPrinter_QueueSummary_Usage(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	results := []StoryResult{
+		{Key: "story-1", Success: true, Duration: time.Minute, Steps: []StepResult{{Usage: Usage{CostUSD: 1.25, Turns: 4}}}},
+		{Key: "story-2", Success: true, Duration: time.Minute, Steps: []StepResult{{Usage: Usage{CostUSD: 0.75, Turns: 2}}}},
+	}
+
+	p.QueueSummary(results, []string{"story-1", "story-2"}, 2*time.Minute)
+
+	output := buf.String()
+	assert.Contains(t, output, "$1.25")
+	assert.Contains(t, output, "$0.75")
+	assert.Contains(t, output, "Usage: $2.00")
+}
+
+func TestDefaultPrinter_QueueSummary_NoUsage(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.QueueSummary([]StoryResult{{Key: "story-1", Success: true}}, []string{"story-1"}, time.Minute)
+
+	assert.NotContains(t, buf.String(), "Usage:")
+}
+
+func TestDefaultPrinter_StepAborted(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.StepAborted("dev-story", "dev-story budget exceeded: 51 turns (limit 50 turns)")
+
+	assert.Contains(t, buf.String(), "dev-story stopped: dev-story budget exceeded: 51 turns (limit 50 turns)")
+}
+
+func TestDefaultPrinter_VerifyResult(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.VerifyResult("go build ./...", 0, time.Second, "", 20)
+	p.VerifyResult("go test ./...", 1, 2*time.Second, "=== RUN TestSchema\n--- FAIL: TestSchema\nFAIL\n", 20)
+
+	output := buf.String()
+	assert.Contains(t, output, "Verified: go build ./... (1s)")
+	assert.Contains(t, output, "Verification failed: go test ./... exited with 1 (2s)")
+	assert.Contains(t, output, "   --- FAIL: TestSchema")
+}
+
+func TestDefaultPrinter_CycleFailed_Reason(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	steps := []StepResult{
+		{Name: "dev-story", ExitCode: -1, Reason: "run budget exceeded: $5.10 spent (limit $5.00)"},
+	}
+
+	p.CycleFailed("test-story", "dev-story", steps, time.Minute)
+
+	assert.Contains(t, buf.String(), "Reason: run budget exceeded: $5.10 spent (limit $5.00)")
+}
+
+func TestDefaultPrinter_QueueSummary_Reason(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	results := []StoryResult{
+		{Key: "story-1", FailedAt: "dev-story", Steps: []StepResult{{Name: "dev-story", Reason: "dev-story budget exceeded: 45m0s elapsed (limit 45m0s)"}}},
+	}
+
+	p.QueueSummary(results, []string{"story-1"}, time.Hour)
+
+	assert.Contains(t, buf.String(), "dev-story budget exceeded: 45m0s elapsed (limit 45m0s)")
+}
+
 func TestDefaultPrinter_QueueHeader(t *testing.T) {
 	var buf bytes.Buffer
 	p := NewPrinterWithWriter(&buf)
@@ -246,6 +448,79 @@ func TestDefaultPrinter_QueueSummary_WithFailure(t *testing.T) {
 	assert.Contains(t, output, "(pending)")
 }
 
+func TestDefaultPrinter_QueueSummary_PendingNotPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	// Parallel runs can finish story-3 while story-2 was never started
+	results := []StoryResult{
+		{Key: "story-1", Success: false, Duration: 5 * time.Second, FailedAt: "dev-story"},
+		{Key: "story-3", Success: true, Duration: 10 * time.Second},
+	}
+
+	p.QueueSummary(results, []string{"story-1", "story-2", "story-3"}, 15*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "Remaining: 1")
+	assert.Regexp(t, `story-2\s+\(pending\)`, output)
+	assert.NotRegexp(t, `story-3\s+\(pending\)`, output)
+}
+
+func TestDefaultPrinter_QueueSummary_Interrupted(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	results := []StoryResult{
+		{Key: "story-1", Success: true, Duration: 10 * time.Second},
+		{Key: "story-2", Success: false, Duration: 5 * time.Second, Interrupted: true},
+	}
+
+	p.QueueSummary(results, []string{"story-1", "story-2", "story-3"}, 15*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "QUEUE INTERRUPTED")
+	assert.Contains(t, output, "Failed: 0 | Remaining: 1 | Interrupted: 1")
+	assert.Regexp(t, `story-2\s+.*\(interrupted\)`, output)
+}
+
+func TestDefaultPrinter_Plan(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.Plan("7-1-schema", []PlanStep{
+		{Workflow: "create-story", NextStatus: "ready-for-dev"},
+		{Workflow: "dev-story", NextStatus: "review"},
+		{Workflow: "code-review", NextStatus: "done", Fix: "dev-story", MaxIterations: 3},
+	})
+	p.Plan("7-2-api", nil)
+
+	assert.Equal(t, "Story 7-1-schema:\n"+
+		"  1. create-story → ready-for-dev\n"+
+		"  2. dev-story → review\n"+
+		"  3. code-review → done (fixed by dev-story, up to 3 reviews)\n"+
+		"\n"+
+		"Story 7-2-api:\n"+
+		"  (already complete)\n"+
+		"\n", buf.String())
+}
+
+func TestDefaultPrinter_Messages(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.Info("Story %s completed successfully", "7-1-schema")
+	p.Warning("failed to write run log: %s", "disk full")
+	p.Error("unknown status value: %s", "blocked")
+	p.Stderr("rate limited")
+	p.Newline()
+
+	assert.Equal(t, "Story 7-1-schema completed successfully\n"+
+		"Warning: failed to write run log: disk full\n"+
+		"Error: unknown status value: blocked\n"+
+		"[stderr] rate limited\n"+
+		"\n", buf.String())
+}
+
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -0,0 +1,103 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// StoryDetails is a story shown by the show command, combining its story file
+// with its entry in the sprint status file.
+type StoryDetails struct {
+	// Key is the story key.
+	Key string `json:"-"`
+	// Title is the title of the story file.
+	Title string `json:"title,omitempty"`
+	// Path is the path of the story file.
+	Path string `json:"path"`
+	// SprintStatus is the status in the sprint status file, and FileStatus
+	// the status written in the story file, if any.
+	SprintStatus string `json:"sprint_status"`
+	FileStatus   string `json:"file_status,omitempty"`
+	// AcceptanceCriteria lists the story's acceptance criteria.
+	AcceptanceCriteria []string `json:"acceptance_criteria,omitempty"`
+	// TasksDone and TasksTotal count the checked and all tasks and subtasks;
+	// OpenTasks lists the unchecked ones.
+	TasksDone  int      `json:"tasks_done"`
+	TasksTotal int      `json:"tasks_total"`
+	OpenTasks  []string `json:"open_tasks,omitempty"`
+	// Files lists the files of the Dev Agent Record.
+	Files []string `json:"files,omitempty"`
+	// ChangeLog lists the entries of the story's change log.
+	ChangeLog []string `json:"change_log,omitempty"`
+}
+
+// Percent returns the share of done tasks, rounded down. A story without
+// tasks is 0% done.
+func (d StoryDetails) Percent() int {
+	if d.TasksTotal == 0 {
+		return 0
+	}
+	return d.TasksDone * 100 / d.TasksTotal
+}
+
+// Story prints the details of a story: its title and statuses, task progress,
+// acceptance criteria, open tasks, files and change log. Empty sections are
+// left out.
+func (p *DefaultPrinter) Story(details StoryDetails) {
+	var sb strings.Builder
+
+	title := details.Title
+	if title == "" {
+		title = details.Key
+	}
+	sb.WriteString(headerStyle.Render(title) + "\n")
+
+	field := func(name, value string) {
+		sb.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-7s", name+":")), value))
+	}
+	field("Story", details.Key)
+	field("File", details.Path)
+
+	statuses := "sprint " + details.SprintStatus
+	switch {
+	case details.FileStatus == "":
+	case details.FileStatus == details.SprintStatus:
+		statuses += " · story file " + details.FileStatus
+	default:
+		// The story file disagrees with the sprint status.
+		statuses += " · " + lipgloss.NewStyle().Foreground(colorWarning).Render("story file "+details.FileStatus)
+	}
+	field("Status", statuses)
+
+	tasks := "none"
+	if details.TasksTotal > 0 {
+		tasks = fmt.Sprintf("%s  %d/%d done (%d%%)", progressBar(details.TasksDone, details.TasksTotal), details.TasksDone, details.TasksTotal, details.Percent())
+	}
+	field("Tasks", tasks)
+
+	writeSection := func(name string, items []string, format func(i int, item string) string) {
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString("\n" + labelStyle.Render(name) + "\n")
+		for i, item := range items {
+			sb.WriteString("  " + format(i, item) + "\n")
+		}
+	}
+	writeSection("Acceptance Criteria", details.AcceptanceCriteria, func(i int, item string) string {
+		return fmt.Sprintf("%d. %s", i+1, item)
+	})
+	writeSection("Open Tasks", details.OpenTasks, func(_ int, item string) string {
+		return "[ ] " + item
+	})
+	writeSection("Files", details.Files, func(_ int, item string) string {
+		return "- " + item
+	})
+	writeSection("Change Log", details.ChangeLog, func(_ int, item string) string {
+		return "- " + item
+	})
+
+	fmt.Fprint(p.out, sb.String())
+}
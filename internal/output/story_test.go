@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testStory = StoryDetails{
+	Key:                "7-1-schema",
+	Title:              "Story 7.1: Define schema",
+	Path:               "_bmad-output/implementation-artifacts/7-1-schema.md",
+	SprintStatus:       "review",
+	FileStatus:         "in-progress",
+	AcceptanceCriteria: []string{"Requests are validated", "Errors are returned as JSON"},
+	TasksDone:          3,
+	TasksTotal:         4,
+	OpenTasks:          []string{"Document the schema"},
+	Files:              []string{"internal/db/schema.go"},
+	ChangeLog:          []string{"2026-01-10 | 1.0 | Initial draft"},
+}
+
+func TestStoryDetails_Percent(t *testing.T) {
+	tests := []struct {
+		name        string
+		done, total int
+		want        int
+	}{
+		{"no tasks", 0, 0, 0},
+		{"rounded down", 2, 3, 66},
+		{"all done", 4, 4, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StoryDetails{TasksDone: tt.done, TasksTotal: tt.total}.Percent())
+		})
+	}
+}
+
+func TestDefaultPrinter_Story(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.Story(testStory)
+
+	out := buf.String()
+	assert.Contains(t, out, "Story 7.1: Define schema")
+	assert.Contains(t, out, "_bmad-output/implementation-artifacts/7-1-schema.md")
+	assert.Contains(t, out, "sprint review · story file in-progress")
+	assert.Contains(t, out, "3/4 done (75%)")
+	assert.Contains(t, out, "  1. Requests are validated\n  2. Errors are returned as JSON\n")
+	assert.Contains(t, out, "  [ ] Document the schema\n")
+	assert.Contains(t, out, "  - internal/db/schema.go\n")
+	assert.Contains(t, out, "  - 2026-01-10 | 1.0 | Initial draft\n")
+}
+
+func TestDefaultPrinter_Story_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	p.Story(StoryDetails{Key: "7-2-api", Path: "7-2-api.md", SprintStatus: "ready-for-dev"})
+
+	out := buf.String()
+	assert.Contains(t, out, "7-2-api")
+	assert.Contains(t, out, "sprint ready-for-dev\n")
+	assert.Contains(t, out, "none")
+	assert.NotContains(t, out, "Acceptance Criteria")
+	assert.NotContains(t, out, "Change Log")
+}
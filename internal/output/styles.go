@@ -98,6 +98,8 @@ const (
 	iconError      = "✗"  // Failed
 	iconPending    = "○"  // Not yet started
 	iconInProgress = "●"  // Currently running
+	iconRetry      = "↻"  // Retrying after failure
+	iconAborted    = "⊘"  // Stopped by a limit
 	iconTool       = "┌─" // Tool block start
 	iconToolEnd    = "└─" // Tool block end
 	iconToolLine   = "│"  // Tool block continuation
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Usage is the token usage and cost of one or more Claude sessions.
+//
+// Values are reported by Claude in its result event and added up per step,
+// per story, and per queue for display in summaries.
+type Usage struct {
+	// CostUSD is the total cost in US dollars.
+	CostUSD float64 `json:"cost_usd"`
+	// InputTokens is the number of uncached input tokens.
+	InputTokens int64 `json:"input_tokens"`
+	// OutputTokens is the number of output tokens.
+	OutputTokens int64 `json:"output_tokens"`
+	// CacheCreationTokens is the number of input tokens written to the cache.
+	CacheCreationTokens int64 `json:"cache_creation_tokens"`
+	// CacheReadTokens is the number of input tokens read from the cache.
+	CacheReadTokens int64 `json:"cache_read_tokens"`
+	// Turns is the number of conversation turns.
+	Turns int `json:"turns"`
+	// DurationMS is the session time reported by Claude, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// Add returns the sum of u and other.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		CostUSD:             u.CostUSD + other.CostUSD,
+		InputTokens:         u.InputTokens + other.InputTokens,
+		OutputTokens:        u.OutputTokens + other.OutputTokens,
+		CacheCreationTokens: u.CacheCreationTokens + other.CacheCreationTokens,
+		CacheReadTokens:     u.CacheReadTokens + other.CacheReadTokens,
+		Turns:               u.Turns + other.Turns,
+		DurationMS:          u.DurationMS + other.DurationMS,
+	}
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// TotalInputTokens returns input tokens including cache writes and reads.
+func (u Usage) TotalInputTokens() int64 {
+	return u.InputTokens + u.CacheCreationTokens + u.CacheReadTokens
+}
+
+// Duration returns the session time reported by Claude.
+func (u Usage) Duration() time.Duration {
+	return time.Duration(u.DurationMS) * time.Millisecond
+}
+
+// String formats the usage for summaries, e.g.
+// "$1.23 | 45.6k in / 7.8k out | 12 turns | 4m12s in Claude".
+func (u Usage) String() string {
+	parts := []string{
+		formatCost(u.CostUSD),
+		fmt.Sprintf("%s in / %s out", formatTokens(u.TotalInputTokens()), formatTokens(u.OutputTokens)),
+	}
+	if u.Turns > 0 {
+		parts = append(parts, pluralize(u.Turns, "turn", "turns"))
+	}
+	if u.DurationMS > 0 {
+		parts = append(parts, fmt.Sprintf("%s in Claude", u.Duration().Round(time.Second)))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Cost formats the cost in US dollars, e.g. "$1.23".
+func (u Usage) Cost() string {
+	return formatCost(u.CostUSD)
+}
+
+// formatCost formats a US dollar amount with cent precision, or more for
+// amounts below one cent.
+func formatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return fmt.Sprintf("$%.4f", usd)
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// formatTokens formats a token count compactly, e.g. 950, 12.3k, 4.5M.
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage_Add(t *testing.T) {
+	a := Usage{CostUSD: 0.5, InputTokens: 10, OutputTokens: 20, CacheCreationTokens: 30, CacheReadTokens: 40, Turns: 2, DurationMS: 1000}
+	b := Usage{CostUSD: 0.25, InputTokens: 1, OutputTokens: 2, CacheCreationTokens: 3, CacheReadTokens: 4, Turns: 1, DurationMS: 500}
+
+	sum := a.Add(b)
+
+	assert.Equal(t, Usage{CostUSD: 0.75, InputTokens: 11, OutputTokens: 22, CacheCreationTokens: 33, CacheReadTokens: 44, Turns: 3, DurationMS: 1500}, sum)
+	assert.Equal(t, 1500*time.Millisecond, sum.Duration())
+	assert.Equal(t, int64(88), sum.TotalInputTokens())
+}
+
+func TestUsage_IsZero(t *testing.T) {
+	assert.True(t, Usage{}.IsZero())
+	assert.False(t, Usage{Turns: 1}.IsZero())
+}
+
+func TestUsage_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage Usage
+		want  string
+	}{
+		{
+			name:  "full usage",
+			usage: Usage{CostUSD: 1.234, InputTokens: 600, CacheReadTokens: 45_000, OutputTokens: 7_800, Turns: 12},
+			want:  "$1.23 | 45.6k in / 7.8k out | 12 turns",
+		},
+		{
+			name:  "single turn",
+			usage: Usage{CostUSD: 0.5, InputTokens: 900, OutputTokens: 100, Turns: 1},
+			want:  "$0.50 | 900 in / 100 out | 1 turn",
+		},
+		{
+			name:  "with duration",
+			usage: Usage{CostUSD: 1.234, InputTokens: 45_600, OutputTokens: 7_800, Turns: 12, DurationMS: 251_600},
+			want:  "$1.23 | 45.6k in / 7.8k out | 12 turns | 4m12s in Claude",
+		},
+		{
+			name:  "no turns",
+			usage: Usage{InputTokens: 2_500_000},
+			want:  "$0.00 | 2.5M in / 0 out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.usage.String())
+		})
+	}
+}
+
+func TestFormatCost(t *testing.T) {
+	assert.Equal(t, "$0.00", formatCost(0))
+	assert.Equal(t, "$0.0042", formatCost(0.0042))
+	assert.Equal(t, "$12.35", formatCost(12.345))
+}
+
+func TestUsage_Cost(t *testing.T) {
+	assert.Equal(t, "$1.23", Usage{CostUSD: 1.234}.Cost())
+}
@@ -0,0 +1,45 @@
+package report_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/report"
+)
+
+// Example_junit demonstrates writing a JUnit report of a queue run, as
+// requested with --report junit=report.xml.
+func Example_junit() {
+	spec, err := report.ParseSpec("junit=report.xml")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	run := report.Run{
+		Name:    "queue",
+		Stories: []string{"7-1-define-schema"},
+		Results: []output.StoryResult{{
+			Key:     "7-1-define-schema",
+			Success: true,
+			Steps: []output.StepResult{
+				{Name: "code-review", Success: true, Duration: 1500 * time.Millisecond},
+			},
+		}},
+	}
+
+	if err := report.WriteJUnit(os.Stdout, run); err != nil {
+		fmt.Println("error:", err)
+	}
+	fmt.Println("format:", spec.Format)
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <testsuites name="bmad-automate queue" tests="1" failures="0" errors="0" skipped="0" time="0.000">
+	//   <testsuite name="7-1-define-schema" tests="1" failures="0" errors="0" skipped="0" time="0.000">
+	//     <testcase name="code-review" classname="7-1-define-schema" time="1.500"></testcase>
+	//   </testsuite>
+	// </testsuites>
+	// format: junit
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"html/template"
+	"io"
+)
+
+// htmlTemplate renders a [summary] as a self-contained HTML page.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; color: #222; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.25rem 0.5rem; text-align: left; }
+pre { background: #f6f8fa; padding: 0.5rem; overflow-x: auto; white-space: pre-wrap; }
+.step { margin-left: 1rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<ul>
+{{- if .Started}}
+<li><strong>Started:</strong> {{.Started}}</li>
+{{- end}}
+<li><strong>Duration:</strong> {{.Duration}}</li>
+<li><strong>Cost:</strong> {{.Cost}}</li>
+<li><strong>Stories:</strong> {{.Counts}}</li>
+</ul>
+<table>
+<tr><th>Story</th><th>Outcome</th><th>Status</th><th>Duration</th><th>Cost</th></tr>
+{{- range .Stories}}
+<tr><td>{{.Key}}</td><td>{{.Outcome}}</td><td>{{.Statuses}}</td><td>{{.Duration}}</td><td>{{.Cost}}</td></tr>
+{{- end}}
+</table>
+{{- range .Stories}}
+{{- if .Steps}}
+<h2>{{.Key}}</h2>
+<p>{{.Outcome}} in {{.Duration}}, {{.Cost}}. Status: {{.Statuses}}</p>
+{{- range .Steps}}
+<div class="step">
+<h3>{{.Name}}</h3>
+<ul>
+<li><strong>Status:</strong> {{.Statuses}}</li>
+<li><strong>Result:</strong> {{.Result}}</li>
+<li><strong>Duration:</strong> {{.Duration}}</li>
+<li><strong>Cost:</strong> {{.Cost}}</li>
+{{- if gt .Attempts 1}}
+<li><strong>Attempts:</strong> {{.Attempts}}</li>
+{{- end}}
+</ul>
+{{- if .Files}}
+<h4>Files touched</h4>
+<ul>
+{{- range .Files}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Commands}}
+<h4>Commands</h4>
+<pre>{{range .Commands}}{{.}}
+{{end}}</pre>
+{{- end}}
+{{- if .FinalText}}
+<h4>Final output</h4>
+<pre>{{.FinalText}}</pre>
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes run as a self-contained HTML report with the same content
+// as [WriteMarkdown]. Claude's final text is shown preformatted rather than
+// rendered as Markdown.
+func WriteHTML(w io.Writer, run Run) error {
+	return htmlTemplate.Execute(w, summarize(run))
+}
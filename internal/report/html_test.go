@@ -0,0 +1,43 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+func TestWriteHTML(t *testing.T) {
+	run := Run{
+		Name:    "epic 7",
+		Stories: []string{"7-1"},
+		Results: []output.StoryResult{{
+			Key:     "7-1",
+			Success: true,
+			Steps: []output.StepResult{{
+				Name:       "code-review",
+				Success:    true,
+				FromStatus: "review",
+				ToStatus:   "done",
+				Files:      []string{"main.go"},
+				Commands:   []string{"go test ./... && echo ok"},
+				FinalText:  "Fixed <3> issues",
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, run))
+	page := buf.String()
+
+	assert.Contains(t, page, "<title>bmad-automate epic 7</title>")
+	assert.Contains(t, page, "<tr><td>7-1</td><td>completed</td><td>review → done</td>")
+	assert.Contains(t, page, "<h3>code-review</h3>")
+	assert.Contains(t, page, "<li><code>main.go</code></li>")
+	assert.Contains(t, page, "go test ./... &amp;&amp; echo ok")
+	assert.Contains(t, page, "<pre>Fixed &lt;3&gt; issues</pre>", "final text should be escaped")
+	assert.NotContains(t, page, "Attempts", "single attempts should not be listed")
+}
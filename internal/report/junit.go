@@ -0,0 +1,224 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"bmad-automate/internal/output"
+)
+
+// junitTestSuites is the root element of a JUnit report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the steps of one story.
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is one lifecycle step.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+// junitProblem is a failure or error of a test case.
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitSkipped marks a test case that did not run.
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes run as a JUnit XML report.
+//
+// Each story is a testsuite and each lifecycle step that ran is a testcase
+// with its duration. A failed step is reported as a failure whose message
+// names the step and its exit code, plus the reason the step was stopped, if
+// any; an interrupted step is reported as an error. Claude's stderr is
+// attached as system-err, and the attempts of retried steps as system-out.
+//
+// Stories that were already done or never started hold a single skipped
+// testcase, and a story that failed before any step ran, for example because
+// its status could not be read, holds a failed testcase named after
+// [output.StoryResult.FailedAt].
+func WriteJUnit(w io.Writer, run Run) error {
+	root := junitTestSuites{
+		Name: "bmad-automate",
+		Time: seconds(run.Duration),
+	}
+	if run.Name != "" {
+		root.Name += " " + run.Name
+	}
+
+	for _, result := range run.Results {
+		root.Suites = append(root.Suites, junitSuite(result))
+	}
+	for _, key := range run.Pending() {
+		root.Suites = append(root.Suites, junitTestSuite{
+			Name:    key,
+			Tests:   1,
+			Skipped: 1,
+			Time:    seconds(0),
+			Cases: []junitTestCase{{
+				Name:      "lifecycle",
+				ClassName: key,
+				Time:      seconds(0),
+				Skipped:   &junitSkipped{Message: "not started"},
+			}},
+		})
+	}
+
+	for _, suite := range root.Suites {
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Errors += suite.Errors
+		root.Skipped += suite.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSuite converts the result of one story.
+func junitSuite(result output.StoryResult) junitTestSuite {
+	suite := junitTestSuite{
+		Name: result.Key,
+		Time: seconds(result.Duration),
+	}
+
+	for _, step := range result.Steps {
+		tc := junitTestCase{
+			Name:      step.Name,
+			ClassName: result.Key,
+			Time:      seconds(step.Duration),
+			SystemOut: junitAttempts(step.Attempts),
+			SystemErr: junitStderr(step.Attempts),
+		}
+		switch {
+		case step.Success:
+		case result.Interrupted && step.Name == result.FailedAt:
+			tc.Error = &junitProblem{Message: "interrupted", Type: "interrupted"}
+		default:
+			tc.Failure = junitFailure(step)
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	switch {
+	case result.Skipped:
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "lifecycle",
+			ClassName: result.Key,
+			Time:      seconds(0),
+			Skipped:   &junitSkipped{Message: "already done"},
+		})
+	case !result.Success && !result.Interrupted && len(result.Steps) == 0:
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      result.FailedAt,
+			ClassName: result.Key,
+			Time:      seconds(result.Duration),
+			Failure: &junitProblem{
+				Message: fmt.Sprintf("failed at %s", result.FailedAt),
+				Type:    result.FailedAt,
+			},
+		})
+	}
+
+	for _, tc := range suite.Cases {
+		suite.Tests++
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+	return suite
+}
+
+// junitFailure describes a failed step.
+func junitFailure(step output.StepResult) *junitProblem {
+	message := fmt.Sprintf("%s failed with exit code %d", step.Name, step.ExitCode)
+	if step.Reason != "" {
+		message += ": " + step.Reason
+	}
+	return &junitProblem{
+		Message: message,
+		Type:    fmt.Sprintf("exit code %d", step.ExitCode),
+		Text:    message,
+	}
+}
+
+// junitAttempts lists the attempts of a retried step; a single attempt
+// yields nothing.
+func junitAttempts(attempts []output.Attempt) string {
+	if len(attempts) <= 1 {
+		return ""
+	}
+	var sb strings.Builder
+	for i, a := range attempts {
+		fmt.Fprintf(&sb, "attempt %d: exit code %d in %s\n", i+1, a.ExitCode, a.Duration.Round(time.Millisecond))
+	}
+	return sb.String()
+}
+
+// junitStderr joins the stderr of all attempts, with a heading per attempt
+// when the step was retried.
+func junitStderr(attempts []output.Attempt) string {
+	var sb strings.Builder
+	for i, a := range attempts {
+		if len(a.Stderr) == 0 {
+			continue
+		}
+		if len(attempts) > 1 {
+			fmt.Fprintf(&sb, "--- attempt %d ---\n", i+1)
+		}
+		for _, line := range a.Stderr {
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// seconds formats d as JUnit time in seconds.
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,130 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+func writeJUnit(t *testing.T, run Run) junitTestSuites {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, run))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte(xml.Header)))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	return suites
+}
+
+func TestWriteJUnit(t *testing.T) {
+	run := Run{
+		Name:     "epic 7",
+		Stories:  []string{"7-1", "7-2", "7-3", "7-4"},
+		Duration: 95 * time.Second,
+		Results: []output.StoryResult{
+			{Key: "7-1", Success: true, Skipped: true},
+			{
+				Key:      "7-2",
+				Success:  true,
+				Duration: 30 * time.Second,
+				Steps: []output.StepResult{
+					{Name: "code-review", Success: true, Duration: 20 * time.Second},
+					{Name: "git-commit", Success: true, Duration: 10 * time.Second},
+				},
+			},
+			{
+				Key:      "7-3",
+				Duration: 65 * time.Second,
+				FailedAt: "dev-story",
+				Steps: []output.StepResult{
+					{Name: "create-story", Success: true, Duration: 5 * time.Second},
+					{
+						Name:     "dev-story",
+						ExitCode: 124,
+						Duration: 60 * time.Second,
+						Reason:   "timed out after 30s",
+						Attempts: []output.Attempt{
+							{ExitCode: 1, Duration: 30 * time.Second, Stderr: []string{"Error: rate limit reached"}},
+							{ExitCode: 124, Duration: 30 * time.Second},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	suites := writeJUnit(t, run)
+
+	assert.Equal(t, "bmad-automate epic 7", suites.Name)
+	assert.Equal(t, "95.000", suites.Time)
+	assert.Equal(t, 6, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Equal(t, 2, suites.Skipped)
+	require.Len(t, suites.Suites, 4)
+
+	done := suites.Suites[0]
+	assert.Equal(t, "7-1", done.Name)
+	require.Len(t, done.Cases, 1)
+	require.NotNil(t, done.Cases[0].Skipped)
+	assert.Equal(t, "already done", done.Cases[0].Skipped.Message)
+
+	passed := suites.Suites[1]
+	assert.Equal(t, 2, passed.Tests)
+	assert.Equal(t, 0, passed.Failures)
+	assert.Equal(t, "code-review", passed.Cases[0].Name)
+	assert.Equal(t, "7-2", passed.Cases[0].ClassName)
+	assert.Equal(t, "20.000", passed.Cases[0].Time)
+	assert.Nil(t, passed.Cases[0].Failure)
+
+	failed := suites.Suites[2]
+	assert.Equal(t, 1, failed.Failures)
+	devStory := failed.Cases[1]
+	require.NotNil(t, devStory.Failure)
+	assert.Equal(t, "dev-story failed with exit code 124: timed out after 30s", devStory.Failure.Message)
+	assert.Equal(t, "exit code 124", devStory.Failure.Type)
+	assert.Equal(t, "--- attempt 1 ---\nError: rate limit reached\n", devStory.SystemErr)
+	assert.Equal(t, "attempt 1: exit code 1 in 30s\nattempt 2: exit code 124 in 30s\n", devStory.SystemOut)
+
+	pending := suites.Suites[3]
+	assert.Equal(t, "7-4", pending.Name)
+	require.NotNil(t, pending.Cases[0].Skipped)
+	assert.Equal(t, "not started", pending.Cases[0].Skipped.Message)
+}
+
+func TestWriteJUnit_Interrupted(t *testing.T) {
+	suites := writeJUnit(t, Run{
+		Stories: []string{"7-1"},
+		Results: []output.StoryResult{{
+			Key:         "7-1",
+			Interrupted: true,
+			FailedAt:    "dev-story",
+			Steps:       []output.StepResult{{Name: "dev-story", ExitCode: -1}},
+		}},
+	})
+
+	assert.Equal(t, 1, suites.Errors)
+	assert.Equal(t, 0, suites.Failures)
+	require.NotNil(t, suites.Suites[0].Cases[0].Error)
+	assert.Equal(t, "interrupted", suites.Suites[0].Cases[0].Error.Message)
+}
+
+func TestWriteJUnit_FailedBeforeSteps(t *testing.T) {
+	suites := writeJUnit(t, Run{
+		Stories: []string{"7-1"},
+		Results: []output.StoryResult{{Key: "7-1", FailedAt: "status"}},
+	})
+
+	require.Len(t, suites.Suites[0].Cases, 1)
+	tc := suites.Suites[0].Cases[0]
+	assert.Equal(t, "status", tc.Name)
+	require.NotNil(t, tc.Failure)
+	assert.Equal(t, "failed at status", tc.Failure.Message)
+	assert.Equal(t, 1, suites.Failures)
+}
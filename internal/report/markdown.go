@@ -0,0 +1,91 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes run as a Markdown report for sprint retrospectives.
+//
+// The report opens with the run's duration, cost and outcome counts and a
+// table of every story. Each story that ran then gets a section with one
+// subsection per lifecycle step: the status transition it applied, its
+// result, duration and cost, the files Claude's tools touched, the commands
+// it ran and its final text, which is included as Markdown.
+func WriteMarkdown(w io.Writer, run Run) error {
+	s := summarize(run)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", s.Title)
+	if s.Started != "" {
+		fmt.Fprintf(&sb, "- **Started:** %s\n", s.Started)
+	}
+	fmt.Fprintf(&sb, "- **Duration:** %s\n", s.Duration)
+	fmt.Fprintf(&sb, "- **Cost:** %s\n", s.Cost)
+	fmt.Fprintf(&sb, "- **Stories:** %s\n\n", s.Counts)
+
+	sb.WriteString("| Story | Outcome | Status | Duration | Cost |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, story := range s.Stories {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n",
+			markdownCell(story.Key), markdownCell(story.Outcome), story.Statuses, story.Duration, story.Cost)
+	}
+
+	for _, story := range s.Stories {
+		if len(story.Steps) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s\n\n", story.Key)
+		fmt.Fprintf(&sb, "%s in %s, %s. Status: %s\n", story.Outcome, story.Duration, story.Cost, story.Statuses)
+
+		for _, step := range story.Steps {
+			fmt.Fprintf(&sb, "\n### %s\n\n", step.Name)
+			fmt.Fprintf(&sb, "- **Status:** %s\n", step.Statuses)
+			fmt.Fprintf(&sb, "- **Result:** %s\n", step.Result)
+			fmt.Fprintf(&sb, "- **Duration:** %s\n", step.Duration)
+			fmt.Fprintf(&sb, "- **Cost:** %s\n", step.Cost)
+			if step.Attempts > 1 {
+				fmt.Fprintf(&sb, "- **Attempts:** %d\n", step.Attempts)
+			}
+
+			if len(step.Files) > 0 {
+				sb.WriteString("\n**Files touched**\n\n")
+				for _, file := range step.Files {
+					fmt.Fprintf(&sb, "- `%s`\n", file)
+				}
+			}
+			if len(step.Commands) > 0 {
+				fence := markdownFence(step.Commands)
+				sb.WriteString("\n**Commands**\n\n" + fence + "sh\n")
+				for _, command := range step.Commands {
+					sb.WriteString(command + "\n")
+				}
+				sb.WriteString(fence + "\n")
+			}
+			if step.FinalText != "" {
+				sb.WriteString("\n**Final output**\n\n" + step.FinalText + "\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownCell escapes the pipes of a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// markdownFence returns a code fence longer than any run of backticks in
+// lines, so the lines cannot close it.
+func markdownFence(lines []string) string {
+	fence := "```"
+	for _, line := range lines {
+		for strings.Contains(line, fence) {
+			fence += "`"
+		}
+	}
+	return fence
+}
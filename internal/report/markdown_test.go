@@ -0,0 +1,58 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	run := Run{
+		Name:     "epic 7",
+		Stories:  []string{"7-1", "7-2"},
+		Duration: time.Minute,
+		Results: []output.StoryResult{{
+			Key:      "7-1",
+			Success:  true,
+			Duration: time.Minute,
+			Steps: []output.StepResult{{
+				Name:       "dev-story",
+				Success:    true,
+				Duration:   time.Minute,
+				FromStatus: "ready-for-dev",
+				ToStatus:   "review",
+				Usage:      output.Usage{CostUSD: 1.5},
+				Attempts:   []output.Attempt{{ExitCode: 1}, {ExitCode: 0}},
+				Files:      []string{"internal/schema.go"},
+				Commands:   []string{"go test ./...", "echo '```'"},
+				FinalText:  "## Summary\n\nImplemented the schema.\n",
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMarkdown(&buf, run))
+	md := buf.String()
+
+	assert.Contains(t, md, "# bmad-automate epic 7\n")
+	assert.Contains(t, md, "- **Stories:** 1 completed, 0 failed, 0 skipped, 1 not started\n")
+	assert.Contains(t, md, "| 7-1 | completed | ready-for-dev → review | 1m0s | $1.50 |\n")
+	assert.Contains(t, md, "| 7-2 | not started | - | 0s | $0.00 |\n")
+	assert.Contains(t, md, "## 7-1\n")
+	assert.NotContains(t, md, "## 7-2\n", "stories without steps should have no section")
+	assert.Contains(t, md, "### dev-story\n")
+	assert.Contains(t, md, "- **Status:** ready-for-dev → review\n")
+	assert.Contains(t, md, "- **Attempts:** 2\n")
+	assert.Contains(t, md, "- `internal/schema.go`\n")
+	assert.Contains(t, md, "````sh\ngo test ./...\necho '```'\n````\n", "the fence should outlast backticks in commands")
+	assert.Contains(t, md, "**Final output**\n\n## Summary\n\nImplemented the schema.\n")
+}
+
+func TestMarkdownCell(t *testing.T) {
+	assert.Equal(t, `failed at a\|b`, markdownCell("failed at a|b"))
+}
@@ -0,0 +1,150 @@
+// Package report writes reports of story runs to files.
+//
+// Reports are requested on the command line with --report <format>=<path>,
+// parsed by [ParseSpec], and built from the [output.StoryResult] of every
+// story that ran. Stories that never started are included as pending, so a
+// report always covers every story of the command.
+//
+// JUnit reports are meant for CI; Markdown and HTML reports describe what
+// Claude did in each step, such as the files it touched and the commands it
+// ran, for people reviewing the run.
+//
+// Key types:
+//   - [Spec] is a requested report: a format and a file path
+//   - [Run] is the outcome of a command, the input of every report
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/output"
+)
+
+// Report formats.
+const (
+	// FormatJUnit is JUnit XML, see [WriteJUnit].
+	FormatJUnit = "junit"
+
+	// FormatMarkdown is a Markdown run report, see [WriteMarkdown].
+	FormatMarkdown = "markdown"
+
+	// FormatHTML is an HTML run report, see [WriteHTML].
+	FormatHTML = "html"
+)
+
+// writers maps each report format to the function that renders it.
+var writers = map[string]func(w io.Writer, run Run) error{
+	FormatJUnit:    WriteJUnit,
+	FormatMarkdown: WriteMarkdown,
+	FormatHTML:     WriteHTML,
+}
+
+// Run is the outcome of a command that ran one or more stories.
+type Run struct {
+	// Name describes the command, e.g. "epic 7" or "queue".
+	Name string
+
+	// Stories lists every story key of the command, in order.
+	Stories []string
+
+	// Results holds one result per story that was processed.
+	Results []output.StoryResult
+
+	// Started is when the command started.
+	Started time.Time
+
+	// Duration is how long the command took.
+	Duration time.Duration
+}
+
+// Pending returns the stories of the run that have no result.
+func (r Run) Pending() []string {
+	processed := make(map[string]bool, len(r.Results))
+	for _, result := range r.Results {
+		processed[result.Key] = true
+	}
+	var pending []string
+	for _, key := range r.Stories {
+		if !processed[key] {
+			pending = append(pending, key)
+		}
+	}
+	return pending
+}
+
+// Spec is a report requested as <format>=<path>, e.g. junit=report.xml.
+type Spec struct {
+	// Format is the report format, e.g. [FormatJUnit].
+	Format string
+
+	// Path is the file the report is written to.
+	Path string
+
+	// GitIgnore writes a .gitignore that ignores every file into the
+	// directory of Path, unless it has one, so the report is never committed.
+	// Only set it for directories that hold nothing but reports.
+	GitIgnore bool
+}
+
+// ParseSpec parses a <format>=<path> report request.
+func ParseSpec(s string) (Spec, error) {
+	format, path, ok := strings.Cut(s, "=")
+	if !ok || path == "" {
+		return Spec{}, fmt.Errorf("invalid report %q: expected <format>=<path>, e.g. junit=report.xml", s)
+	}
+	if _, ok := writers[format]; !ok {
+		return Spec{}, fmt.Errorf("unknown report format %q (valid formats: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return Spec{Format: format, Path: path}, nil
+}
+
+// String returns the spec as <format>=<path>.
+func (s Spec) String() string {
+	return s.Format + "=" + s.Path
+}
+
+// Write renders the report of run and writes it to the spec's path,
+// creating parent directories as needed.
+func (s Spec) Write(run Run) error {
+	write, ok := writers[s.Format]
+	if !ok {
+		return fmt.Errorf("unknown report format %q", s.Format)
+	}
+
+	if dir := filepath.Dir(s.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if s.GitIgnore {
+		if err := git.IgnoreAll(filepath.Dir(s.Path)); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	f, err := os.Create(s.Path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := write(f, run); err != nil {
+		_ = f.Close() //nolint:errcheck // The write error is more useful
+		return fmt.Errorf("failed to write %s report: %w", s.Format, err)
+	}
+	return f.Close()
+}
+
+// Formats returns the supported report formats in sorted order.
+func Formats() []string {
+	formats := make([]string, 0, len(writers))
+	for format := range writers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
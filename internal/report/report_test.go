@@ -0,0 +1,88 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Spec
+		wantErr string
+	}{
+		{input: "junit=report.xml", want: Spec{Format: FormatJUnit, Path: "report.xml"}},
+		{input: "junit=out/a=b.xml", want: Spec{Format: FormatJUnit, Path: "out/a=b.xml"}},
+		{input: "markdown=retro.md", want: Spec{Format: FormatMarkdown, Path: "retro.md"}},
+		{input: "html=retro.html", want: Spec{Format: FormatHTML, Path: "retro.html"}},
+		{input: "junit", wantErr: "expected <format>=<path>"},
+		{input: "junit=", wantErr: "expected <format>=<path>"},
+		{input: "tap=report.tap", wantErr: "unknown report format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSpec(tt.input)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestSpec_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	spec := Spec{Format: FormatJUnit, Path: path}
+
+	err := spec.Write(Run{Stories: []string{"7-1"}, Results: []output.StoryResult{{Key: "7-1", Success: true}}})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<testsuite name="7-1"`)
+}
+
+func TestSpec_WriteGitIgnore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	require.NoError(t, Spec{Format: FormatMarkdown, Path: filepath.Join(dir, "a.md")}.Write(Run{}))
+	assert.NoFileExists(t, filepath.Join(dir, ".gitignore"))
+
+	require.NoError(t, Spec{Format: FormatMarkdown, Path: filepath.Join(dir, "b.md"), GitIgnore: true}.Write(Run{}))
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*\n", string(data))
+}
+
+func TestSpec_WriteError(t *testing.T) {
+	dir := t.TempDir()
+	// A file where the report directory should be
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "reports"), nil, 0644))
+	spec := Spec{Format: FormatJUnit, Path: filepath.Join(dir, "reports", "junit.xml")}
+
+	assert.Error(t, spec.Write(Run{}))
+}
+
+func TestRun_Pending(t *testing.T) {
+	run := Run{
+		Stories: []string{"7-1", "7-2", "7-3"},
+		Results: []output.StoryResult{{Key: "7-2"}},
+	}
+
+	assert.Equal(t, []string{"7-1", "7-3"}, run.Pending())
+}
+
+func TestFormats(t *testing.T) {
+	assert.Equal(t, []string{FormatHTML, FormatJUnit, FormatMarkdown}, Formats())
+}
@@ -0,0 +1,190 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bmad-automate/internal/output"
+)
+
+// summary is a run prepared for the human-readable report formats, with every
+// value already formatted for display.
+type summary struct {
+	Title    string
+	Started  string
+	Duration string
+	Cost     string
+	Counts   string
+	Stories  []storySummary
+}
+
+// storySummary describes one story of a run.
+type storySummary struct {
+	Key      string
+	Outcome  string
+	Statuses string
+	Duration string
+	Cost     string
+	Steps    []stepSummary
+}
+
+// stepSummary describes one lifecycle step of a story.
+type stepSummary struct {
+	Name      string
+	Statuses  string
+	Result    string
+	Duration  string
+	Cost      string
+	Attempts  int
+	Files     []string
+	Commands  []string
+	FinalText string
+}
+
+// summarize prepares run for the Markdown and HTML reports.
+func summarize(run Run) summary {
+	s := summary{
+		Title:    "bmad-automate",
+		Duration: formatDuration(run.Duration),
+	}
+	if run.Name != "" {
+		s.Title += " " + run.Name
+	}
+	if !run.Started.IsZero() {
+		s.Started = run.Started.Format("2006-01-02 15:04:05")
+	}
+
+	var (
+		total                                            output.Usage
+		completed, failed, interrupted, skipped, pending int
+	)
+	for _, result := range run.Results {
+		total = total.Add(result.Usage())
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Success:
+			completed++
+		case result.Interrupted:
+			interrupted++
+		default:
+			failed++
+		}
+		s.Stories = append(s.Stories, summarizeStory(result))
+	}
+	for _, key := range run.Pending() {
+		pending++
+		s.Stories = append(s.Stories, storySummary{
+			Key:      key,
+			Outcome:  "not started",
+			Statuses: "-",
+			Duration: formatDuration(0),
+			Cost:     output.Usage{}.Cost(),
+		})
+	}
+	s.Cost = total.Cost()
+
+	counts := []string{fmt.Sprintf("%d completed", completed), fmt.Sprintf("%d failed", failed)}
+	if interrupted > 0 {
+		counts = append(counts, fmt.Sprintf("%d interrupted", interrupted))
+	}
+	counts = append(counts, fmt.Sprintf("%d skipped", skipped))
+	if pending > 0 {
+		counts = append(counts, fmt.Sprintf("%d not started", pending))
+	}
+	s.Counts = strings.Join(counts, ", ")
+	return s
+}
+
+// summarizeStory describes the result of one story.
+func summarizeStory(result output.StoryResult) storySummary {
+	story := storySummary{
+		Key:      result.Key,
+		Outcome:  storyOutcome(result),
+		Statuses: storyStatuses(result.Steps),
+		Duration: formatDuration(result.Duration),
+		Cost:     result.Usage().Cost(),
+	}
+	for _, step := range result.Steps {
+		story.Steps = append(story.Steps, stepSummary{
+			Name:      step.Name,
+			Statuses:  stepStatuses(step),
+			Result:    stepOutcome(result, step),
+			Duration:  formatDuration(step.Duration),
+			Cost:      step.Usage.Cost(),
+			Attempts:  len(step.Attempts),
+			Files:     step.Files,
+			Commands:  step.Commands,
+			FinalText: strings.TrimSpace(step.FinalText),
+		})
+	}
+	return story
+}
+
+// storyOutcome describes how a story ended.
+func storyOutcome(result output.StoryResult) string {
+	switch {
+	case result.Skipped:
+		return "skipped (already done)"
+	case result.Success:
+		return "completed"
+	case result.Interrupted && result.FailedAt != "":
+		return "interrupted at " + result.FailedAt
+	case result.Interrupted:
+		return "interrupted"
+	default:
+		return "failed at " + result.FailedAt
+	}
+}
+
+// stepOutcome describes how a step of result ended.
+func stepOutcome(result output.StoryResult, step output.StepResult) string {
+	switch {
+	case step.Success:
+		return "succeeded"
+	case result.Interrupted && step.Name == result.FailedAt:
+		return "interrupted"
+	}
+	outcome := fmt.Sprintf("failed with exit code %d", step.ExitCode)
+	if step.Reason != "" {
+		outcome += ": " + step.Reason
+	}
+	return outcome
+}
+
+// storyStatuses lists the statuses a story went through, e.g.
+// "backlog → ready-for-dev → review", or "-" if it did not change. Steps that
+// keep the status, such as git-commit after code-review, add nothing.
+func storyStatuses(steps []output.StepResult) string {
+	var statuses []string
+	for _, step := range steps {
+		if len(statuses) == 0 && step.FromStatus != "" {
+			statuses = append(statuses, step.FromStatus)
+		}
+		if step.ToStatus != "" && (len(statuses) == 0 || statuses[len(statuses)-1] != step.ToStatus) {
+			statuses = append(statuses, step.ToStatus)
+		}
+	}
+	if len(statuses) < 2 {
+		return "-"
+	}
+	return strings.Join(statuses, " → ")
+}
+
+// stepStatuses describes the status transition of a step.
+func stepStatuses(step output.StepResult) string {
+	switch {
+	case step.FromStatus == "":
+		return "-"
+	case step.ToStatus == "":
+		return step.FromStatus + " (unchanged)"
+	default:
+		return step.FromStatus + " → " + step.ToStatus
+	}
+}
+
+// formatDuration rounds d for display.
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/output"
+)
+
+func TestSummarize(t *testing.T) {
+	run := Run{
+		Name:     "epic 7",
+		Stories:  []string{"7-1", "7-2", "7-3", "7-4"},
+		Started:  time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+		Duration: 95 * time.Second,
+		Results: []output.StoryResult{
+			{Key: "7-1", Success: true, Skipped: true},
+			{
+				Key:      "7-2",
+				Success:  true,
+				Duration: 30 * time.Second,
+				Steps: []output.StepResult{
+					{Name: "code-review", Success: true, FromStatus: "review", ToStatus: "done", Usage: output.Usage{CostUSD: 0.5}},
+					{Name: "git-commit", Success: true, FromStatus: "done", ToStatus: "done", Usage: output.Usage{CostUSD: 0.25}},
+				},
+			},
+			{
+				Key:      "7-3",
+				FailedAt: "dev-story",
+				Steps: []output.StepResult{
+					{Name: "dev-story", ExitCode: 124, Reason: "timed out after 30s", FromStatus: "ready-for-dev",
+						Attempts: []output.Attempt{{ExitCode: 1}, {ExitCode: 124}}},
+				},
+			},
+		},
+	}
+
+	s := summarize(run)
+
+	assert.Equal(t, "bmad-automate epic 7", s.Title)
+	assert.Equal(t, "2026-03-04 05:06:07", s.Started)
+	assert.Equal(t, "1m35s", s.Duration)
+	assert.Equal(t, "$0.75", s.Cost)
+	assert.Equal(t, "1 completed, 1 failed, 1 skipped, 1 not started", s.Counts)
+
+	require.Len(t, s.Stories, 4)
+	assert.Equal(t, "skipped (already done)", s.Stories[0].Outcome)
+	assert.Equal(t, "completed", s.Stories[1].Outcome)
+	assert.Equal(t, "review → done", s.Stories[1].Statuses)
+	assert.Equal(t, "$0.75", s.Stories[1].Cost)
+	assert.Equal(t, "failed at dev-story", s.Stories[2].Outcome)
+	assert.Equal(t, "7-4", s.Stories[3].Key)
+	assert.Equal(t, "not started", s.Stories[3].Outcome)
+
+	failed := s.Stories[2].Steps[0]
+	assert.Equal(t, "failed with exit code 124: timed out after 30s", failed.Result)
+	assert.Equal(t, "ready-for-dev (unchanged)", failed.Statuses)
+	assert.Equal(t, 2, failed.Attempts)
+}
+
+func TestStoryOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		result output.StoryResult
+		want   string
+	}{
+		{"completed", output.StoryResult{Success: true}, "completed"},
+		{"skipped", output.StoryResult{Success: true, Skipped: true}, "skipped (already done)"},
+		{"failed", output.StoryResult{FailedAt: "status"}, "failed at status"},
+		{"interrupted at step", output.StoryResult{Interrupted: true, FailedAt: "dev-story"}, "interrupted at dev-story"},
+		{"interrupted between steps", output.StoryResult{Interrupted: true}, "interrupted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, storyOutcome(tt.result))
+		})
+	}
+}
+
+func TestStepStatuses(t *testing.T) {
+	tests := []struct {
+		name string
+		step output.StepResult
+		want string
+	}{
+		{"transition", output.StepResult{FromStatus: "backlog", ToStatus: "ready-for-dev"}, "backlog → ready-for-dev"},
+		{"not updated", output.StepResult{FromStatus: "review"}, "review (unchanged)"},
+		{"unknown", output.StepResult{}, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, stepStatuses(tt.step))
+		})
+	}
+}
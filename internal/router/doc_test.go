@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"bmad-automate/internal/config"
 	"bmad-automate/internal/router"
 	"bmad-automate/internal/status"
 )
@@ -72,3 +73,27 @@ func Example_getLifecycle() {
 	// First step workflow: code-review
 	// Done story complete: true
 }
+
+// This example demonstrates building a Router from a custom lifecycle that
+// adds a qa status and a security-review workflow after code review.
+func Example_fromConfig() {
+	lc := config.LifecycleConfig{
+		Statuses: []string{"ready-for-dev", "review", "qa", "done"},
+		Terminal: []string{"done"},
+		Transitions: map[string][]config.TransitionConfig{
+			"ready-for-dev": {{Workflow: "dev-story", Next: "review"}},
+			"review":        {{Workflow: "code-review", Next: "qa"}},
+			"qa":            {{Workflow: "security-review", Next: "done"}},
+		},
+	}
+
+	r := router.FromConfig(lc)
+
+	steps, _ := r.GetLifecycle("review")
+	for _, step := range steps {
+		fmt.Printf("%s -> %s\n", step.Workflow, step.NextStatus)
+	}
+	// Output:
+	// code-review -> qa
+	// security-review -> done
+}
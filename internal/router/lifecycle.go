@@ -1,6 +1,7 @@
 package router
 
 import (
+	"bmad-automate/internal/config"
 	"bmad-automate/internal/status"
 )
 
@@ -17,10 +18,38 @@ type LifecycleStep struct {
 	// NextStatus is the status to set after this step completes successfully.
 	// The final step typically sets status to "done".
 	NextStatus status.Status
+
+	// Fix is the workflow that addresses the findings of a review step. When
+	// set, the step is a review loop: Workflow and Fix run in turn until
+	// Workflow reports no findings or has run [LifecycleStep.Iterations]
+	// times. Empty for ordinary steps.
+	Fix string
+
+	// MaxIterations limits how often Workflow runs in a review loop. Zero
+	// uses [config.DefaultMaxIterations].
+	MaxIterations int
+
+	// RequireTasks is true if every task of the story must be done before
+	// the step sets NextStatus.
+	RequireTasks bool
+}
+
+// Iterations returns how often the step's workflow runs at most: once for
+// ordinary steps, and MaxIterations or [config.DefaultMaxIterations] for
+// review loops.
+func (s LifecycleStep) Iterations() int {
+	switch {
+	case s.Fix == "":
+		return 1
+	case s.MaxIterations > 0:
+		return s.MaxIterations
+	default:
+		return config.DefaultMaxIterations
+	}
 }
 
 // GetLifecycle returns the complete sequence of lifecycle steps from the given
-// status through to "done".
+// status through to "done" using the default lifecycle.
 //
 // This is the multi-step router used by the lifecycle executor to run a story
 // through its full lifecycle. Unlike [GetWorkflow] which returns a single workflow,
@@ -35,30 +64,8 @@ type LifecycleStep struct {
 // Returns [ErrStoryComplete] for done stories (caller should skip, not fail).
 // Returns [ErrUnknownStatus] for unrecognized status values (likely YAML typo).
 //
-// See [status.Status] for valid status values.
+// See [status.Status] for valid status values and [Router.GetLifecycle] for
+// custom lifecycles.
 func GetLifecycle(s status.Status) ([]LifecycleStep, error) {
-	switch s {
-	case status.StatusBacklog:
-		return []LifecycleStep{
-			{Workflow: "create-story", NextStatus: status.StatusReadyForDev},
-			{Workflow: "dev-story", NextStatus: status.StatusReview},
-			{Workflow: "code-review", NextStatus: status.StatusDone},
-			{Workflow: "git-commit", NextStatus: status.StatusDone},
-		}, nil
-	case status.StatusReadyForDev, status.StatusInProgress:
-		return []LifecycleStep{
-			{Workflow: "dev-story", NextStatus: status.StatusReview},
-			{Workflow: "code-review", NextStatus: status.StatusDone},
-			{Workflow: "git-commit", NextStatus: status.StatusDone},
-		}, nil
-	case status.StatusReview:
-		return []LifecycleStep{
-			{Workflow: "code-review", NextStatus: status.StatusDone},
-			{Workflow: "git-commit", NextStatus: status.StatusDone},
-		}, nil
-	case status.StatusDone:
-		return nil, ErrStoryComplete
-	default:
-		return nil, ErrUnknownStatus
-	}
+	return defaultRouter.GetLifecycle(s)
 }
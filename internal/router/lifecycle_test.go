@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"bmad-automate/internal/config"
 	"bmad-automate/internal/status"
 )
 
@@ -111,3 +112,38 @@ func TestGetLifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestRouter_ReviewLoop(t *testing.T) {
+	lc := qaLifecycle()
+	lc.Transitions["review"] = []config.TransitionConfig{{Workflow: "code-review", Next: "qa", Fix: "dev-story", MaxIterations: 4}}
+	r := FromConfig(lc)
+
+	steps, err := r.GetLifecycle("review")
+	if err != nil {
+		t.Fatalf("GetLifecycle(review) err = %v, want nil", err)
+	}
+	want := LifecycleStep{Workflow: "code-review", NextStatus: "qa", Fix: "dev-story", MaxIterations: 4}
+	if steps[0] != want {
+		t.Errorf("GetLifecycle(review)[0] = %+v, want %+v", steps[0], want)
+	}
+}
+
+func TestLifecycleStep_Iterations(t *testing.T) {
+	tests := []struct {
+		name string
+		step LifecycleStep
+		want int
+	}{
+		{"ordinary step", LifecycleStep{Workflow: "code-review"}, 1},
+		{"review loop", LifecycleStep{Workflow: "code-review", Fix: "dev-story", MaxIterations: 5}, 5},
+		{"review loop default", LifecycleStep{Workflow: "code-review", Fix: "dev-story"}, config.DefaultMaxIterations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.Iterations(); got != tt.want {
+				t.Errorf("Iterations() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
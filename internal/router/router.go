@@ -4,25 +4,32 @@
 // and provides lifecycle step sequences for multi-step execution. It serves as
 // the central decision point for determining which workflow to run for a given story.
 //
+// The mapping is a state machine defined by the lifecycle section of the
+// configuration (see [config.LifecycleConfig]). The package-level functions use
+// the default BMAD lifecycle; use [FromConfig] to route with a custom one.
+//
 // Key functions:
 //   - [GetWorkflow] returns the single workflow for a status (used by run command)
 //   - [GetLifecycle] returns the full step sequence to completion (used by lifecycle executor)
 //
 // Key types:
+//   - [Router] routes statuses through a configured state machine
 //   - [LifecycleStep] represents a single step in a lifecycle sequence
 package router
 
 import (
 	"errors"
+	"fmt"
 
+	"bmad-automate/internal/config"
 	"bmad-automate/internal/status"
 )
 
 // Sentinel errors for workflow routing.
 var (
-	// ErrStoryComplete is a sentinel error indicating the story has status "done"
-	// and no workflow is needed. Callers should skip the story rather than treat
-	// this as a failure condition.
+	// ErrStoryComplete is a sentinel error indicating the story has a terminal
+	// status such as "done" and no workflow is needed. Callers should skip the
+	// story rather than treat this as a failure condition.
 	ErrStoryComplete = errors.New("story is complete, no workflow needed")
 
 	// ErrUnknownStatus is a sentinel error indicating the status value is not
@@ -31,7 +38,190 @@ var (
 	ErrUnknownStatus = errors.New("unknown status value")
 )
 
-// GetWorkflow returns the single workflow name for the given story status.
+// Router maps story statuses to workflows using a lifecycle state machine.
+//
+// Each non-terminal status has an ordered list of [LifecycleStep] transitions.
+// A Router is immutable after construction and safe for concurrent use.
+//
+// Use [New], [FromConfig] or [Default] to create an instance.
+type Router struct {
+	statuses    []status.Status
+	terminal    map[status.Status]bool
+	transitions map[status.Status][]LifecycleStep
+}
+
+// New creates a [Router] from a list of statuses, the terminal statuses, and
+// the transitions run from each non-terminal status.
+//
+// New does not validate the state machine; see [config.Config.Validate].
+func New(statuses, terminal []status.Status, transitions map[status.Status][]LifecycleStep) *Router {
+	r := &Router{
+		statuses:    append([]status.Status(nil), statuses...),
+		terminal:    make(map[status.Status]bool, len(terminal)),
+		transitions: make(map[status.Status][]LifecycleStep, len(transitions)),
+	}
+	for _, s := range terminal {
+		r.terminal[s] = true
+	}
+	for s, steps := range transitions {
+		r.transitions[s] = append([]LifecycleStep(nil), steps...)
+	}
+	return r
+}
+
+// FromConfig creates a [Router] from the lifecycle section of the configuration.
+//
+// The configuration should already have passed [config.Config.Validate], which
+// [config.Loader] does on load.
+func FromConfig(lc config.LifecycleConfig) *Router {
+	statuses := make([]status.Status, 0, len(lc.Statuses))
+	for _, s := range lc.Statuses {
+		statuses = append(statuses, status.Status(s))
+	}
+
+	terminal := make([]status.Status, 0, len(lc.Terminal))
+	for _, s := range lc.Terminal {
+		terminal = append(terminal, status.Status(s))
+	}
+
+	transitions := make(map[status.Status][]LifecycleStep, len(lc.Transitions))
+	for from, steps := range lc.Transitions {
+		for _, step := range steps {
+			transitions[status.Status(from)] = append(transitions[status.Status(from)], LifecycleStep{
+				Workflow:      step.Workflow,
+				NextStatus:    status.Status(step.Next),
+				Fix:           step.Fix,
+				MaxIterations: step.MaxIterations,
+				RequireTasks:  step.RequireTasks,
+			})
+		}
+	}
+
+	return New(statuses, terminal, transitions)
+}
+
+// defaultRouter routes using the built-in BMAD lifecycle.
+var defaultRouter = FromConfig(config.DefaultConfig().Lifecycle)
+
+// Default returns the [Router] for the built-in BMAD lifecycle:
+// backlog -> ready-for-dev -> review -> done, with in-progress treated like
+// ready-for-dev.
+func Default() *Router {
+	return defaultRouter
+}
+
+// Statuses returns all valid statuses in configuration order.
+func (r *Router) Statuses() []status.Status {
+	return append([]status.Status(nil), r.statuses...)
+}
+
+// IsValid reports whether s is one of the router's statuses.
+func (r *Router) IsValid(s status.Status) bool {
+	for _, known := range r.statuses {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether s is a terminal status, such as "done".
+func (r *Router) IsTerminal(s status.Status) bool {
+	return r.terminal[s]
+}
+
+// TerminalStatuses returns the terminal statuses in configuration order.
+func (r *Router) TerminalStatuses() []status.Status {
+	var terminal []status.Status
+	for _, s := range r.statuses {
+		if r.terminal[s] {
+			terminal = append(terminal, s)
+		}
+	}
+	return terminal
+}
+
+// NextStatuses returns the statuses that the transitions from s set, in
+// order and without duplicates. Terminal and unknown statuses have none.
+func (r *Router) NextStatuses(s status.Status) []status.Status {
+	var next []status.Status
+	seen := make(map[status.Status]bool)
+	for _, step := range r.transitions[s] {
+		if !seen[step.NextStatus] {
+			seen[step.NextStatus] = true
+			next = append(next, step.NextStatus)
+		}
+	}
+	return next
+}
+
+// CanTransition reports whether a story may move from one status to another
+// under the lifecycle: to a status set by one of the transitions from its
+// current status, or to the status it already has.
+func (r *Router) CanTransition(from, to status.Status) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range r.NextStatuses(from) {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// GetWorkflow returns the first workflow to run for a story in status s.
+//
+// Returns [ErrStoryComplete] for terminal statuses (caller should skip, not fail).
+// Returns [ErrUnknownStatus] for statuses without transitions (likely YAML typo).
+func (r *Router) GetWorkflow(s status.Status) (string, error) {
+	if r.terminal[s] {
+		return "", ErrStoryComplete
+	}
+	steps, ok := r.transitions[s]
+	if !ok || len(steps) == 0 {
+		return "", ErrUnknownStatus
+	}
+	return steps[0].Workflow, nil
+}
+
+// GetLifecycle returns every step needed to move a story from status s to a
+// terminal status.
+//
+// The transitions of s run first, then the transitions of the status the last
+// one moves to, and so on until a terminal status is reached.
+//
+// Returns [ErrStoryComplete] for terminal statuses (caller should skip, not fail).
+// Returns [ErrUnknownStatus] for statuses without transitions (likely YAML typo).
+// Returns an error if the transitions loop without reaching a terminal status.
+func (r *Router) GetLifecycle(s status.Status) ([]LifecycleStep, error) {
+	if r.terminal[s] {
+		return nil, ErrStoryComplete
+	}
+	if len(r.transitions[s]) == 0 {
+		return nil, ErrUnknownStatus
+	}
+
+	var steps []LifecycleStep
+	seen := make(map[status.Status]bool)
+	for current := s; !r.terminal[current]; {
+		if seen[current] {
+			return nil, fmt.Errorf("lifecycle from %q never reaches a terminal status", s)
+		}
+		seen[current] = true
+
+		next := r.transitions[current]
+		if len(next) == 0 {
+			return nil, fmt.Errorf("status %q reached from %q: %w", current, s, ErrUnknownStatus)
+		}
+		steps = append(steps, next...)
+		current = next[len(next)-1].NextStatus
+	}
+	return steps, nil
+}
+
+// GetWorkflow returns the single workflow name for the given story status
+// using the default lifecycle.
 //
 // This is the single-step router used by commands that execute one workflow at a time.
 // The mapping is:
@@ -43,18 +233,8 @@ var (
 // Returns [ErrStoryComplete] for done stories (caller should skip, not fail).
 // Returns [ErrUnknownStatus] for unrecognized status values (likely YAML typo).
 //
-// See [status.Status] for valid status values.
+// See [status.Status] for valid status values and [Router.GetWorkflow] for
+// custom lifecycles.
 func GetWorkflow(s status.Status) (string, error) {
-	switch s {
-	case status.StatusBacklog:
-		return "create-story", nil
-	case status.StatusReadyForDev, status.StatusInProgress:
-		return "dev-story", nil
-	case status.StatusReview:
-		return "code-review", nil
-	case status.StatusDone:
-		return "", ErrStoryComplete
-	default:
-		return "", ErrUnknownStatus
-	}
+	return defaultRouter.GetWorkflow(s)
 }
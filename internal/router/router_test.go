@@ -2,8 +2,10 @@ package router
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
+	"bmad-automate/internal/config"
 	"bmad-automate/internal/status"
 )
 
@@ -99,3 +101,127 @@ func TestSentinelErrors(t *testing.T) {
 		}
 	})
 }
+
+// qaLifecycle returns a lifecycle with a custom qa status and security-review workflow.
+func qaLifecycle() config.LifecycleConfig {
+	return config.LifecycleConfig{
+		Statuses: []string{"backlog", "ready-for-dev", "review", "qa", "done"},
+		Terminal: []string{"done"},
+		Transitions: map[string][]config.TransitionConfig{
+			"backlog":       {{Workflow: "create-story", Next: "ready-for-dev"}},
+			"ready-for-dev": {{Workflow: "dev-story", Next: "review"}},
+			"review":        {{Workflow: "code-review", Next: "qa", RequireTasks: true}},
+			"qa": {
+				{Workflow: "security-review", Next: "done"},
+				{Workflow: "git-commit", Next: "done"},
+			},
+		},
+	}
+}
+
+func TestRouter_CustomLifecycle(t *testing.T) {
+	r := FromConfig(qaLifecycle())
+
+	steps, err := r.GetLifecycle("review")
+	if err != nil {
+		t.Fatalf("GetLifecycle(review) err = %v, want nil", err)
+	}
+	want := []LifecycleStep{
+		{Workflow: "code-review", NextStatus: "qa", RequireTasks: true},
+		{Workflow: "security-review", NextStatus: status.StatusDone},
+		{Workflow: "git-commit", NextStatus: status.StatusDone},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("GetLifecycle(review) = %v, want %v", steps, want)
+	}
+
+	workflow, err := r.GetWorkflow("qa")
+	if err != nil || workflow != "security-review" {
+		t.Errorf("GetWorkflow(qa) = %q, %v, want security-review, nil", workflow, err)
+	}
+
+	if _, err := r.GetLifecycle(status.StatusInProgress); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("GetLifecycle(in-progress) err = %v, want ErrUnknownStatus", err)
+	}
+	if _, err := r.GetWorkflow(status.StatusDone); !errors.Is(err, ErrStoryComplete) {
+		t.Errorf("GetWorkflow(done) err = %v, want ErrStoryComplete", err)
+	}
+}
+
+func TestRouter_StatusQueries(t *testing.T) {
+	r := FromConfig(qaLifecycle())
+
+	wantStatuses := []status.Status{"backlog", "ready-for-dev", "review", "qa", "done"}
+	if got := r.Statuses(); !reflect.DeepEqual(got, wantStatuses) {
+		t.Errorf("Statuses() = %v, want %v", got, wantStatuses)
+	}
+	if !r.IsValid("qa") {
+		t.Error("IsValid(qa) = false, want true")
+	}
+	if r.IsValid(status.StatusInProgress) {
+		t.Error("IsValid(in-progress) = true, want false")
+	}
+	if !r.IsTerminal(status.StatusDone) || r.IsTerminal("qa") {
+		t.Error("IsTerminal should only be true for done")
+	}
+	if got, want := r.TerminalStatuses(), []status.Status{status.StatusDone}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TerminalStatuses() = %v, want %v", got, want)
+	}
+}
+
+func TestRouter_Transitions(t *testing.T) {
+	r := FromConfig(qaLifecycle())
+
+	if got, want := r.NextStatuses("review"), []status.Status{"qa"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NextStatuses(review) = %v, want %v", got, want)
+	}
+	if got, want := r.NextStatuses("qa"), []status.Status{status.StatusDone}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NextStatuses(qa) = %v, want %v (without duplicates)", got, want)
+	}
+	if got := r.NextStatuses(status.StatusDone); got != nil {
+		t.Errorf("NextStatuses(done) = %v, want nil", got)
+	}
+
+	tests := []struct {
+		from, to status.Status
+		want     bool
+	}{
+		{"backlog", "ready-for-dev", true},
+		{"review", "qa", true},
+		{"qa", "done", true},
+		{"review", "review", true},
+		{"backlog", "done", false},
+		{"review", "ready-for-dev", false},
+		{"done", "review", false},
+	}
+	for _, tt := range tests {
+		if got := r.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRouter_Cycle(t *testing.T) {
+	r := New(
+		[]status.Status{"a", "b", "done"},
+		[]status.Status{"done"},
+		map[status.Status][]LifecycleStep{
+			"a": {{Workflow: "w1", NextStatus: "b"}},
+			"b": {{Workflow: "w2", NextStatus: "a"}},
+		},
+	)
+
+	if _, err := r.GetLifecycle("a"); err == nil {
+		t.Error("GetLifecycle(a) err = nil, want cycle error")
+	}
+}
+
+func TestDefault_MatchesPackageFunctions(t *testing.T) {
+	for _, s := range []status.Status{status.StatusBacklog, status.StatusReadyForDev, status.StatusInProgress, status.StatusReview} {
+		got, err := Default().GetLifecycle(s)
+		want, wantErr := GetLifecycle(s)
+		if err != nil || wantErr != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Default().GetLifecycle(%q) = %v, %v, want %v, %v", s, got, err, want, wantErr)
+		}
+	}
+}
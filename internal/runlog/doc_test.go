@@ -0,0 +1,48 @@
+package runlog_test
+
+import (
+	"fmt"
+	"os"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
+)
+
+// This example records one attempt of a workflow step and reads it back.
+func Example_step() {
+	tmpDir, err := os.MkdirTemp("", "runlog-example")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(tmpDir)
+
+	log := runlog.New(tmpDir, "20260304-050607", "5f2b9c1a7e3d")
+
+	// The workflow runner starts a step, records each event, and ends it
+	step, err := log.StartStep("7-1-define-schema", "dev-story", 1, "Work on story: 7-1-define-schema")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	event, _ := claude.ParseSingle(`{"type":"assistant","message":{"content":[{"type":"text","text":"Done"}]}}`)
+	step.Event(event)
+	if err := step.End(0, output.Usage{Turns: 1}, "", ""); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	records, err := runlog.ReadFile(log.StepPath("7-1-define-schema", "dev-story"))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, rec := range records {
+		fmt.Println(rec.Kind)
+	}
+	// Output:
+	// start
+	// event
+	// end
+}
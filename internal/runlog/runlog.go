@@ -0,0 +1,340 @@
+// Package runlog records every Claude session of a run to disk.
+//
+// Each run gets its own directory, named by a run ID. Every workflow step of a
+// story is written to <dir>/<run-id>/<story>/<step>.jsonl as one JSON [Record]
+// per line: a start record with the prompt and the configuration hash, one
+// record per Claude event holding the event exactly as Claude emitted it, and an
+// end record with the exit code, duration, and usage. Retries of a step append
+// further start, event, and end records to the same file.
+//
+// The logs are an audit trail of what Claude did, and can be read back with
+// [ReadFile] to inspect a failed step without running it again. They hold
+// prompts and tool output, so a .gitignore in the base directory keeps them out
+// of commits.
+//
+// Key types:
+//   - [Log] creates the files of a single run
+//   - [Step] writes the records of one workflow step
+//   - [Record] is a single line of a step log
+//   - [Attempt] is an attempt of a step found in a run directory by [Attempts]
+package runlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/git"
+	"bmad-automate/internal/output"
+)
+
+// Record kinds written to a step log.
+const (
+	// KindStart opens an attempt of a step and carries its metadata.
+	KindStart = "start"
+
+	// KindEvent holds a single Claude event.
+	KindEvent = "event"
+
+	// KindEnd closes an attempt and carries its outcome.
+	KindEnd = "end"
+)
+
+// Record is a single line of a step log.
+//
+// Which fields are set depends on Kind; see [KindStart], [KindEvent] and [KindEnd].
+type Record struct {
+	// Time is when the record was written.
+	Time time.Time `json:"time"`
+
+	// Kind is the record type.
+	Kind string `json:"kind"`
+
+	// RunID, Story, Step, Attempt, Prompt, ConfigHash and SessionID describe
+	// the attempt. Set on start records; SessionID is set on end records.
+	RunID      string `json:"run_id,omitempty"`
+	Story      string `json:"story,omitempty"`
+	Step       string `json:"step,omitempty"`
+	Attempt    int    `json:"attempt,omitempty"`
+	Prompt     string `json:"prompt,omitempty"`
+	ConfigHash string `json:"config_hash,omitempty"`
+	SessionID  string `json:"session_id,omitempty"`
+
+	// Event is the Claude event as emitted by Claude. Set on event records.
+	Event json.RawMessage `json:"event,omitempty"`
+
+	// ExitCode, DurationMS, Usage and Reason describe the outcome of the
+	// attempt. Set on end records.
+	ExitCode   *int          `json:"exit_code,omitempty"`
+	DurationMS int64         `json:"duration_ms,omitempty"`
+	Usage      *output.Usage `json:"usage,omitempty"`
+	Reason     string        `json:"reason,omitempty"`
+}
+
+// NewRunID returns a run ID for a run started at t.
+//
+// Run IDs sort in the order the runs were started.
+func NewRunID(t time.Time) string {
+	return t.Format("20060102-150405")
+}
+
+// Log writes the step logs of a single run.
+//
+// A Log is safe for concurrent use, so the runners of stories that execute in
+// parallel can share it. All methods treat a nil Log as disabled.
+//
+// Use [New] to create an instance.
+type Log struct {
+	baseDir    string
+	dir        string
+	runID      string
+	configHash string
+}
+
+// New creates a [Log] that writes to baseDir/runID.
+//
+// The configHash, typically from Config.Hash in the config package, is recorded
+// with every step. Directories are created when the first step starts, along
+// with a .gitignore in baseDir that ignores every file, unless baseDir already
+// has one.
+func New(baseDir, runID, configHash string) *Log {
+	return &Log{
+		baseDir:    baseDir,
+		dir:        filepath.Join(baseDir, runID),
+		runID:      runID,
+		configHash: configHash,
+	}
+}
+
+// Dir returns the directory of the run.
+func (l *Log) Dir() string {
+	if l == nil {
+		return ""
+	}
+	return l.dir
+}
+
+// RunID returns the ID of the run.
+func (l *Log) RunID() string {
+	if l == nil {
+		return ""
+	}
+	return l.runID
+}
+
+// StepPath returns the path of the log for a story's workflow step.
+func (l *Log) StepPath(storyKey, workflow string) string {
+	if l == nil {
+		return ""
+	}
+	return filepath.Join(l.dir, storyKey, workflow+".jsonl")
+}
+
+// StartStep opens the log of a story's workflow step and writes the start record
+// of an attempt. Call [Step.End] when the attempt is over.
+//
+// A nil Log returns a nil [Step], whose methods do nothing.
+func (l *Log) StartStep(storyKey, workflow string, attempt int, prompt string) (*Step, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	path := l.StepPath(storyKey, workflow)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create run log directory: %w", err)
+	}
+	if err := git.IgnoreAll(l.baseDir); err != nil {
+		return nil, fmt.Errorf("failed to create run log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open run log: %w", err)
+	}
+
+	s := &Step{f: f, enc: json.NewEncoder(f), start: time.Now()}
+	s.write(Record{
+		Kind:       KindStart,
+		RunID:      l.runID,
+		Story:      storyKey,
+		Step:       workflow,
+		Attempt:    attempt,
+		Prompt:     prompt,
+		ConfigHash: l.configHash,
+	})
+	if s.err != nil {
+		_ = f.Close() //nolint:errcheck // The write error is more useful
+		return nil, fmt.Errorf("failed to write run log: %w", s.err)
+	}
+	return s, nil
+}
+
+// Step writes the records of one attempt of a workflow step.
+//
+// All methods treat a nil Step as disabled. Write errors are kept and returned
+// by [Step.End], so a failing disk does not interrupt the workflow.
+type Step struct {
+	mu    sync.Mutex
+	f     *os.File
+	enc   *json.Encoder
+	start time.Time
+	err   error
+}
+
+// Event records a Claude event.
+//
+// The event's original JSON is recorded when available; events that were not
+// parsed from Claude's output are recorded from their [claude.StreamEvent].
+func (s *Step) Event(event claude.Event) {
+	if s == nil {
+		return
+	}
+	data := event.JSON
+	if len(data) == 0 {
+		raw := event.Raw
+		if raw == nil {
+			raw = &claude.StreamEvent{Type: string(event.Type), Subtype: event.Subtype, SessionID: event.SessionID}
+		}
+		var err error
+		if data, err = json.Marshal(raw); err != nil {
+			s.mu.Lock()
+			s.err = err
+			s.mu.Unlock()
+			return
+		}
+	}
+	s.write(Record{Kind: KindEvent, Event: data})
+}
+
+// End writes the end record of the attempt and closes the log.
+//
+// It returns the first error that occurred while writing the attempt.
+func (s *Step) End(exitCode int, usage output.Usage, sessionID, reason string) error {
+	if s == nil {
+		return nil
+	}
+	s.write(Record{
+		Kind:       KindEnd,
+		SessionID:  sessionID,
+		ExitCode:   &exitCode,
+		DurationMS: time.Since(s.start).Milliseconds(),
+		Usage:      &usage,
+		Reason:     reason,
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.f.Close(); err != nil && s.err == nil {
+		s.err = err
+	}
+	return s.err
+}
+
+// write encodes r as one line, stamping it with the current time.
+func (s *Step) write(r Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	r.Time = time.Now()
+	s.err = s.enc.Encode(r)
+}
+
+// Read decodes the records of a step log.
+func Read(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return records, fmt.Errorf("invalid run log record: %w", err)
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// ReadFile decodes the records of the step log at path.
+func ReadFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Attempt is one attempt of a step found in a run directory: a start record
+// and the records that follow it in the step log, up to the next start record.
+type Attempt struct {
+	// Story is the story key, taken from the log's directory name.
+	Story string
+
+	// Step is the workflow name, taken from the log's file name.
+	Step string
+
+	// Path is the path of the log file.
+	Path string
+
+	// Started is the time of the attempt's first record.
+	Started time.Time
+
+	// Records are the records of the attempt, starting with its start record.
+	Records []Record
+}
+
+// Attempts returns the attempts recorded in the step logs in the run
+// directory runDir, in the order in which they started. Attempts of different
+// steps interleave as they did in the run, e.g. a code review, the fix it
+// asked for and the review that followed.
+func Attempts(runDir string) ([]Attempt, error) {
+	if _, err := os.Stat(runDir); err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(runDir, "*", "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts []Attempt
+	for _, path := range paths {
+		records, err := ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for i, rec := range records {
+			if i == 0 || rec.Kind == KindStart {
+				attempts = append(attempts, Attempt{
+					Story:   filepath.Base(filepath.Dir(path)),
+					Step:    strings.TrimSuffix(filepath.Base(path), ".jsonl"),
+					Path:    path,
+					Started: rec.Time,
+				})
+			}
+			last := &attempts[len(attempts)-1]
+			last.Records = append(last.Records, rec)
+		}
+	}
+
+	// Attempts of a file are in order already; the stable sort keeps them so.
+	sort.SliceStable(attempts, func(i, j int) bool {
+		if !attempts[i].Started.Equal(attempts[j].Started) {
+			return attempts[i].Started.Before(attempts[j].Started)
+		}
+		return attempts[i].Path < attempts[j].Path
+	})
+	return attempts, nil
+}
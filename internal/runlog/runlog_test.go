@@ -0,0 +1,183 @@
+package runlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/output"
+)
+
+func TestNewRunID(t *testing.T) {
+	id := NewRunID(time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC))
+
+	assert.Equal(t, "20260304-050607", id)
+}
+
+func TestLog_Step(t *testing.T) {
+	dir := t.TempDir()
+	log := New(dir, "run-1", "abc123")
+
+	step, err := log.StartStep("7-1-schema", "dev-story", 1, "Work on story: 7-1-schema")
+	require.NoError(t, err)
+
+	parsed, err := claude.ParseSingle(`{"type":"system","subtype":"init","session_id":"s-1","model":"claude-sonnet"}`)
+	require.NoError(t, err)
+	step.Event(parsed)
+	step.Event(claude.Event{Type: claude.EventTypeResult, SessionComplete: true})
+	usage := output.Usage{CostUSD: 0.5, Turns: 3}
+	require.NoError(t, step.End(1, usage, "s-1", ""))
+
+	path := filepath.Join(dir, "run-1", "7-1-schema", "dev-story.jsonl")
+	assert.Equal(t, path, log.StepPath("7-1-schema", "dev-story"))
+
+	records, err := ReadFile(path)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	start := records[0]
+	assert.Equal(t, KindStart, start.Kind)
+	assert.Equal(t, "run-1", start.RunID)
+	assert.Equal(t, "7-1-schema", start.Story)
+	assert.Equal(t, "dev-story", start.Step)
+	assert.Equal(t, 1, start.Attempt)
+	assert.Equal(t, "Work on story: 7-1-schema", start.Prompt)
+	assert.Equal(t, "abc123", start.ConfigHash)
+	assert.False(t, start.Time.IsZero())
+
+	assert.Equal(t, KindEvent, records[1].Kind)
+	assert.JSONEq(t, `{"type":"system","subtype":"init","session_id":"s-1","model":"claude-sonnet"}`, string(records[1].Event),
+		"parsed events should be recorded as emitted")
+	assert.JSONEq(t, `{"type":"result"}`, string(records[2].Event))
+
+	end := records[3]
+	assert.Equal(t, KindEnd, end.Kind)
+	require.NotNil(t, end.ExitCode)
+	assert.Equal(t, 1, *end.ExitCode)
+	assert.Equal(t, "s-1", end.SessionID)
+	assert.Equal(t, &usage, end.Usage)
+}
+
+func TestLog_StepAppendsAttempts(t *testing.T) {
+	dir := t.TempDir()
+	log := New(dir, "run-1", "")
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		step, err := log.StartStep("story", "code-review", attempt, "review")
+		require.NoError(t, err)
+		require.NoError(t, step.End(0, output.Usage{}, "", ""))
+	}
+
+	records, err := ReadFile(log.StepPath("story", "code-review"))
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, 1, records[0].Attempt)
+	assert.Equal(t, 2, records[2].Attempt)
+}
+
+func TestLog_StartStepIgnoresLogs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "runs")
+	_, err := New(dir, "run-1", "").StartStep("story", "dev-story", 1, "prompt")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*\n", string(data))
+
+	// An existing .gitignore is left alone.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.jsonl\n"), 0644))
+	_, err = New(dir, "run-2", "").StartStep("story", "dev-story", 1, "prompt")
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.jsonl\n", string(data))
+}
+
+func TestLog_Nil(t *testing.T) {
+	var log *Log
+
+	step, err := log.StartStep("story", "dev-story", 1, "prompt")
+
+	require.NoError(t, err)
+	assert.Nil(t, step)
+	step.Event(claude.Event{Type: claude.EventTypeAssistant})
+	assert.NoError(t, step.End(0, output.Usage{}, "", ""))
+	assert.Empty(t, log.Dir())
+}
+
+func TestLog_StartStepError(t *testing.T) {
+	dir := t.TempDir()
+	// A file where the run directory should be
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "run-1"), nil, 0644))
+	log := New(dir, "run-1", "")
+
+	step, err := log.StartStep("story", "dev-story", 1, "prompt")
+
+	assert.Error(t, err)
+	assert.Nil(t, step)
+}
+
+func TestAttempts(t *testing.T) {
+	dir := t.TempDir()
+	log := New(dir, "run-1", "")
+	for _, s := range []struct {
+		story, step string
+		attempt     int
+	}{
+		{"7-2-api", "create-story", 1},
+		{"7-1-schema", "code-review", 1},
+		{"7-1-schema", "dev-story", 1},
+		{"7-1-schema", "code-review", 2},
+	} {
+		step, err := log.StartStep(s.story, s.step, s.attempt, "prompt")
+		require.NoError(t, err)
+		step.Event(claude.Event{Raw: &claude.StreamEvent{Type: "assistant"}})
+		require.NoError(t, step.End(0, output.Usage{}, "", ""))
+		time.Sleep(time.Millisecond)
+	}
+
+	attempts, err := Attempts(log.Dir())
+	require.NoError(t, err)
+
+	var names []string
+	for _, a := range attempts {
+		names = append(names, fmt.Sprintf("%s/%s#%d", a.Story, a.Step, a.Records[0].Attempt))
+		assert.Equal(t, log.StepPath(a.Story, a.Step), a.Path)
+		assert.Equal(t, a.Records[0].Time, a.Started)
+		require.Len(t, a.Records, 3, "an attempt should hold its start, event and end records")
+		assert.Equal(t, KindStart, a.Records[0].Kind)
+		assert.Equal(t, KindEnd, a.Records[2].Kind)
+	}
+	assert.Equal(t, []string{
+		"7-2-api/create-story#1",
+		"7-1-schema/code-review#1",
+		"7-1-schema/dev-story#1",
+		"7-1-schema/code-review#2",
+	}, names, "attempts should be in the order they started across files")
+}
+
+func TestAttempts_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := Attempts(filepath.Join(t.TempDir(), "missing"))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		runDir := t.TempDir()
+		path := filepath.Join(runDir, "7-1-schema", "dev-story.jsonl")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("{not json\n"), 0644))
+
+		_, err := Attempts(runDir)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), path)
+	})
+}
@@ -1,17 +1,19 @@
 // Package state provides lifecycle execution state persistence for resume functionality.
 //
-// When a lifecycle execution fails (e.g., due to a Claude CLI error), the state
-// is saved to disk so that execution can be resumed from the point of failure
-// rather than starting over from the beginning. This is particularly valuable
-// for long-running story lifecycles.
+// The lifecycle executor checkpoints its position to disk before every workflow
+// step. When an execution fails or the process dies (e.g., due to a Claude CLI
+// error or a crash), execution can be resumed from the point of failure rather
+// than starting over from the beginning. This is particularly valuable for
+// long-running story lifecycles.
 //
 // Key types:
 //   - [State] represents the persisted execution state (story key, step index, etc.)
 //   - [Manager] handles state persistence operations (save, load, clear)
 //
-// The state file is stored as a hidden JSON file ([StateFileName]) in the working
-// directory. State is written atomically using a temp file and rename pattern
-// to prevent corruption on crash.
+// The state file is stored as a hidden JSON file ([StateFileName]) in the directory
+// given to [NewManager]; the CLI uses the repository's git directory, so the
+// file is never committed. State is written atomically using a temp file and
+// rename pattern to prevent corruption on crash.
 package state
 
 import (
@@ -21,7 +23,7 @@ import (
 	"path/filepath"
 )
 
-// StateFileName is the name of the state file in the working directory.
+// StateFileName is the name of the state file in the directory of a [Manager].
 // It is a hidden file (prefixed with ".") to avoid cluttering the directory.
 // The file contains JSON-encoded [State] data.
 const StateFileName = ".bmad-state.json"
@@ -33,8 +35,8 @@ var ErrNoState = errors.New("no state file exists")
 
 // State represents the persisted lifecycle execution state.
 //
-// This struct is serialized to JSON and saved to disk when a lifecycle
-// execution fails, enabling resume from the point of failure.
+// This struct is serialized to JSON and saved to disk before each lifecycle
+// step, enabling resume from the point of failure.
 type State struct {
 	// StoryKey is the identifier of the story being processed.
 	StoryKey string `json:"story_key"`
@@ -48,8 +50,14 @@ type State struct {
 	TotalSteps int `json:"total_steps"`
 
 	// StartStatus is the story's status when execution began.
-	// Stored for debugging and context when viewing saved state.
+	// The lifecycle is rebuilt from this status on resume, and it is used to
+	// detect stories whose sprint status changed since the checkpoint.
 	StartStatus string `json:"start_status"`
+
+	// Sessions maps workflow names to the Claude session ID of their latest
+	// run, so a failed step can continue its session instead of starting over.
+	// Only steps whose runner reported a session are listed.
+	Sessions map[string]string `json:"sessions,omitempty"`
 }
 
 // Manager handles state persistence operations.
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -69,7 +70,7 @@ func TestSaveWritesValidJSON(t *testing.T) {
 		t.Fatalf("file contains invalid JSON: %v", err)
 	}
 
-	if decoded != state {
+	if !reflect.DeepEqual(decoded, state) {
 		t.Errorf("saved state mismatch: got %+v, want %+v", decoded, state)
 	}
 }
@@ -95,11 +96,48 @@ func TestLoadReturnsSavedState(t *testing.T) {
 		t.Fatalf("Load failed: %v", err)
 	}
 
-	if loaded != original {
+	if !reflect.DeepEqual(loaded, original) {
 		t.Errorf("loaded state mismatch: got %+v, want %+v", loaded, original)
 	}
 }
 
+// TestSessionsRoundTrip verifies step sessions are saved and omitted when empty
+func TestSessionsRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+
+	original := State{
+		StoryKey:    "PROJ-789",
+		StepIndex:   1,
+		TotalSteps:  4,
+		StartStatus: "backlog",
+		Sessions:    map[string]string{"create-story": "s-1", "dev-story": "s-2"},
+	}
+	if err := mgr.Save(original); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, original) {
+		t.Errorf("loaded state mismatch: got %+v, want %+v", loaded, original)
+	}
+
+	data, err := json.Marshal(State{StoryKey: "PROJ-789"})
+	if err != nil {
+		t.Fatalf("failed to marshal state: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("failed to unmarshal state: %v", err)
+	}
+	if _, ok := fields["sessions"]; ok {
+		t.Errorf("sessions should be omitted when empty: %s", data)
+	}
+}
+
 // TestLoadReturnsErrNoStateWhenFileMissing verifies Load returns ErrNoState when file doesn't exist
 func TestLoadReturnsErrNoStateWhenFileMissing(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -0,0 +1,204 @@
+package status
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Key prefix and suffix of the epic and retrospective entries.
+const (
+	epicKeyPrefix       = "epic-"
+	retrospectiveSuffix = "-retrospective"
+)
+
+// EpicKey returns the key of an epic's own entry, e.g. "epic-7".
+func EpicKey(epicID string) string {
+	return epicKeyPrefix + epicID
+}
+
+// RetrospectiveKey returns the key of an epic's retrospective entry, e.g.
+// "epic-7-retrospective".
+func RetrospectiveKey(epicID string) string {
+	return epicKeyPrefix + epicID + retrospectiveSuffix
+}
+
+// keyKind is the kind of a development_status entry.
+type keyKind int
+
+const (
+	kindStory keyKind = iota
+	kindEpic
+	kindRetrospective
+)
+
+// parseKey returns the kind of a development_status key and the epic it
+// belongs to. The epic of a story is the first segment of its key when the
+// second is a story number, so "7-1-define-schema" is story 1 of epic 7;
+// other stories have no epic.
+func parseKey(key string) (kind keyKind, epicID string, storyNum int) {
+	if rest, ok := strings.CutPrefix(key, epicKeyPrefix); ok && rest != "" {
+		if id, ok := strings.CutSuffix(rest, retrospectiveSuffix); ok && id != "" {
+			return kindRetrospective, id, 0
+		}
+		return kindEpic, rest, 0
+	}
+
+	epicID, rest, ok := strings.Cut(key, "-")
+	numStr, _, _ := strings.Cut(rest, "-")
+	num, err := strconv.Atoi(numStr)
+	if !ok || epicID == "" || err != nil {
+		return kindStory, "", 0
+	}
+	return kindStory, epicID, num
+}
+
+// IsStoryKey reports whether key is a story rather than an epic or
+// retrospective entry.
+func IsStoryKey(key string) bool {
+	kind, _, _ := parseKey(key)
+	return kind == kindStory
+}
+
+// Story is a story key with its status.
+type Story struct {
+	// Key is the story key, e.g. "7-1-define-schema".
+	Key string
+
+	// Status is the story's current status.
+	Status Status
+}
+
+// Epic is an epic of the sprint with its stories.
+type Epic struct {
+	// ID is the epic identifier, e.g. "7". It is empty for the stories whose
+	// key does not follow the {epicID}-{storyNum}-{description} pattern.
+	ID string
+
+	// Status is the status of the epic's own entry, e.g. [EpicInProgress].
+	// Empty if the file has no entry for the epic.
+	Status Status
+
+	// Retrospective is the status of the epic's retrospective entry, e.g.
+	// [RetrospectiveOptional]. Empty if the file has none.
+	Retrospective Status
+
+	// Stories holds the stories of the epic, sorted by story number.
+	Stories []Story
+}
+
+// Epics groups the entries of the sprint by epic.
+//
+// Each epic holds its stories and the status of its own epic-N and
+// epic-N-retrospective entries, so an epic is listed even before any of its
+// stories exist. Epics are sorted numerically where possible, and stories by
+// story number. Stories without an epic are collected in a final epic with an
+// empty ID.
+func (s *SprintStatus) Epics() []Epic {
+	type storyWithNum struct {
+		Story
+		num int
+	}
+	byID := make(map[string]*Epic)
+	stories := make(map[string][]storyWithNum)
+	var other []Story
+
+	epic := func(id string) *Epic {
+		if byID[id] == nil {
+			byID[id] = &Epic{ID: id}
+		}
+		return byID[id]
+	}
+
+	for key, status := range s.DevelopmentStatus {
+		kind, epicID, num := parseKey(key)
+		switch {
+		case kind == kindEpic:
+			epic(epicID).Status = status
+		case kind == kindRetrospective:
+			epic(epicID).Retrospective = status
+		case epicID == "":
+			other = append(other, Story{Key: key, Status: status})
+		default:
+			epic(epicID)
+			stories[epicID] = append(stories[epicID], storyWithNum{Story{Key: key, Status: status}, num})
+		}
+	}
+
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return lessNumeric(ids[i], ids[j])
+	})
+
+	epics := make([]Epic, 0, len(ids)+1)
+	for _, id := range ids {
+		list := stories[id]
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].num != list[j].num {
+				return list[i].num < list[j].num
+			}
+			return list[i].Key < list[j].Key
+		})
+		e := *byID[id]
+		for _, story := range list {
+			e.Stories = append(e.Stories, story.Story)
+		}
+		epics = append(epics, e)
+	}
+
+	if len(other) > 0 {
+		sort.Slice(other, func(i, j int) bool {
+			return other[i].Key < other[j].Key
+		})
+		epics = append(epics, Epic{Stories: other})
+	}
+	return epics
+}
+
+// epicStatus returns the status an epic should have given its stories, and
+// whether that differs from current: [EpicDone] once every story is complete,
+// and [EpicInProgress] once a story has left the backlog. Reopening a story
+// of a done epic moves the epic back to in-progress. An epic without stories
+// keeps its status.
+func epicStatus(current Status, stories []Story, isComplete func(Status) bool) (Status, bool) {
+	if len(stories) == 0 {
+		return current, false
+	}
+
+	complete, started := true, false
+	for _, story := range stories {
+		if !isComplete(story.Status) {
+			complete = false
+		}
+		if story.Status != StatusBacklog {
+			started = true
+		}
+	}
+
+	want := current
+	switch {
+	case complete:
+		want = EpicDone
+	case started && (current == EpicBacklog || current == EpicContexted || current == EpicDone):
+		want = EpicInProgress
+	}
+	return want, want != current
+}
+
+// lessNumeric orders numbers numerically and before any other strings,
+// which are ordered lexically.
+func lessNumeric(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil && na != nb:
+		return na < nb
+	case (errA == nil) != (errB == nil):
+		return errA == nil
+	default:
+		return a < b
+	}
+}
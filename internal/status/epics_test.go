@@ -0,0 +1,132 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSprintStatus_Epics(t *testing.T) {
+	s := &SprintStatus{DevelopmentStatus: map[string]Status{
+		"10-1-late":      StatusBacklog,
+		"2-10-tenth":     StatusReview,
+		"2-2-second":     StatusDone,
+		"2-1-first":      StatusDone,
+		"auth-1-login":   StatusInProgress,
+		"cleanup":        StatusBacklog,
+		"2-notes-readme": StatusBacklog,
+	}}
+
+	want := []Epic{
+		{ID: "2", Stories: []Story{
+			{Key: "2-1-first", Status: StatusDone},
+			{Key: "2-2-second", Status: StatusDone},
+			{Key: "2-10-tenth", Status: StatusReview},
+		}},
+		{ID: "10", Stories: []Story{{Key: "10-1-late", Status: StatusBacklog}}},
+		{ID: "auth", Stories: []Story{{Key: "auth-1-login", Status: StatusInProgress}}},
+		{Stories: []Story{
+			{Key: "2-notes-readme", Status: StatusBacklog},
+			{Key: "cleanup", Status: StatusBacklog},
+		}},
+	}
+	assert.Equal(t, want, s.Epics())
+}
+
+func TestSprintStatus_Epics_EpicEntries(t *testing.T) {
+	s := &SprintStatus{DevelopmentStatus: map[string]Status{
+		"epic-1":               EpicInProgress,
+		"1-1-login":            StatusDone,
+		"epic-1-retrospective": RetrospectiveOptional,
+		"epic-2":               EpicBacklog,
+	}}
+
+	want := []Epic{
+		{ID: "1", Status: EpicInProgress, Retrospective: RetrospectiveOptional, Stories: []Story{
+			{Key: "1-1-login", Status: StatusDone},
+		}},
+		{ID: "2", Status: EpicBacklog},
+	}
+	assert.Equal(t, want, s.Epics())
+}
+
+func TestSprintStatus_Epics_Empty(t *testing.T) {
+	s := &SprintStatus{}
+	assert.Empty(t, s.Epics())
+}
+
+func TestLessNumeric(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"2", "10", true},
+		{"10", "2", false},
+		{"9", "auth", true},
+		{"auth", "9", false},
+		{"auth", "billing", true},
+		{"08", "8", true},
+		{"8", "08", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"<"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, lessNumeric(tt.a, tt.b))
+		})
+	}
+}
+
+func TestIsStoryKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"7-1-define-schema", true},
+		{"cleanup", true},
+		{"epic-7", false},
+		{"epic-7-retrospective", false},
+		{"epic-auth", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsStoryKey(tt.key))
+		})
+	}
+}
+
+func TestEpicAndRetrospectiveKey(t *testing.T) {
+	assert.Equal(t, "epic-7", EpicKey("7"))
+	assert.Equal(t, "epic-7-retrospective", RetrospectiveKey("7"))
+}
+
+func TestEpicStatus(t *testing.T) {
+	isDone := func(s Status) bool { return s == StatusDone }
+	tests := []struct {
+		name        string
+		current     Status
+		stories     []Status
+		want        Status
+		wantChanged bool
+	}{
+		{"nothing started", EpicBacklog, []Status{StatusBacklog, StatusBacklog}, EpicBacklog, false},
+		{"first story started", EpicBacklog, []Status{StatusReadyForDev, StatusBacklog}, EpicInProgress, true},
+		{"contexted epic started", EpicContexted, []Status{StatusInProgress}, EpicInProgress, true},
+		{"still in progress", EpicInProgress, []Status{StatusDone, StatusReview}, EpicInProgress, false},
+		{"last story done", EpicInProgress, []Status{StatusDone, StatusDone}, EpicDone, true},
+		{"story reopened", EpicDone, []Status{StatusDone, StatusReview}, EpicInProgress, true},
+		{"no stories", EpicBacklog, nil, EpicBacklog, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stories := make([]Story, len(tt.stories))
+			for i, s := range tt.stories {
+				stories[i] = Story{Key: "1-" + string(rune('1'+i)) + "-story", Status: s}
+			}
+			got, changed := epicStatus(tt.current, stories, isDone)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantChanged, changed)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BMADConfigPath is the location of the BMAD method configuration relative to
+// the project root. Its output folder settings decide where the status file is.
+const BMADConfigPath = "_bmad/bmm/config.yaml"
+
+// ErrNotFound is returned by [Locate] when no BMAD project is found.
+var ErrNotFound = errors.New("no sprint status file found")
+
+// bmadConfig holds the settings of a BMAD config.yaml that locate the
+// sprint status file. Paths may start with the {project-root} placeholder.
+type bmadConfig struct {
+	// ImplementationArtifacts is the folder holding sprint-status.yaml.
+	ImplementationArtifacts string `yaml:"implementation_artifacts"`
+
+	// SprintArtifacts is the name older BMAD versions use for
+	// ImplementationArtifacts.
+	SprintArtifacts string `yaml:"sprint_artifacts"`
+
+	// OutputFolder is the folder holding all BMAD output, with the status
+	// file in its implementation-artifacts folder.
+	OutputFolder string `yaml:"output_folder"`
+}
+
+// Locate finds the sprint status file of the BMAD project in dir or its
+// closest parent directory that belongs to a BMAD project.
+//
+// Walking up from dir, the first directory that either has a [BMADConfigPath]
+// or a status file at [DefaultStatusPath] is the project root:
+//   - If it has a BMAD config, the status file is in the config's
+//     implementation_artifacts folder, or in the implementation-artifacts
+//     folder of its output_folder. The file need not exist yet.
+//   - Otherwise the status file at [DefaultStatusPath] is returned.
+//
+// Returns the status file and the absolute path of the project root,
+// [ErrNotFound] if no directory up to the file system root matches, or an
+// error if a BMAD config cannot be read.
+func Locate(dir string) (path, root string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to locate sprint status: %w", err)
+	}
+
+	for {
+		path, ok, err := locateIn(dir)
+		if err != nil {
+			return "", "", err
+		}
+		if ok {
+			return path, dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// locateIn returns the status file of the project rooted at dir, and whether
+// dir is a project root.
+func locateIn(dir string) (string, bool, error) {
+	defaultPath := filepath.Join(dir, DefaultStatusPath)
+
+	configPath := filepath.Join(dir, BMADConfigPath)
+	data, err := os.ReadFile(configPath)
+	if err == nil {
+		var cfg bmadConfig
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return "", false, fmt.Errorf("failed to read %s: %w", configPath, err)
+		}
+		folder := cfg.statusFolder()
+		if folder == "" {
+			// A BMAD config without output settings uses the default location.
+			return defaultPath, true, nil
+		}
+		return filepath.Join(resolveProjectPath(dir, folder), filepath.Base(DefaultStatusPath)), true, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", false, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	if _, err := os.Stat(defaultPath); err == nil {
+		return defaultPath, true, nil
+	}
+	return "", false, nil
+}
+
+// statusFolder returns the folder of the status file, or "" if the config
+// does not set one.
+func (c bmadConfig) statusFolder() string {
+	switch {
+	case c.ImplementationArtifacts != "":
+		return c.ImplementationArtifacts
+	case c.SprintArtifacts != "":
+		return c.SprintArtifacts
+	case c.OutputFolder != "":
+		return c.OutputFolder + "/" + filepath.Base(filepath.Dir(DefaultStatusPath))
+	default:
+		return ""
+	}
+}
+
+// resolveProjectPath resolves a path from a BMAD config against the project
+// root, replacing the {project-root} placeholder.
+func resolveProjectPath(root, path string) string {
+	path = strings.ReplaceAll(path, "{project-root}", root)
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(root, path)
+}
@@ -0,0 +1,110 @@
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile creates the file at root/name with content, creating parent
+// directories as needed.
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		from     string
+		want     string
+		wantRoot string
+	}{
+		{
+			name:  "default location",
+			files: map[string]string{DefaultStatusPath: "development_status: {}"},
+			want:  DefaultStatusPath,
+		},
+		{
+			name:  "walks up from a subdirectory",
+			files: map[string]string{DefaultStatusPath: "development_status: {}", "src/api/main.go": ""},
+			from:  "src/api",
+			want:  DefaultStatusPath,
+		},
+		{
+			name:     "nearest project wins",
+			files:    map[string]string{DefaultStatusPath: "", "services/shop/" + DefaultStatusPath: ""},
+			from:     "services/shop",
+			want:     "services/shop/" + DefaultStatusPath,
+			wantRoot: "services/shop",
+		},
+		{
+			name:  "implementation artifacts setting",
+			files: map[string]string{BMADConfigPath: `implementation_artifacts: "{project-root}/docs/sprint"`},
+			want:  "docs/sprint/sprint-status.yaml",
+		},
+		{
+			name:  "older sprint artifacts setting",
+			files: map[string]string{BMADConfigPath: `sprint_artifacts: "{project-root}/docs/sprint-artifacts"`},
+			want:  "docs/sprint-artifacts/sprint-status.yaml",
+		},
+		{
+			name:  "relative output folder",
+			files: map[string]string{BMADConfigPath: "output_folder: out"},
+			want:  "out/implementation-artifacts/sprint-status.yaml",
+		},
+		{
+			name: "BMAD config takes precedence over the default location",
+			files: map[string]string{
+				BMADConfigPath:    `output_folder: "{project-root}/docs"`,
+				DefaultStatusPath: "",
+			},
+			want: "docs/implementation-artifacts/sprint-status.yaml",
+		},
+		{
+			name:  "BMAD config without output settings",
+			files: map[string]string{BMADConfigPath: "project_name: shop", "src/main.go": ""},
+			from:  "src",
+			want:  DefaultStatusPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				writeFile(t, root, name, content)
+			}
+
+			got, gotRoot, err := Locate(filepath.Join(root, tt.from))
+
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(root, tt.want), got)
+			assert.Equal(t, filepath.Join(root, tt.wantRoot), gotRoot)
+		})
+	}
+}
+
+func TestLocate_Errors(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := Locate(t.TempDir())
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("invalid BMAD config", func(t *testing.T) {
+		root := t.TempDir()
+		writeFile(t, root, BMADConfigPath, "output_folder: [unclosed")
+
+		_, _, err := Locate(root)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read")
+		assert.Contains(t, err.Error(), BMADConfigPath)
+	})
+}
@@ -0,0 +1,158 @@
+//go:build !windows
+
+package status
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sprint-status.yaml.lock")
+
+	unlock, err := lockFile(path)
+	require.NoError(t, err)
+
+	locked := make(chan struct{})
+	go func() {
+		unlock2, err := lockFile(path)
+		assert.NoError(t, err)
+		close(locked)
+		unlock2()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("a second lock should wait for the first")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the second lock should be taken after unlock")
+	}
+}
+
+// hammerStatuses is the sequence of statuses each story is moved through.
+var hammerStatuses = []Status{StatusReadyForDev, StatusInProgress, StatusReview, StatusDone}
+
+// hammerContent returns a status file with stories stories in each of
+// workers epics, all in backlog.
+func hammerContent(workers, stories int) string {
+	var b strings.Builder
+	b.WriteString("# hammered\ndevelopment_status:\n")
+	for w := 1; w <= workers; w++ {
+		fmt.Fprintf(&b, "  epic-%d: backlog\n", w)
+		for s := 1; s <= stories; s++ {
+			fmt.Fprintf(&b, "  %d-%d-story: backlog\n", w, s)
+		}
+	}
+	return b.String()
+}
+
+// hammer moves every story of epic through hammerStatuses with its own
+// Writer, as a separate process would.
+func hammer(path string, epic, stories int) error {
+	writer := NewWriter("")
+	writer.SetPath(path)
+	for _, s := range hammerStatuses {
+		for story := 1; story <= stories; story++ {
+			if err := writer.UpdateStatus(fmt.Sprintf("%d-%d-story", epic, story), s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// assertHammered checks that no update of the hammer was lost.
+func assertHammered(t *testing.T, path string, workers, stories int) {
+	t.Helper()
+	reader := NewReader("")
+	reader.SetPath(path)
+	sprint, err := reader.Read()
+	require.NoError(t, err)
+
+	for w := 1; w <= workers; w++ {
+		assert.Equal(t, EpicDone, sprint.DevelopmentStatus[EpicKey(strconv.Itoa(w))])
+		for s := 1; s <= stories; s++ {
+			key := fmt.Sprintf("%d-%d-story", w, s)
+			assert.Equal(t, StatusDone, sprint.DevelopmentStatus[key], key)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# hammered\n"), "comments should be preserved")
+}
+
+func TestWriter_UpdateStatus_Concurrent(t *testing.T) {
+	const workers, stories = 8, 5
+	path := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(hammerContent(workers, stories)), 0644))
+
+	var wg sync.WaitGroup
+	for w := 1; w <= workers; w++ {
+		wg.Add(1)
+		go func(epic int) {
+			defer wg.Done()
+			assert.NoError(t, hammer(path, epic, stories))
+		}(w)
+	}
+	wg.Wait()
+
+	assertHammered(t, path, workers, stories)
+}
+
+func TestWriter_UpdateStatus_ConcurrentProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts several processes")
+	}
+	const workers, stories = 4, 5
+	path := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(hammerContent(workers, stories)), 0644))
+
+	cmds := make([]*exec.Cmd, workers)
+	for w := 1; w <= workers; w++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestHammerProcess$")
+		cmd.Env = append(os.Environ(),
+			"BMAD_HAMMER_PATH="+path,
+			"BMAD_HAMMER_EPIC="+strconv.Itoa(w),
+			"BMAD_HAMMER_STORIES="+strconv.Itoa(stories),
+		)
+		require.NoError(t, cmd.Start())
+		cmds[w-1] = cmd
+	}
+	for _, cmd := range cmds {
+		assert.NoError(t, cmd.Wait())
+	}
+
+	assertHammered(t, path, workers, stories)
+}
+
+// TestHammerProcess is not a real test: it is the process started by
+// TestWriter_UpdateStatus_ConcurrentProcesses.
+func TestHammerProcess(t *testing.T) {
+	path := os.Getenv("BMAD_HAMMER_PATH")
+	if path == "" {
+		t.Skip("started by TestWriter_UpdateStatus_ConcurrentProcesses")
+	}
+	epic, err := strconv.Atoi(os.Getenv("BMAD_HAMMER_EPIC"))
+	require.NoError(t, err)
+	stories, err := strconv.Atoi(os.Getenv("BMAD_HAMMER_STORIES"))
+	require.NoError(t, err)
+
+	require.NoError(t, hammer(path, epic, stories))
+}
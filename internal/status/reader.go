@@ -19,9 +19,11 @@ const DefaultStatusPath = "_bmad-output/implementation-artifacts/sprint-status.y
 //
 // The basePath field specifies the project root directory. When empty,
 // the current working directory is used. The full path to the status file
-// is constructed as: basePath + DefaultStatusPath.
+// is constructed as: basePath + DefaultStatusPath, unless a path is set
+// with [Reader.SetPath].
 type Reader struct {
 	basePath string
+	path     string
 }
 
 // NewReader creates a new [Reader] with the specified base path.
@@ -34,14 +36,26 @@ func NewReader(basePath string) *Reader {
 	}
 }
 
+// SetPath sets the path of the status file, overriding the base path, for
+// example to a path found by [Locate].
+func (r *Reader) SetPath(path string) {
+	r.path = path
+}
+
+// Path returns the path of the status file.
+func (r *Reader) Path() string {
+	if r.path != "" {
+		return r.path
+	}
+	return filepath.Join(r.basePath, DefaultStatusPath)
+}
+
 // Read reads and parses the complete sprint status file.
 //
 // It returns the full [SprintStatus] structure containing all story statuses.
 // Returns an error if the file cannot be read or parsed.
 func (r *Reader) Read() (*SprintStatus, error) {
-	fullPath := filepath.Join(r.basePath, DefaultStatusPath)
-
-	data, err := os.ReadFile(fullPath)
+	data, err := os.ReadFile(r.Path())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read sprint status: %w", err)
 	}
@@ -76,6 +90,8 @@ func (r *Reader) GetStoryStatus(storyKey string) (Status, error) {
 //
 // Story keys are matched using the pattern {epicID}-{N}-*, where N is a numeric
 // story number. Results are sorted numerically by story number (1, 2, 10 not 1, 10, 2).
+// The epic's own entry and its retrospective are not stories and are never
+// returned. The epic may also be given by its entry key, e.g. "epic-7".
 //
 // Returns an error if the file cannot be read or if no stories are found for the epic.
 func (r *Reader) GetEpicStories(epicID string) ([]string, error) {
@@ -91,9 +107,13 @@ func (r *Reader) GetEpicStories(epicID string) ([]string, error) {
 	}
 	var stories []storyWithNum
 
+	if kind, id, _ := parseKey(epicID); kind == kindEpic {
+		epicID = id
+	}
+
 	prefix := epicID + "-"
 	for key := range sprintStatus.DevelopmentStatus {
-		if !strings.HasPrefix(key, prefix) {
+		if !strings.HasPrefix(key, prefix) || !IsStoryKey(key) {
 			continue
 		}
 
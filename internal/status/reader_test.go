@@ -287,3 +287,65 @@ func TestReader_GetEpicStories_FileNotFound(t *testing.T) {
 	assert.Nil(t, stories)
 	assert.Contains(t, err.Error(), "failed to read sprint status")
 }
+
+// bmadStatusContent is a sprint-status.yaml as generated by BMAD, with
+// metadata, epic entries and retrospectives.
+const bmadStatusContent = `# generated: 2026-03-01
+generated: 2026-03-01
+project: shop
+project_key: SHOP
+tracking_system: file-system
+story_location: docs/stories
+
+development_status:
+  epic-1: in-progress
+  1-1-login: done
+  1-2-logout: review
+  epic-1-retrospective: optional
+  epic-2: backlog
+  2-1-cart: backlog
+  epic-2-retrospective: optional
+  epic-3: backlog
+`
+
+func writeBMADStatus(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(bmadStatusContent), 0644))
+	return tmpDir
+}
+
+func TestReader_Read_BMADSchema(t *testing.T) {
+	reader := NewReader(writeBMADStatus(t))
+
+	status, err := reader.Read()
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01", status.Generated)
+	assert.Equal(t, "shop", status.Project)
+	assert.Equal(t, "SHOP", status.ProjectKey)
+	assert.Equal(t, "file-system", status.TrackingSystem)
+	assert.Equal(t, "docs/stories", status.StoryLocation)
+	assert.Equal(t, EpicInProgress, status.DevelopmentStatus["epic-1"])
+	assert.Equal(t, RetrospectiveOptional, status.DevelopmentStatus["epic-1-retrospective"])
+}
+
+func TestReader_GetEpicStories_SkipsEpicEntries(t *testing.T) {
+	reader := NewReader(writeBMADStatus(t))
+
+	stories, err := reader.GetEpicStories("1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1-1-login", "1-2-logout"}, stories)
+
+	stories, err = reader.GetEpicStories("epic-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2-1-cart"}, stories, "the epic may be given by its entry key")
+
+	_, err = reader.GetEpicStories("epic")
+	assert.Error(t, err, "epic entries are not stories of an epic named epic")
+
+	_, err = reader.GetEpicStories("3")
+	assert.ErrorContains(t, err, "no stories found for epic: 3")
+}
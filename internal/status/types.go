@@ -7,6 +7,8 @@
 // Key types:
 //   - [Status] - Story development status enum with validation
 //   - [SprintStatus] - Parsed representation of sprint-status.yaml
+//   - [Epic] and [Story] - Epics with their stories and retrospective, see
+//     [SprintStatus.Epics]
 //   - [Reader] - Reads and queries sprint status from YAML files
 //   - [Writer] - Updates status values while preserving YAML formatting
 //
@@ -23,7 +25,9 @@ package status
 // See the internal/router package for status-to-workflow mapping.
 type Status string
 
-// Status constants define the valid development statuses in the story lifecycle.
+// Status constants define the built-in development statuses of the default
+// story lifecycle. Additional statuses can be defined in the lifecycle
+// configuration.
 const (
 	// StatusBacklog indicates a story that has not been started.
 	// Stories in backlog trigger the create-story workflow.
@@ -46,8 +50,11 @@ const (
 	StatusDone Status = "done"
 )
 
-// IsValid reports whether the status is one of the known valid status values.
+// IsValid reports whether the status is one of the built-in status values.
 // It returns true for backlog, ready-for-dev, in-progress, review, and done.
+//
+// Custom statuses defined in the lifecycle configuration are not covered; see
+// Router.IsValid in the internal/router package.
 func (s Status) IsValid() bool {
 	switch s {
 	case StatusBacklog, StatusReadyForDev, StatusInProgress, StatusReview, StatusDone:
@@ -57,12 +64,62 @@ func (s Status) IsValid() bool {
 	}
 }
 
+// Statuses of the epic-N entries in a BMAD sprint-status.yaml file.
+const (
+	// EpicBacklog indicates an epic whose stories have not been started.
+	EpicBacklog Status = "backlog"
+
+	// EpicContexted indicates an epic whose technical context has been
+	// created. Older BMAD versions use it instead of in-progress.
+	EpicContexted Status = "contexted"
+
+	// EpicInProgress indicates an epic with at least one started story.
+	EpicInProgress Status = "in-progress"
+
+	// EpicDone indicates an epic whose stories are all complete.
+	EpicDone Status = "done"
+)
+
+// Statuses of the epic-N-retrospective entries in a BMAD sprint-status.yaml
+// file.
+const (
+	// RetrospectiveOptional indicates a retrospective that has not been held.
+	RetrospectiveOptional Status = "optional"
+
+	// RetrospectiveCompleted indicates a retrospective that has been held.
+	RetrospectiveCompleted Status = "completed"
+)
+
 // SprintStatus represents the parsed contents of a sprint-status.yaml file.
 //
-// The file structure contains a development_status map where keys are story
-// identifiers (e.g., "7-1-define-schema") and values are their current [Status].
+// The development_status map holds three kinds of entries, told apart by
+// their key:
+//   - stories, e.g. "7-1-define-schema: review", with a story [Status]
+//   - epics, e.g. "epic-7: in-progress", see [EpicKey] and [EpicInProgress]
+//   - retrospectives, e.g. "epic-7-retrospective: optional", see
+//     [RetrospectiveKey] and [RetrospectiveOptional]
+//
+// Use [SprintStatus.Epics] for the typed view of epics and their stories.
+// The metadata fields are informational; files without them are valid.
 type SprintStatus struct {
-	// DevelopmentStatus maps story keys to their current development status.
-	// Story keys follow the pattern: {epicID}-{storyNum}-{description}.
+	// Generated is when the file was generated, as written in the file.
+	Generated string `yaml:"generated"`
+
+	// Project is the project name.
+	Project string `yaml:"project"`
+
+	// ProjectKey is the short project key.
+	ProjectKey string `yaml:"project_key"`
+
+	// TrackingSystem names the system stories are tracked in, e.g.
+	// "file-system".
+	TrackingSystem string `yaml:"tracking_system"`
+
+	// StoryLocation is where story files are kept.
+	StoryLocation string `yaml:"story_location"`
+
+	// DevelopmentStatus maps story, epic and retrospective keys to their
+	// current status. Story keys follow the pattern
+	// {epicID}-{storyNum}-{description}.
 	DevelopmentStatus map[string]Status `yaml:"development_status"`
 }
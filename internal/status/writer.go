@@ -1,20 +1,39 @@
 package status
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 )
 
+// ErrConflict is returned by [Writer.UpdateStatus] when the status file keeps
+// changing while it is being updated.
+var ErrConflict = errors.New("sprint status changed while it was being updated")
+
+// maxUpdateAttempts is how often [Writer.UpdateStatus] reads the file again
+// after it was changed by someone else before giving up with [ErrConflict].
+const maxUpdateAttempts = 3
+
 // Writer writes sprint status updates to YAML files at [DefaultStatusPath].
 //
 // It uses yaml.v3's Node API to preserve comments, ordering, and formatting
 // when updating status values. Writes are performed atomically using a
 // temporary file and rename pattern to prevent corruption.
+//
+// Updates are serialized with an advisory lock on a sidecar file next to the
+// status file, "sprint-status.yaml.lock", so that several bmad-automate
+// processes can share one status file. A Writer is safe for concurrent use.
 type Writer struct {
-	basePath string
+	mu               sync.Mutex
+	basePath         string
+	path             string
+	validStatuses    []Status
+	terminalStatuses []Status
 }
 
 // NewWriter creates a new [Writer] with the specified base path.
@@ -27,72 +46,175 @@ func NewWriter(basePath string) *Writer {
 	}
 }
 
+// SetPath sets the path of the status file, overriding the base path, for
+// example to a path found by [Locate].
+func (w *Writer) SetPath(path string) {
+	w.path = path
+}
+
+// Path returns the path of the status file.
+func (w *Writer) Path() string {
+	if w.path != "" {
+		return w.path
+	}
+	return filepath.Join(w.basePath, DefaultStatusPath)
+}
+
+// SetValidStatuses restricts updates to the given statuses.
+//
+// Use this when the lifecycle defines custom statuses, such as "qa". When not
+// set, only the built-in statuses accepted by [Status.IsValid] are allowed.
+func (w *Writer) SetValidStatuses(statuses []Status) {
+	w.validStatuses = append([]Status(nil), statuses...)
+}
+
+// SetTerminalStatuses sets the statuses in which a story is complete, which
+// decide when an epic is done. When not set, only [StatusDone] is terminal.
+func (w *Writer) SetTerminalStatuses(statuses []Status) {
+	w.terminalStatuses = append([]Status(nil), statuses...)
+}
+
 // UpdateStatus atomically updates the [Status] for a specific story key.
 //
 // The update process:
-//  1. Validates that newStatus is a known valid status
-//  2. Reads the existing file into a yaml.Node tree (preserves formatting)
-//  3. Locates and updates the story's status value
-//  4. Writes to a temporary file, then renames for atomic update
+//  1. Validates that newStatus is a known valid status (see [Writer.SetValidStatuses])
+//  2. Locks the sidecar lock file, waiting for other processes to finish
+//  3. Reads the existing file into a yaml.Node tree (preserves formatting)
+//  4. Locates and updates the story's status value
+//  5. Updates the status of the story's epic entry, if the file has one: the
+//     epic becomes [EpicInProgress] when its first story leaves the backlog
+//     and [EpicDone] when its last story completes
+//  6. Writes to a temporary file, checks that the status file still has the
+//     content read in step 3, then renames for atomic update
+//
+// Editors do not take the lock, so a file changed between steps 3 and 6 is
+// read and updated again, up to three times before [ErrConflict] is returned.
 //
 // Returns an error if the status is invalid, the file cannot be read/written,
 // or the story key is not found.
 func (w *Writer) UpdateStatus(storyKey string, newStatus Status) error {
 	// Validate the new status
-	if !newStatus.IsValid() {
+	if !w.isValid(newStatus) {
 		return fmt.Errorf("invalid status: %s", newStatus)
 	}
 
-	fullPath := filepath.Join(w.basePath, DefaultStatusPath)
+	w.mu.Lock()
+	defer w.mu.Unlock()
 
-	// Read existing file
-	data, err := os.ReadFile(fullPath)
-	if err != nil {
+	fullPath := w.Path()
+	if _, err := os.Stat(fullPath); err != nil {
 		return fmt.Errorf("failed to read sprint status: %w", err)
 	}
+	unlock, err := lockFile(fullPath + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
+	for attempt := 1; ; attempt++ {
+		// Read existing file
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			return fmt.Errorf("failed to read sprint status: %w", err)
+		}
+
+		updatedData, err := w.updateData(data, storyKey, newStatus)
+		if err != nil {
+			return err
+		}
+
+		err = replaceIfUnchanged(fullPath, data, updatedData)
+		if !errors.Is(err, ErrConflict) || attempt == maxUpdateAttempts {
+			return err
+		}
+	}
+}
+
+// updateData returns the status file data with the story's status, and its
+// epic's status, updated.
+func (w *Writer) updateData(data []byte, storyKey string, newStatus Status) ([]byte, error) {
 	// Parse YAML into a Node tree to preserve formatting
 	var doc yaml.Node
 	if err := yaml.Unmarshal(data, &doc); err != nil {
-		return fmt.Errorf("failed to parse sprint status: %w", err)
+		return nil, fmt.Errorf("failed to parse sprint status: %w", err)
 	}
 
 	// Find and update the story status in the node tree
-	if err := updateStoryStatusInNode(&doc, storyKey, newStatus); err != nil {
-		return err
+	devStatusNode, err := developmentStatusNode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	if !setStatusInNode(devStatusNode, storyKey, newStatus) {
+		return nil, fmt.Errorf("story not found: %s", storyKey)
+	}
+	if err := w.updateEpicInNode(devStatusNode, storyKey); err != nil {
+		return nil, err
 	}
 
 	// Marshal the node tree back to YAML (preserves formatting)
 	updatedData, err := yaml.Marshal(&doc)
 	if err != nil {
-		return fmt.Errorf("failed to marshal sprint status: %w", err)
+		return nil, fmt.Errorf("failed to marshal sprint status: %w", err)
 	}
+	return updatedData, nil
+}
 
-	// Write back to file atomically (write to temp, then rename)
-	tmpPath := fullPath + ".tmp"
-	if err := os.WriteFile(tmpPath, updatedData, 0644); err != nil {
+// replaceIfUnchanged atomically replaces the file at path with data, unless
+// its content is no longer original. A changed file is left
+// as it is and [ErrConflict] is returned.
+func replaceIfUnchanged(path string, original, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
 		return fmt.Errorf("failed to write sprint status: %w", err)
 	}
 
-	if err := os.Rename(tmpPath, fullPath); err != nil {
-		// Clean up temp file on rename failure
+	// Write to a temp file in the same directory, so the rename is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write sprint status: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, info.Mode().Perm())
+	}
+	if err != nil {
 		os.Remove(tmpPath)
 		return fmt.Errorf("failed to write sprint status: %w", err)
 	}
 
+	current, err := os.ReadFile(path)
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to read sprint status: %w", err)
+	}
+	if !bytes.Equal(current, original) {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write sprint status: %w", ErrConflict)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		// Clean up temp file on rename failure
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write sprint status: %w", err)
+	}
 	return nil
 }
 
-// updateStoryStatusInNode finds and updates a story's status within a yaml.Node tree.
-func updateStoryStatusInNode(doc *yaml.Node, storyKey string, newStatus Status) error {
+// developmentStatusNode returns the development_status mapping of a
+// yaml.Node tree.
+func developmentStatusNode(doc *yaml.Node) (*yaml.Node, error) {
 	// Document node contains the root content node
 	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
-		return fmt.Errorf("invalid YAML document structure")
+		return nil, fmt.Errorf("invalid YAML document structure")
 	}
 
 	root := doc.Content[0]
 	if root.Kind != yaml.MappingNode {
-		return fmt.Errorf("expected mapping at root level")
+		return nil, fmt.Errorf("expected mapping at root level")
 	}
 
 	// Find development_status key in root mapping
@@ -106,23 +228,79 @@ func updateStoryStatusInNode(doc *yaml.Node, storyKey string, newStatus Status)
 	}
 
 	if devStatusNode == nil {
-		return fmt.Errorf("development_status not found in file")
+		return nil, fmt.Errorf("development_status not found in file")
 	}
 
 	if devStatusNode.Kind != yaml.MappingNode {
-		return fmt.Errorf("development_status is not a mapping")
+		return nil, fmt.Errorf("development_status is not a mapping")
 	}
 
-	// Find the story key within development_status
+	return devStatusNode, nil
+}
+
+// setStatusInNode updates the value of key within the development_status
+// mapping. It returns false if the key is not found.
+func setStatusInNode(devStatusNode *yaml.Node, key string, newStatus Status) bool {
 	for i := 0; i < len(devStatusNode.Content); i += 2 {
-		keyNode := devStatusNode.Content[i]
-		if keyNode.Value == storyKey {
-			// Update the value node
-			valueNode := devStatusNode.Content[i+1]
-			valueNode.Value = string(newStatus)
-			return nil
+		if devStatusNode.Content[i].Value == key {
+			devStatusNode.Content[i+1].Value = string(newStatus)
+			return true
 		}
 	}
+	return false
+}
+
+// updateEpicInNode updates the epic entry of the story's epic, if the file
+// has one, to match the statuses of the epic's stories.
+func (w *Writer) updateEpicInNode(devStatusNode *yaml.Node, storyKey string) error {
+	_, epicID, _ := parseKey(storyKey)
+	if epicID == "" {
+		return nil
+	}
 
-	return fmt.Errorf("story not found: %s", storyKey)
+	var entries map[string]Status
+	if err := devStatusNode.Decode(&entries); err != nil {
+		return fmt.Errorf("failed to parse sprint status: %w", err)
+	}
+	current, ok := entries[EpicKey(epicID)]
+	if !ok {
+		return nil
+	}
+
+	var stories []Story
+	for key, s := range entries {
+		if kind, id, _ := parseKey(key); kind == kindStory && id == epicID {
+			stories = append(stories, Story{Key: key, Status: s})
+		}
+	}
+	if want, changed := epicStatus(current, stories, w.isComplete); changed {
+		setStatusInNode(devStatusNode, EpicKey(epicID), want)
+	}
+	return nil
+}
+
+// isComplete reports whether a story in status s is complete.
+func (w *Writer) isComplete(s Status) bool {
+	if w.terminalStatuses == nil {
+		return s == StatusDone
+	}
+	for _, terminal := range w.terminalStatuses {
+		if s == terminal {
+			return true
+		}
+	}
+	return false
+}
+
+// isValid reports whether s is allowed by the configured statuses.
+func (w *Writer) isValid(s Status) bool {
+	if w.validStatuses == nil {
+		return s.IsValid()
+	}
+	for _, valid := range w.validStatuses {
+		if s == valid {
+			return true
+		}
+	}
+	return false
 }
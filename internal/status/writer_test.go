@@ -99,6 +99,27 @@ func TestWriter_UpdateStatus_InvalidStatus(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid status")
 }
 
+func TestWriter_UpdateStatus_CustomStatuses(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+	statusPath := filepath.Join(statusDir, "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte("development_status:\n  7-1-define-schema: review\n"), 0644))
+
+	writer := NewWriter(tmpDir)
+	writer.SetValidStatuses([]Status{StatusReview, "qa", StatusDone})
+
+	require.NoError(t, writer.UpdateStatus("7-1-define-schema", Status("qa")))
+
+	data, err := os.ReadFile(statusPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "7-1-define-schema: qa")
+
+	err = writer.UpdateStatus("7-1-define-schema", StatusBacklog)
+	assert.ErrorContains(t, err, "invalid status", "built-in status outside the configured set should be rejected")
+}
+
 func TestWriter_UpdateStatus_FileNotFound(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -213,3 +234,140 @@ func TestWriter_UpdateStatus_AllStatusTransitions(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_UpdateStatus_EpicStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		storyKey  string
+		newStatus Status
+		terminal  []Status
+		wantEpic1 Status
+		wantEpic2 Status
+	}{
+		{
+			name:      "first story started",
+			storyKey:  "2-1-cart",
+			newStatus: StatusReadyForDev,
+			wantEpic1: EpicInProgress,
+			wantEpic2: EpicInProgress,
+		},
+		{
+			name:      "last story completed",
+			storyKey:  "1-2-logout",
+			newStatus: StatusDone,
+			wantEpic1: EpicDone,
+			wantEpic2: EpicBacklog,
+		},
+		{
+			name:      "custom terminal status",
+			storyKey:  "1-2-logout",
+			newStatus: StatusReview,
+			terminal:  []Status{StatusDone, StatusReview},
+			wantEpic1: EpicDone,
+			wantEpic2: EpicBacklog,
+		},
+		{
+			name:      "epic unchanged while stories remain",
+			storyKey:  "1-1-login",
+			newStatus: StatusReview,
+			wantEpic1: EpicInProgress,
+			wantEpic2: EpicBacklog,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := writeBMADStatus(t)
+			writer := NewWriter(tmpDir)
+			if tt.terminal != nil {
+				writer.SetTerminalStatuses(tt.terminal)
+			}
+
+			require.NoError(t, writer.UpdateStatus(tt.storyKey, tt.newStatus))
+
+			status, err := NewReader(tmpDir).Read()
+			require.NoError(t, err)
+			assert.Equal(t, tt.newStatus, status.DevelopmentStatus[tt.storyKey])
+			assert.Equal(t, tt.wantEpic1, status.DevelopmentStatus["epic-1"])
+			assert.Equal(t, tt.wantEpic2, status.DevelopmentStatus["epic-2"])
+			assert.Equal(t, EpicBacklog, status.DevelopmentStatus["epic-3"], "epics without stories keep their status")
+			assert.Equal(t, RetrospectiveOptional, status.DevelopmentStatus["epic-1-retrospective"])
+			assert.Equal(t, "shop", status.Project)
+		})
+	}
+}
+
+func TestWriter_UpdateStatus_NoEpicEntry(t *testing.T) {
+	tmpDir := t.TempDir()
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+	statusPath := filepath.Join(statusDir, "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte("development_status:\n  7-1-schema: review\n"), 0644))
+
+	require.NoError(t, NewWriter(tmpDir).UpdateStatus("7-1-schema", StatusDone))
+
+	data, err := os.ReadFile(statusPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "7-1-schema: done")
+	assert.NotContains(t, string(data), "epic-7", "no epic entry should be added")
+}
+
+func TestWriter_SetPath(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "planning/status.yaml", "development_status:\n  7-1-schema: review\n")
+	path := filepath.Join(root, "planning", "status.yaml")
+
+	reader := NewReader(root)
+	writer := NewWriter(root)
+	assert.Equal(t, filepath.Join(root, DefaultStatusPath), reader.Path())
+	assert.Equal(t, filepath.Join(root, DefaultStatusPath), writer.Path())
+
+	reader.SetPath(path)
+	writer.SetPath(path)
+	assert.Equal(t, path, reader.Path())
+	assert.Equal(t, path, writer.Path())
+
+	require.NoError(t, writer.UpdateStatus("7-1-schema", StatusDone))
+	got, err := reader.GetStoryStatus("7-1-schema")
+	require.NoError(t, err)
+	assert.Equal(t, StatusDone, got)
+}
+
+func TestReplaceIfUnchanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		wantErr  error
+		want     string
+	}{
+		{name: "unchanged file is replaced", original: "a: 1\n", want: "a: 2\n"},
+		{name: "changed file is kept", original: "a: 0\n", wantErr: ErrConflict, want: "a: 1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "sprint-status.yaml")
+			require.NoError(t, os.WriteFile(path, []byte("a: 1\n"), 0640))
+
+			err := replaceIfUnchanged(path, []byte(tt.original), []byte("a: 2\n"))
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			data, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			assert.Equal(t, tt.want, string(data))
+
+			info, statErr := os.Stat(path)
+			require.NoError(t, statErr)
+			assert.Equal(t, os.FileMode(0640), info.Mode().Perm(), "the file mode should be kept")
+
+			entries, dirErr := os.ReadDir(dir)
+			require.NoError(t, dirErr)
+			assert.Len(t, entries, 1, "no temporary files should be left behind")
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package story_test
+
+import (
+	"fmt"
+
+	"bmad-automate/internal/story"
+)
+
+// This example lists the tasks dev-story has not checked off yet.
+func ExampleStory_Incomplete() {
+	s := story.Parse([]byte(`# Story 7.1: Define schema
+
+Status: in-progress
+
+## Tasks / Subtasks
+
+- [x] Create the schema
+  - [x] Define the tables
+  - [ ] Add indexes
+- [ ] Document the schema
+`))
+
+	fmt.Println(s.Status)
+	for _, task := range s.Incomplete() {
+		fmt.Printf("line %d: %s\n", task.Line, task.Text)
+	}
+	// Output:
+	// in-progress
+	// line 9: Add indexes
+	// line 10: Document the schema
+}
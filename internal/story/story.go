@@ -0,0 +1,317 @@
+// Package story parses the story markdown files written by the BMAD workflows.
+//
+// create-story writes one file per story, <story-key>.md, next to
+// sprint-status.yaml, with its acceptance criteria and tasks. dev-story checks
+// off the tasks and fills in the Dev Agent Record and Change Log, and
+// code-review adds review follow-ups as tasks tagged [AI-Review].
+// The parser is lenient: sections it does not find are left empty.
+//
+// Key types:
+//   - [Story] - A parsed story file
+//   - [Task] - A task checkbox, with its subtasks
+//   - [DevAgentRecord] - The notes dev-story leaves about its work
+package story
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"bmad-automate/internal/status"
+)
+
+var (
+	// checkboxPattern matches a task list item and captures its indentation,
+	// check mark and text, e.g. "  - [x] Add tests".
+	checkboxPattern = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s*(.*)$`)
+
+	// listItemPattern matches a list item without a checkbox.
+	listItemPattern = regexp.MustCompile(`^\s*(?:[-*+]|\d+\.)\s+(.*)$`)
+
+	// tableRulePattern matches the line below the header of a table.
+	tableRulePattern = regexp.MustCompile(`^\|[\s:|-]+\|?$`)
+
+	// statusPattern matches the status line below the title, allowing
+	// Markdown emphasis, e.g. "Status: review" or "**Status:** review".
+	statusPattern = regexp.MustCompile(`^\**Status:?\**:?\s*(.+?)\s*$`)
+
+	// findingPattern matches the tag of a review follow-up, e.g.
+	// "[AI-Review][High] Validate input".
+	findingPattern = regexp.MustCompile(`(?i)^\[AI-Review\]`)
+)
+
+// Story is a parsed story file.
+type Story struct {
+	// Title is the text of the first level-one heading, e.g.
+	// "Story 7.1: Define schema".
+	Title string
+
+	// Status is the value of the "Status:" line. Empty if there is none.
+	Status status.Status
+
+	// AcceptanceCriteria are the items of the "Acceptance Criteria"
+	// section. Lines that continue an item are joined to it.
+	AcceptanceCriteria []string
+
+	// Tasks are the top-level checkboxes of the "Tasks / Subtasks" section,
+	// including the review follow-ups listed there.
+	Tasks []Task
+
+	// DevAgentRecord holds the "Dev Agent Record" section.
+	DevAgentRecord DevAgentRecord
+
+	// ChangeLog are the entries of the "Change Log" section, from a list
+	// or from the rows of a table, whose cells are joined with " | ".
+	ChangeLog []string
+
+	// Findings are the checkboxes tagged [AI-Review] anywhere in the file,
+	// checked or not.
+	Findings []Task
+}
+
+// Task is a checkbox in a story file.
+type Task struct {
+	// Text is the text after the checkbox.
+	Text string
+
+	// Done reports whether the checkbox is checked.
+	Done bool
+
+	// Line is the 1-based line number of the checkbox.
+	Line int
+
+	// Subtasks are the checkboxes indented below the task. Deeper levels are
+	// flattened into the subtasks of the top-level task.
+	Subtasks []Task
+}
+
+// DevAgentRecord is the record dev-story keeps of its work on a story.
+type DevAgentRecord struct {
+	// AgentModel is the first line of "Agent Model Used".
+	AgentModel string
+
+	// DebugLog lists the items of "Debug Log References".
+	DebugLog []string
+
+	// CompletionNotes lists the items of "Completion Notes List".
+	CompletionNotes []string
+
+	// Files lists the items of "File List".
+	Files []string
+}
+
+// Path returns the path of the file of storyKey in dir.
+func Path(dir, storyKey string) string {
+	return filepath.Join(dir, storyKey+".md")
+}
+
+// Load reads and parses the story file at path.
+//
+// The error wraps [os.ErrNotExist] if the file does not exist.
+func Load(path string) (*Story, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read story file: %w", err)
+	}
+	return Parse(data), nil
+}
+
+// Parse parses the contents of a story file.
+func Parse(data []byte) *Story {
+	s := &Story{}
+
+	var section, subsection string
+	inFence := false
+	taskIndent := -1
+	continuing := false // The next line may continue the last criterion
+	inTable := false    // The next table row is not a header
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "# "):
+			if s.Title == "" {
+				s.Title = strings.TrimSpace(trimmed[2:])
+			}
+			continue
+		case strings.HasPrefix(trimmed, "## "):
+			section = strings.ToLower(strings.TrimSpace(trimmed[3:]))
+			subsection = ""
+			continuing, inTable = false, false
+			continue
+		case strings.HasPrefix(trimmed, "### "):
+			subsection = strings.ToLower(strings.TrimSpace(trimmed[4:]))
+			continue
+		}
+
+		if s.Status == "" && section == "" {
+			if m := statusPattern.FindStringSubmatch(trimmed); m != nil {
+				s.Status = status.Status(strings.Trim(m[1], "*_` "))
+				continue
+			}
+		}
+
+		if m := checkboxPattern.FindStringSubmatch(text); m != nil {
+			task := Task{Text: m[3], Done: m[2] != " ", Line: line}
+			if findingPattern.MatchString(task.Text) {
+				s.Findings = append(s.Findings, task)
+			}
+			if strings.HasPrefix(section, "tasks") {
+				indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+				if taskIndent < 0 || indent <= taskIndent || len(s.Tasks) == 0 {
+					taskIndent = indent
+					s.Tasks = append(s.Tasks, task)
+				} else {
+					parent := &s.Tasks[len(s.Tasks)-1]
+					parent.Subtasks = append(parent.Subtasks, task)
+				}
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(section, "acceptance criteria"):
+			continuing = s.addCriterion(text, continuing)
+		case section == "dev agent record":
+			s.DevAgentRecord.add(subsection, trimmed)
+		case section == "change log":
+			inTable = s.addChange(trimmed, inTable)
+		}
+	}
+
+	return s
+}
+
+// addCriterion records a line of the acceptance criteria. An unindented list
+// item or the first line of a paragraph starts a criterion; other lines
+// continue the last one while continuing is set. It returns whether the next
+// line may continue the criterion.
+func (s *Story) addCriterion(line string, continuing bool) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return false
+	}
+
+	m := listItemPattern.FindStringSubmatch(line)
+	indented := line != strings.TrimLeft(line, " \t")
+	if continuing && len(s.AcceptanceCriteria) > 0 && (m == nil || indented) {
+		s.AcceptanceCriteria[len(s.AcceptanceCriteria)-1] += " " + trimmed
+		return true
+	}
+	if m != nil {
+		trimmed = m[1]
+	}
+	s.AcceptanceCriteria = append(s.AcceptanceCriteria, trimmed)
+	return true
+}
+
+// addChange records a line of the change log. Table rows are entries, except
+// for the header row; inTable reports whether the header was seen. It returns
+// the new value of inTable.
+func (s *Story) addChange(line string, inTable bool) bool {
+	switch {
+	case tableRulePattern.MatchString(line):
+		return true
+	case strings.HasPrefix(line, "|"):
+		if !inTable {
+			return false // Header row, followed by the rule
+		}
+		var cells []string
+		for _, cell := range strings.Split(strings.Trim(line, "|"), "|") {
+			if cell = strings.TrimSpace(cell); cell != "" {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) > 0 {
+			s.ChangeLog = append(s.ChangeLog, strings.Join(cells, " | "))
+		}
+		return true
+	}
+
+	if m := listItemPattern.FindStringSubmatch(line); m != nil {
+		s.ChangeLog = append(s.ChangeLog, m[1])
+	}
+	return false
+}
+
+// add records a line of the given subsection of the Dev Agent Record.
+func (r *DevAgentRecord) add(subsection, line string) {
+	if line == "" {
+		return
+	}
+	item := line
+	if m := listItemPattern.FindStringSubmatch(line); m != nil {
+		item = m[1]
+	}
+
+	switch {
+	case strings.HasPrefix(subsection, "agent model"):
+		if r.AgentModel == "" {
+			r.AgentModel = item
+		}
+	case strings.HasPrefix(subsection, "debug log"):
+		r.DebugLog = append(r.DebugLog, item)
+	case strings.HasPrefix(subsection, "completion notes"):
+		r.CompletionNotes = append(r.CompletionNotes, item)
+	case strings.HasPrefix(subsection, "file list"):
+		r.Files = append(r.Files, item)
+	}
+}
+
+// Incomplete returns the unchecked tasks and subtasks in file order, without
+// their subtasks. A checked task with unchecked subtasks only contributes the
+// subtasks.
+func (s *Story) Incomplete() []Task {
+	var open []Task
+	for _, task := range s.Tasks {
+		if !task.Done {
+			open = append(open, Task{Text: task.Text, Line: task.Line})
+		}
+		for _, sub := range task.Subtasks {
+			if !sub.Done {
+				open = append(open, Task{Text: sub.Text, Line: sub.Line})
+			}
+		}
+	}
+	return open
+}
+
+// Progress returns the number of checked and of all tasks and subtasks.
+func (s *Story) Progress() (done, total int) {
+	for _, task := range s.Tasks {
+		for _, t := range append([]Task{task}, task.Subtasks...) {
+			total++
+			if t.Done {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// OpenFindings returns the review follow-ups that are not checked yet.
+func (s *Story) OpenFindings() []Task {
+	var open []Task
+	for _, finding := range s.Findings {
+		if !finding.Done {
+			open = append(open, finding)
+		}
+	}
+	return open
+}
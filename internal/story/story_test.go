@@ -0,0 +1,206 @@
+package story
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/status"
+)
+
+const reviewedStory = `# Story 7.1: Define schema
+
+Status: review
+
+## Story
+
+As a developer, I want a schema, so that data is validated.
+
+## Tasks / Subtasks
+
+- [x] Create the schema (AC: 1)
+  - [x] Define the tables
+  - [ ] Add indexes
+- [ ] Document the schema
+- [X] Wire up migrations
+
+### Review Follow-ups (AI)
+
+- [ ] [AI-Review][High] Validate input lengths [schema.go:42]
+- [x] [AI-Review][Med] Rename the table
+
+## Dev Notes
+
+` + "```markdown" + `
+- [ ] Not a task, just an example
+` + "```" + `
+
+## Dev Agent Record
+
+### Agent Model Used
+
+claude-sonnet
+
+### Debug Log References
+
+### Completion Notes List
+
+- Created the schema
+- Migrations run on startup
+
+### File List
+
+- internal/db/schema.go
+- internal/db/schema_test.go
+
+## Senior Developer Review (AI)
+
+* [ ] [ai-review][Low] Add a comment
+`
+
+func TestParse(t *testing.T) {
+	s := Parse([]byte(reviewedStory))
+
+	assert.Equal(t, "Story 7.1: Define schema", s.Title)
+	assert.Equal(t, status.StatusReview, s.Status)
+
+	require.Len(t, s.Tasks, 5)
+	assert.Equal(t, Task{Text: "Create the schema (AC: 1)", Done: true, Line: 11, Subtasks: []Task{
+		{Text: "Define the tables", Done: true, Line: 12},
+		{Text: "Add indexes", Line: 13},
+	}}, s.Tasks[0])
+	assert.Equal(t, "Document the schema", s.Tasks[1].Text)
+	assert.False(t, s.Tasks[1].Done)
+	assert.True(t, s.Tasks[2].Done, "an upper-case X checks the box")
+	assert.Equal(t, "[AI-Review][High] Validate input lengths [schema.go:42]", s.Tasks[3].Text)
+
+	assert.Equal(t, DevAgentRecord{
+		AgentModel:      "claude-sonnet",
+		CompletionNotes: []string{"Created the schema", "Migrations run on startup"},
+		Files:           []string{"internal/db/schema.go", "internal/db/schema_test.go"},
+	}, s.DevAgentRecord)
+
+	require.Len(t, s.Findings, 3)
+	assert.Equal(t, "[ai-review][Low] Add a comment", s.Findings[2].Text)
+}
+
+func TestParse_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    status.Status
+	}{
+		{"plain", "# Story\n\nStatus: ready-for-dev\n", status.StatusReadyForDev},
+		{"bold", "# Story\n\n**Status:** done\n", status.StatusDone},
+		{"missing", "# Story\n\n## Story\n\nStatus: review\n", ""},
+		{"empty file", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse([]byte(tt.content)).Status)
+		})
+	}
+}
+
+func TestStory_Incomplete(t *testing.T) {
+	s := Parse([]byte(reviewedStory))
+
+	var texts []string
+	for _, task := range s.Incomplete() {
+		assert.Empty(t, task.Subtasks)
+		texts = append(texts, task.Text)
+	}
+	assert.Equal(t, []string{
+		"Add indexes",
+		"Document the schema",
+		"[AI-Review][High] Validate input lengths [schema.go:42]",
+	}, texts)
+
+	assert.Empty(t, Parse([]byte("# Story\n\n- [ ] Not in the tasks section\n")).Incomplete())
+}
+
+func TestStory_OpenFindings(t *testing.T) {
+	s := Parse([]byte(reviewedStory))
+
+	open := s.OpenFindings()
+	require.Len(t, open, 2)
+	assert.Equal(t, 19, open[0].Line)
+	assert.Equal(t, "[ai-review][Low] Add a comment", open[1].Text)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "7-1-schema.md"), []byte(reviewedStory), 0644))
+
+	s, err := Load(Path(dir, "7-1-schema"))
+	require.NoError(t, err)
+	assert.Equal(t, status.StatusReview, s.Status)
+
+	_, err = Load(Path(dir, "7-2-api"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestParse_AcceptanceCriteria(t *testing.T) {
+	content := `# Story 7.2: API
+
+## Acceptance Criteria
+
+1. Given a schema, when the API starts,
+   then it validates requests.
+2. Errors are returned as JSON
+   - with a code
+   - and a message
+
+**AC3:** Responses are logged.
+
+## Tasks / Subtasks
+`
+	assert.Equal(t, []string{
+		"Given a schema, when the API starts, then it validates requests.",
+		"Errors are returned as JSON - with a code - and a message",
+		"**AC3:** Responses are logged.",
+	}, Parse([]byte(content)).AcceptanceCriteria)
+}
+
+func TestParse_ChangeLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "table",
+			content: "## Change Log\n\n| Date | Version | Description |\n| ---- | :-----: | ----------- |\n| 2026-01-10 | 1.0 | Initial draft |\n| 2026-01-12 | 1.1 | Implemented |\n",
+			want:    []string{"2026-01-10 | 1.0 | Initial draft", "2026-01-12 | 1.1 | Implemented"},
+		},
+		{
+			name:    "list",
+			content: "## Change Log\n\n- 2026-01-10: Initial draft\n- 2026-01-12: Implemented\n",
+			want:    []string{"2026-01-10: Initial draft", "2026-01-12: Implemented"},
+		},
+		{
+			name:    "missing",
+			content: "# Story\n\n| Date | Version |\n| --- | --- |\n| 2026-01-10 | 1.0 |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse([]byte(tt.content)).ChangeLog)
+		})
+	}
+}
+
+func TestStory_Progress(t *testing.T) {
+	done, total := Parse([]byte(reviewedStory)).Progress()
+	assert.Equal(t, 4, done)
+	assert.Equal(t, 7, total)
+
+	done, total = Parse([]byte("# Story\n")).Progress()
+	assert.Zero(t, done)
+	assert.Zero(t, total)
+}
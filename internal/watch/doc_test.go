@@ -0,0 +1,29 @@
+package watch_test
+
+import (
+	"fmt"
+
+	"bmad-automate/internal/status"
+	"bmad-automate/internal/watch"
+)
+
+// This example compares two reads of the sprint status file, as the watcher
+// does after every settled write.
+func ExampleDiff() {
+	before := &status.SprintStatus{DevelopmentStatus: map[string]status.Status{
+		"epic-7":     status.EpicBacklog,
+		"7-1-schema": status.StatusBacklog,
+		"7-2-api":    status.StatusBacklog,
+	}}
+	after := &status.SprintStatus{DevelopmentStatus: map[string]status.Status{
+		"epic-7":     status.EpicInProgress,
+		"7-1-schema": status.StatusReadyForDev,
+		"7-2-api":    status.StatusBacklog,
+	}}
+
+	for _, change := range watch.Diff(before, after) {
+		fmt.Printf("%s: %s -> %s\n", change.Key, change.From, change.To)
+	}
+	// Output:
+	// 7-1-schema: backlog -> ready-for-dev
+}
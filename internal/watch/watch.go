@@ -0,0 +1,166 @@
+// Package watch reports story status changes in the sprint status file.
+//
+// A [Watcher] watches sprint-status.yaml with fsnotify. Editors and tools
+// often write a file several times in quick succession, so the file is only
+// read again once it has not changed for a debounce period. Every story whose
+// status differs from the previous read is then reported as a [Change].
+//
+// Key types:
+//   - [Watcher] - Watches the status file and reports changes
+//   - [Change] - A story that moved from one status to another
+package watch
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"bmad-automate/internal/status"
+)
+
+// Change is a story whose status changed between two reads of the status file.
+type Change struct {
+	// Key is the story key.
+	Key string
+
+	// From is the previous status, or "" for a story that was added.
+	From status.Status
+
+	// To is the new status.
+	To status.Status
+}
+
+// StatusReader reads the sprint status file. The production implementation is
+// [status.Reader].
+type StatusReader interface {
+	Read() (*status.SprintStatus, error)
+}
+
+// Watcher watches a sprint status file and reports story status changes.
+//
+// Use [New] to create an instance and [Watcher.Close] to release it.
+type Watcher struct {
+	path     string
+	reader   StatusReader
+	debounce time.Duration
+	onError  func(error)
+
+	fsw  *fsnotify.Watcher
+	last *status.SprintStatus
+}
+
+// New starts watching the status file at path, read through reader.
+//
+// Changes are those made after New returns; they are reported by
+// [Watcher.Run] once the file has not been written for debounce. Returns an
+// error if the file cannot be read or its directory cannot be watched.
+func New(path string, reader StatusReader, debounce time.Duration) (*Watcher, error) {
+	last, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch sprint status: %w", err)
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch sprint status: %w", err)
+	}
+
+	// Watch the directory: editors and status.Writer replace the file by
+	// renaming, which ends a watch on the file itself.
+	if err := fsw.Add(filepath.Dir(path)); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch sprint status: %w", err)
+	}
+
+	return &Watcher{
+		path:     path,
+		reader:   reader,
+		debounce: debounce,
+		onError:  func(error) {},
+		fsw:      fsw,
+		last:     last,
+	}, nil
+}
+
+// SetErrorHandler sets the function called when the file cannot be read or
+// watched while [Watcher.Run] keeps running, for example while it holds
+// invalid YAML. By default such errors are ignored.
+func (w *Watcher) SetErrorHandler(fn func(err error)) {
+	w.onError = fn
+}
+
+// Close stops watching the file.
+func (w *Watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// Run reports changes to the status file until ctx is done or the watcher is
+// closed, calling onChange with the changes of every settled write. Stories
+// whose status did not change, and epic and retrospective entries, are not
+// reported.
+//
+// onChange is called on the goroutine that runs Run; the file is not read
+// again until it returns.
+func (w *Watcher) Run(ctx context.Context, onChange func([]Change)) {
+	settled := time.NewTimer(w.debounce)
+	settled.Stop()
+	defer settled.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != w.path || event.Op == fsnotify.Chmod {
+				continue
+			}
+			settled.Reset(w.debounce)
+
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			w.onError(fmt.Errorf("failed to watch sprint status: %w", err))
+
+		case <-settled.C:
+			current, err := w.reader.Read()
+			if err != nil {
+				w.onError(err)
+				continue
+			}
+			if changes := Diff(w.last, current); len(changes) > 0 {
+				onChange(changes)
+			}
+			w.last = current
+		}
+	}
+}
+
+// Diff returns the stories of current whose status differs from previous, in
+// board order (see [status.SprintStatus.Epics]). Removed stories are not
+// reported.
+func Diff(previous, current *status.SprintStatus) []Change {
+	var changes []Change
+	for _, epic := range current.Epics() {
+		for _, story := range epic.Stories {
+			from, ok := previous.DevelopmentStatus[story.Key]
+			if ok && from == story.Status {
+				continue
+			}
+			changes = append(changes, Change{Key: story.Key, From: from, To: story.Status})
+		}
+	}
+	return changes
+}
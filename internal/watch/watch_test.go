@@ -0,0 +1,123 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/status"
+)
+
+func TestDiff(t *testing.T) {
+	previous := &status.SprintStatus{DevelopmentStatus: map[string]status.Status{
+		"epic-7":     status.EpicBacklog,
+		"7-1-schema": status.StatusBacklog,
+		"7-2-api":    status.StatusBacklog,
+		"7-3-ui":     status.StatusReview,
+		"7-4-old":    status.StatusBacklog,
+	}}
+	current := &status.SprintStatus{DevelopmentStatus: map[string]status.Status{
+		"epic-7":     status.EpicInProgress,
+		"7-1-schema": status.StatusReadyForDev,
+		"7-2-api":    status.StatusBacklog,
+		"7-3-ui":     status.StatusDone,
+		"7-10-new":   status.StatusBacklog,
+	}}
+
+	assert.Equal(t, []Change{
+		{Key: "7-1-schema", From: status.StatusBacklog, To: status.StatusReadyForDev},
+		{Key: "7-3-ui", From: status.StatusReview, To: status.StatusDone},
+		{Key: "7-10-new", To: status.StatusBacklog},
+	}, Diff(previous, current), "epic entries and removed stories should not be reported")
+
+	assert.Empty(t, Diff(current, current))
+}
+
+// writeStatus writes the status file at path.
+func writeStatus(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestWatcher_Run(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sprint-status.yaml")
+	writeStatus(t, path, "development_status:\n  7-1-schema: backlog\n  7-2-api: backlog\n")
+	reader := status.NewReader("")
+	reader.SetPath(path)
+
+	w, err := New(path, reader, 50*time.Millisecond)
+	require.NoError(t, err)
+	defer w.Close()
+
+	var (
+		mu      sync.Mutex
+		errs    []error
+		changes = make(chan []Change, 10)
+	)
+	w.SetErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx, func(c []Change) { changes <- c })
+	}()
+
+	// Rapid edits are reported once, with the settled content.
+	writeStatus(t, path, "development_status:\n  7-1-schema: ready-for-dev\n  7-2-api: backlog\n")
+	writeStatus(t, path, "development_status:\n  7-1-schema: ready-for-dev\n  7-2-api: in-progress\n")
+	writeStatus(t, path, "development_status:\n  7-1-schema: ready-for-dev\n  7-2-api: backlog\n")
+	// Other files in the directory are ignored.
+	writeStatus(t, filepath.Join(dir, "notes.md"), "# notes")
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, []Change{{Key: "7-1-schema", From: status.StatusBacklog, To: status.StatusReadyForDev}}, c)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	// Invalid content is reported as an error and keeps the last good read.
+	writeStatus(t, path, "development_status: [unclosed")
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	writeStatus(t, path, "development_status:\n  7-1-schema: ready-for-dev\n  7-2-api: review\n")
+	select {
+	case c := <-changes:
+		assert.Equal(t, []Change{{Key: "7-2-api", From: status.StatusBacklog, To: status.StatusReview}}, c)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	assert.Empty(t, changes, "only settled writes should be reported")
+}
+
+func TestNew_MissingFile(t *testing.T) {
+	reader := status.NewReader(t.TempDir())
+
+	_, err := New(reader.Path(), reader, time.Millisecond)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read sprint status")
+}
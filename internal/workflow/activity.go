@@ -0,0 +1,46 @@
+package workflow
+
+import (
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/output"
+)
+
+// activity collects what Claude did during a step: the files its tools
+// touched, the commands it ran and the last text it wrote. A nil activity
+// collects nothing.
+type activity struct {
+	files     []string
+	seen      map[string]bool
+	commands  []string
+	finalText string
+}
+
+// add records a streaming event.
+func (a *activity) add(event claude.Event) {
+	if a == nil {
+		return
+	}
+	if event.IsText() {
+		a.finalText = event.Text
+	}
+	if !event.IsToolUse() {
+		return
+	}
+	if path := event.ToolFilePath; path != "" && !a.seen[path] {
+		if a.seen == nil {
+			a.seen = make(map[string]bool)
+		}
+		a.seen[path] = true
+		a.files = append(a.files, path)
+	}
+	if event.ToolCommand != "" {
+		a.commands = append(a.commands, event.ToolCommand)
+	}
+}
+
+// apply copies the collected activity to result.
+func (a *activity) apply(result *output.StepResult) {
+	result.Files = a.files
+	result.Commands = a.commands
+	result.FinalText = a.finalText
+}
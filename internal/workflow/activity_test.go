@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/output"
+)
+
+func TestActivity(t *testing.T) {
+	var a activity
+	a.add(claude.Event{Type: claude.EventTypeAssistant, Text: "first"})
+	a.add(claude.Event{Type: claude.EventTypeAssistant, ToolName: "Write", ToolFilePath: "a.go"})
+	a.add(claude.Event{Type: claude.EventTypeAssistant, ToolName: "Bash", ToolCommand: "make"})
+	a.add(claude.Event{Type: claude.EventTypeAssistant, ToolName: "Bash", ToolCommand: "make"})
+	a.add(claude.Event{Type: claude.EventTypeAssistant, ToolName: "Edit", ToolFilePath: "a.go"})
+	a.add(claude.Event{Type: claude.EventTypeUser, ToolStdout: "ok"})
+	a.add(claude.Event{Type: claude.EventTypeAssistant, Text: "last"})
+
+	var result output.StepResult
+	a.apply(&result)
+
+	assert.Equal(t, []string{"a.go"}, result.Files, "files should be listed once")
+	assert.Equal(t, []string{"make", "make"}, result.Commands, "every command should be listed")
+	assert.Equal(t, "last", result.FinalText)
+}
+
+func TestActivity_Nil(t *testing.T) {
+	var a *activity
+	assert.NotPanics(t, func() {
+		a.add(claude.Event{Type: claude.EventTypeAssistant, Text: "ignored"})
+	})
+}
@@ -0,0 +1,118 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
+)
+
+// ErrBudgetExceeded is a sentinel error matched by every [BudgetError].
+//
+// Use errors.Is to tell a step that was stopped by a budget limit apart from
+// an ordinary failure.
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// BudgetError reports which budget limit was crossed.
+type BudgetError struct {
+	// Scope names the budget: a workflow name, or "run" for the run budget.
+	Scope string
+
+	// Used describes the amount used, e.g. "$5.12 spent" or "51 turns".
+	Used string
+
+	// Limit describes the configured limit, e.g. "$5.00" or "50 turns".
+	Limit string
+}
+
+// Error implements the error interface.
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%s budget exceeded: %s (limit %s)", e.Scope, e.Used, e.Limit)
+}
+
+// Unwrap returns [ErrBudgetExceeded] so callers can use errors.Is.
+func (e *BudgetError) Unwrap() error {
+	return ErrBudgetExceeded
+}
+
+// Budget tracks the usage of Claude sessions against a [config.BudgetConfig].
+//
+// The clock for the wall time limit starts when the budget is created. A Budget
+// is safe for concurrent use, so one run budget can be shared by the runners of
+// stories that execute in parallel. All methods treat a nil Budget as unlimited.
+//
+// Use [NewBudget] to create an instance.
+type Budget struct {
+	scope  string
+	limits config.BudgetConfig
+	start  time.Time
+
+	mu    sync.Mutex
+	spent output.Usage
+}
+
+// NewBudget creates a [Budget] for the given limits and starts its clock.
+//
+// The scope names the budget in error messages.
+func NewBudget(scope string, limits config.BudgetConfig) *Budget {
+	return &Budget{
+		scope:  scope,
+		limits: limits,
+		start:  time.Now(),
+	}
+}
+
+// Add records usage against the budget.
+func (b *Budget) Add(u output.Usage) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent = b.spent.Add(u)
+}
+
+// Spent returns the usage recorded so far.
+func (b *Budget) Spent() output.Usage {
+	if b == nil {
+		return output.Usage{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spent
+}
+
+// Check returns a [BudgetError] if the recorded usage plus pending usage that
+// has not been recorded yet reaches a limit, or if the wall time is used up.
+// Cost and turns may reach their limit exactly; only going over is an error.
+func (b *Budget) Check(pending output.Usage) error {
+	if b == nil {
+		return nil
+	}
+	used := b.Spent().Add(pending)
+
+	if max := b.limits.MaxCostUSD; max > 0 && used.CostUSD > max {
+		return &BudgetError{Scope: b.scope, Used: fmt.Sprintf("$%.2f spent", used.CostUSD), Limit: fmt.Sprintf("$%.2f", max)}
+	}
+	if max := b.limits.MaxTurns; max > 0 && used.Turns > max {
+		return &BudgetError{Scope: b.scope, Used: fmt.Sprintf("%d turns", used.Turns), Limit: fmt.Sprintf("%d turns", max)}
+	}
+	if max := b.limits.MaxDuration; max > 0 {
+		if elapsed := time.Since(b.start); elapsed >= max {
+			return &BudgetError{Scope: b.scope, Used: fmt.Sprintf("%s elapsed", elapsed.Round(time.Second)), Limit: max.String()}
+		}
+	}
+	return nil
+}
+
+// Remaining returns the wall time left before the budget's duration limit is
+// reached. ok is false if the budget has no duration limit.
+func (b *Budget) Remaining() (remaining time.Duration, ok bool) {
+	if b == nil || b.limits.MaxDuration <= 0 {
+		return 0, false
+	}
+	return b.limits.MaxDuration - time.Since(b.start), true
+}
@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
+)
+
+func TestBudget_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  config.BudgetConfig
+		spent   output.Usage
+		pending output.Usage
+		wantErr string
+	}{
+		{
+			name:   "unlimited",
+			spent:  output.Usage{CostUSD: 100, Turns: 1000},
+			limits: config.BudgetConfig{},
+		},
+		{
+			name:   "cost at limit is allowed",
+			limits: config.BudgetConfig{MaxCostUSD: 1},
+			spent:  output.Usage{CostUSD: 1},
+		},
+		{
+			name:    "cost over limit",
+			limits:  config.BudgetConfig{MaxCostUSD: 1},
+			spent:   output.Usage{CostUSD: 0.75},
+			pending: output.Usage{CostUSD: 0.5},
+			wantErr: "dev-story budget exceeded: $1.25 spent (limit $1.00)",
+		},
+		{
+			name:    "pending turns over limit",
+			limits:  config.BudgetConfig{MaxTurns: 10},
+			spent:   output.Usage{Turns: 8},
+			pending: output.Usage{Turns: 3},
+			wantErr: "dev-story budget exceeded: 11 turns (limit 10 turns)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBudget("dev-story", tt.limits)
+			b.Add(tt.spent)
+
+			err := b.Check(tt.pending)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+			assert.True(t, errors.Is(err, ErrBudgetExceeded))
+		})
+	}
+}
+
+func TestBudget_Duration(t *testing.T) {
+	b := NewBudget("run", config.BudgetConfig{MaxDuration: time.Hour})
+
+	remaining, ok := b.Remaining()
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(remaining), float64(time.Second))
+	assert.NoError(t, b.Check(output.Usage{}))
+
+	b.start = time.Now().Add(-2 * time.Hour)
+
+	err := b.Check(output.Usage{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "run budget exceeded: 2h0m0s elapsed (limit 1h0m0s)")
+}
+
+func TestBudget_Nil(t *testing.T) {
+	var b *Budget
+
+	b.Add(output.Usage{CostUSD: 1})
+	_, ok := b.Remaining()
+
+	assert.False(t, ok)
+	assert.NoError(t, b.Check(output.Usage{Turns: 1000}))
+	assert.Equal(t, output.Usage{}, b.Spent())
+}
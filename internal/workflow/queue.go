@@ -3,7 +3,6 @@ package workflow
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"bmad-automate/internal/output"
@@ -30,20 +29,27 @@ type StatusReader interface {
 // Use [NewQueueRunner] to create a QueueRunner instance.
 type QueueRunner struct {
 	runner *Runner
+	router *router.Router
 }
 
 // NewQueueRunner creates a new queue runner wrapping the given [Runner].
 //
 // The provided runner is used to execute individual workflows for each story.
+// Stories are routed with [router.Default] unless [QueueRunner.SetRouter] is called.
 func NewQueueRunner(runner *Runner) *QueueRunner {
-	return &QueueRunner{runner: runner}
+	return &QueueRunner{runner: runner, router: router.Default()}
+}
+
+// SetRouter configures the lifecycle state machine used to pick each story's workflow.
+func (q *QueueRunner) SetRouter(r *router.Router) {
+	q.router = r
 }
 
 // RunQueueWithStatus executes the appropriate workflow for each story based on its status.
 //
 // For each story in storyKeys, the method:
 //  1. Looks up the story's current status via statusReader
-//  2. Routes to the appropriate workflow based on status (via router.GetWorkflow)
+//  2. Routes to the appropriate workflow based on status (via [router.Router.GetWorkflow])
 //  3. Executes the workflow using [Runner.RunSingle]
 //
 // Behavior:
@@ -70,7 +76,7 @@ func (q *QueueRunner) RunQueueWithStatus(ctx context.Context, storyKeys []string
 		// Get story status
 		storyStatus, err := statusReader.GetStoryStatus(storyKey)
 		if err != nil {
-			fmt.Printf("  Error: %v\n", err)
+			q.runner.printer.Error("%v", err)
 			result := output.StoryResult{
 				Key:      storyKey,
 				Success:  false,
@@ -83,11 +89,11 @@ func (q *QueueRunner) RunQueueWithStatus(ctx context.Context, storyKeys []string
 		}
 
 		// Route to appropriate workflow
-		workflowName, err := router.GetWorkflow(storyStatus)
+		workflowName, err := q.router.GetWorkflow(storyStatus)
 		if err != nil {
 			if errors.Is(err, router.ErrStoryComplete) {
 				// Done stories are skipped, not failures
-				fmt.Printf("  ↷ Skipped (already done)\n")
+				q.runner.printer.Info("  ↷ Skipped (already done)")
 				result := output.StoryResult{
 					Key:      storyKey,
 					Success:  true,
@@ -95,13 +101,13 @@ func (q *QueueRunner) RunQueueWithStatus(ctx context.Context, storyKeys []string
 					Skipped:  true,
 				}
 				results = append(results, result)
-				fmt.Println() // Add spacing between stories
+				q.runner.printer.Newline() // Add spacing between stories
 				continue
 			}
 			if errors.Is(err, router.ErrUnknownStatus) {
-				fmt.Printf("  Error: unknown status value: %s\n", storyStatus)
+				q.runner.printer.Error("unknown status value: %s", storyStatus)
 			} else {
-				fmt.Printf("  Error: %v\n", err)
+				q.runner.printer.Error("%v", err)
 			}
 			result := output.StoryResult{
 				Key:      storyKey,
@@ -132,7 +138,7 @@ func (q *QueueRunner) RunQueueWithStatus(ctx context.Context, storyKeys []string
 		}
 
 		results = append(results, result)
-		fmt.Println() // Add spacing between stories
+		q.runner.printer.Newline() // Add spacing between stories
 	}
 
 	q.runner.printer.QueueSummary(results, storyKeys, time.Since(queueStart))
@@ -0,0 +1,112 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/runlog"
+)
+
+// Replay renders the attempts recorded in a step log as they looked live.
+//
+// For each attempt, the command header, every recorded event and the footer are
+// printed through the runner's printer. Events are fed through a
+// [claude.DefaultParser], like Claude's output during a live run.
+//
+// The recorded gaps between events are kept, divided by speed: 1 replays in real
+// time, 10 ten times faster, and 0 or less without any delay. An attempt without
+// an end record, for example because the run was killed, is shown as failed with
+// exit code -1. Replay returns ctx.Err() if ctx is canceled.
+func (r *Runner) Replay(ctx context.Context, records []runlog.Record, speed float64) error {
+	var (
+		attempt *replayAttempt
+		last    time.Time
+	)
+
+	for _, rec := range records {
+		if speed > 0 && !last.IsZero() {
+			if err := r.wait(ctx, time.Duration(float64(rec.Time.Sub(last))/speed)); err != nil {
+				attempt.abort(last)
+				return err
+			}
+		}
+		previous := last
+		last = rec.Time
+
+		switch rec.Kind {
+		case runlog.KindStart:
+			attempt.abort(previous)
+			attempt = r.startReplay(rec)
+		case runlog.KindEvent:
+			if attempt != nil {
+				attempt.write(rec.Event)
+			}
+		case runlog.KindEnd:
+			exitCode := -1
+			if rec.ExitCode != nil {
+				exitCode = *rec.ExitCode
+			}
+			attempt.finish(time.Duration(rec.DurationMS)*time.Millisecond, exitCode)
+			attempt = nil
+		}
+	}
+
+	attempt.abort(last)
+	return nil
+}
+
+// replayAttempt streams the recorded events of one attempt to a parser.
+type replayAttempt struct {
+	runner *Runner
+	start  time.Time
+	pipe   *io.PipeWriter
+	done   chan struct{}
+}
+
+// startReplay prints the header of a recorded attempt and starts parsing its events.
+func (r *Runner) startReplay(rec runlog.Record) *replayAttempt {
+	label := fmt.Sprintf("%s: %s", rec.Step, rec.Story)
+	if rec.Attempt > 1 {
+		label = fmt.Sprintf("%s (attempt %d)", label, rec.Attempt)
+	}
+	r.printer.CommandHeader(label, rec.Prompt, r.config.Output.TruncateLength)
+
+	pr, pw := io.Pipe()
+	a := &replayAttempt{runner: r, start: rec.Time, pipe: pw, done: make(chan struct{})}
+	go func() {
+		defer close(a.done)
+		for event := range claude.NewParser().Parse(pr) {
+			r.handleEvent(event)
+		}
+		_, _ = io.Copy(io.Discard, pr) //nolint:errcheck // Unblock the writer if parsing stopped early
+	}()
+	return a
+}
+
+// write passes one recorded event to the parser.
+func (a *replayAttempt) write(event []byte) {
+	_, _ = a.pipe.Write(append(append([]byte{}, event...), '\n')) //nolint:errcheck // Pipe errors end the replay of the attempt
+}
+
+// finish waits for the recorded events to be printed and prints the footer.
+// It does nothing for a nil attempt.
+func (a *replayAttempt) finish(duration time.Duration, exitCode int) {
+	if a == nil {
+		return
+	}
+	_ = a.pipe.Close() //nolint:errcheck // Closing a pipe writer never fails
+	<-a.done
+	a.runner.printer.CommandFooter(duration, exitCode == 0, exitCode)
+}
+
+// abort finishes an attempt that has no end record as failed, with the time
+// of its last record as the end.
+func (a *replayAttempt) abort(last time.Time) {
+	if a == nil {
+		return
+	}
+	a.finish(last.Sub(a.start), -1)
+}
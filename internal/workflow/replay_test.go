@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
+)
+
+func replayRecords(t *testing.T) []runlog.Record {
+	t.Helper()
+	start := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	exitCode := 0
+	return []runlog.Record{
+		{Time: start, Kind: runlog.KindStart, Story: "7-1-schema", Step: "code-review", Attempt: 1, Prompt: "Review story: 7-1-schema"},
+		{Time: start.Add(time.Second), Kind: runlog.KindEvent, Event: json.RawMessage(`{"type":"system","subtype":"init"}`)},
+		{Time: start.Add(3 * time.Second), Kind: runlog.KindEvent, Event: json.RawMessage(`{"type":"assistant","message":{"content":[{"type":"text","text":"Found 2 issues"}]}}`)},
+		{Time: start.Add(4 * time.Second), Kind: runlog.KindEnd, ExitCode: &exitCode, DurationMS: 4000},
+		{Time: start.Add(5 * time.Second), Kind: runlog.KindStart, Story: "7-1-schema", Step: "code-review", Attempt: 2, Prompt: "Review story: 7-1-schema"},
+		{Time: start.Add(9 * time.Second), Kind: runlog.KindEvent, Event: json.RawMessage(`{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}`)},
+	}
+}
+
+func TestRunner_Replay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	runner := NewRunner(nil, output.NewPrinterWithWriter(buf), config.DefaultConfig())
+	var waits []time.Duration
+	runner.wait = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	err := runner.Replay(context.Background(), replayRecords(t), 2)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "code-review: 7-1-schema")
+	assert.Contains(t, out, "code-review: 7-1-schema (attempt 2)")
+	assert.Contains(t, out, "Found 2 issues")
+	assert.Contains(t, out, "go test ./...")
+	assert.Contains(t, out, "Exit code: -1", "an attempt without end record should be shown as failed")
+	assert.Equal(t, []time.Duration{
+		500 * time.Millisecond, time.Second, 500 * time.Millisecond, 500 * time.Millisecond, 2 * time.Second,
+	}, waits, "recorded gaps should be divided by the speed")
+}
+
+func TestRunner_Replay_NoDelay(t *testing.T) {
+	runner := NewRunner(nil, output.NewPrinterWithWriter(&bytes.Buffer{}), config.DefaultConfig())
+	runner.wait = func(ctx context.Context, d time.Duration) error {
+		t.Fatal("speed 0 should not wait")
+		return nil
+	}
+
+	require.NoError(t, runner.Replay(context.Background(), replayRecords(t), 0))
+}
+
+func TestRunner_Replay_Canceled(t *testing.T) {
+	buf := &bytes.Buffer{}
+	runner := NewRunner(nil, output.NewPrinterWithWriter(buf), config.DefaultConfig())
+	runner.wait = sleepContext
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := runner.Replay(ctx, replayRecords(t), 1)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, buf.String(), "Found 2 issues")
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package workflow
+
+import (
+	"context"
+	"os/exec"
+)
+
+// shellCommand returns the command that runs a verification command with
+// "cmd /C".
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	return exec.CommandContext(ctx, "cmd", "/C", command)
+}
@@ -0,0 +1,137 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/config"
+	"bmad-automate/internal/output"
+)
+
+// maxFixOutput is how many bytes of a failed verification command's output
+// are passed to the fix prompt. The end is kept, as that is where test
+// runners and compilers summarize failures.
+const maxFixOutput = 16 * 1024
+
+// verifyWaitDelay is how long a verification command's output is waited for
+// after it was stopped, in case processes it started keep the output open.
+const verifyWaitDelay = 5 * time.Second
+
+// verifyFailure describes the verification command that failed.
+type verifyFailure struct {
+	command  string
+	exitCode int
+	output   string
+}
+
+// reason returns the explanation stored in [output.StepResult.Reason].
+func (f *verifyFailure) reason() string {
+	return fmt.Sprintf("verification failed: %s exited with %d", f.command, f.exitCode)
+}
+
+// verifyStep runs the workflow's verification commands after Claude succeeded
+// in the step in result.
+//
+// While a command fails and fix attempts are left, Claude is asked to fix the
+// failure in the step's session and the commands run again. If verification
+// still fails, the step fails with the exit code of the failed command.
+func (r *Runner) verifyStep(ctx context.Context, result *output.StepResult, wf config.WorkflowConfig, storyKey string, budgets []*Budget, done *activity) {
+	for fix := 0; ; fix++ {
+		failure := r.verify(ctx, wf.Verify)
+		if failure == nil {
+			return
+		}
+		result.ExitCode = failure.exitCode
+		result.Reason = failure.reason()
+		if fix >= wf.Verify.FixAttempts || stopped(ctx) {
+			r.printer.StepAborted(result.Name, result.Reason)
+			return
+		}
+
+		if err := checkBudgets(budgets, output.Usage{}); err != nil {
+			r.stopStep(result, err)
+			return
+		}
+
+		prompt, err := r.config.GetFixPrompt(result.Name, storyKey, failure.command, failure.output)
+		if err != nil {
+			r.printer.Error("%v", err)
+			r.printer.StepAborted(result.Name, result.Reason)
+			return
+		}
+
+		fixCtx := ctx
+		if result.SessionID != "" {
+			fixCtx = claude.WithResumeSession(ctx, result.SessionID)
+		}
+		if _, budgetErr := r.runAttempt(fixCtx, result, wf, storyKey, prompt, budgets, done); budgetErr != nil {
+			r.stopStep(result, budgetErr)
+			return
+		}
+		if result.ExitCode != 0 {
+			return
+		}
+	}
+}
+
+// verify runs the verification commands in order in the runner's work
+// directory and returns the first that failed, or nil if all passed.
+func (r *Runner) verify(ctx context.Context, v config.VerifyConfig) *verifyFailure {
+	for _, command := range v.Commands {
+		cmdCtx, cancel := ctx, context.CancelFunc(func() {})
+		if v.Timeout > 0 {
+			cmdCtx, cancel = context.WithTimeout(ctx, v.Timeout)
+		}
+
+		start := time.Now()
+		cmd := shellCommand(cmdCtx, command)
+		cmd.Dir = r.workDir
+		cmd.WaitDelay = verifyWaitDelay
+		out, err := cmd.CombinedOutput()
+		timedOut := errors.Is(cmdCtx.Err(), context.DeadlineExceeded)
+		cancel()
+
+		exitCode := 0
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+		case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+			exitCode = exitErr.ExitCode()
+		case exitErr != nil:
+			// Stopped by a signal.
+			exitCode = 1
+		default:
+			// The shell could not be started.
+			exitCode = 1
+			out = append(out, err.Error()...)
+		}
+		if timedOut {
+			exitCode = ExitCodeTimeout
+			out = append(out, fmt.Sprintf("\ntimed out after %s", v.Timeout)...)
+		}
+
+		r.printer.VerifyResult(command, exitCode, time.Since(start), string(out), r.config.Output.TruncateLines)
+		if exitCode != 0 {
+			return &verifyFailure{command: command, exitCode: exitCode, output: tail(string(out), maxFixOutput)}
+		}
+	}
+	return nil
+}
+
+// tail returns the last n bytes of s, starting at a line boundary if there is
+// one, marking that the beginning was cut.
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[len(s)-n:]
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:]
+	}
+	return "... (output truncated)\n" + s
+}
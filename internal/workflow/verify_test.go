@@ -0,0 +1,159 @@
+//go:build !windows
+
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmad-automate/internal/claude"
+	"bmad-automate/internal/config"
+)
+
+// setVerify sets the verification settings of the dev-story workflow.
+func setVerify(runner *Runner, v config.VerifyConfig) {
+	wf := runner.config.Workflows["dev-story"]
+	wf.Verify = v
+	runner.config.Workflows["dev-story"] = wf
+}
+
+func TestRunner_RunStep_Verify(t *testing.T) {
+	tests := []struct {
+		name         string
+		verify       config.VerifyConfig
+		wantExitCode int
+		wantReason   string
+		wantPrompts  int
+		wantOutput   []string
+		wantNot      []string
+	}{
+		{
+			name:        "all commands pass",
+			verify:      config.VerifyConfig{Commands: []string{"echo building", "exit 0"}},
+			wantPrompts: 1,
+			wantOutput:  []string{"Verified: echo building", "Verified: exit 0"},
+		},
+		{
+			name:         "first failure stops verification",
+			verify:       config.VerifyConfig{Commands: []string{"exit 0", "echo broken; exit 3", "echo never"}},
+			wantExitCode: 3,
+			wantReason:   "verification failed: echo broken; exit 3 exited with 3",
+			wantPrompts:  1,
+			wantOutput:   []string{"Verification failed: echo broken; exit 3 exited with 3", "   broken"},
+			wantNot:      []string{"echo never"},
+		},
+		{
+			name:         "fix attempts are used up",
+			verify:       config.VerifyConfig{Commands: []string{"echo broken; exit 3"}, FixAttempts: 2},
+			wantExitCode: 3,
+			wantReason:   "verification failed: echo broken; exit 3 exited with 3",
+			wantPrompts:  3,
+		},
+		{
+			name:         "command times out",
+			verify:       config.VerifyConfig{Commands: []string{"sleep 5"}, Timeout: 50 * time.Millisecond},
+			wantExitCode: ExitCodeTimeout,
+			wantReason:   "verification failed: sleep 5 exited with 124",
+			wantPrompts:  1,
+			wantOutput:   []string{"timed out after 50ms"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, mockExecutor, buf := setupTestRunner()
+			setVerify(runner, tt.verify)
+
+			result := runner.RunStep(context.Background(), "dev-story", "7-1-schema")
+
+			assert.Equal(t, tt.wantExitCode, result.ExitCode)
+			assert.Equal(t, tt.wantExitCode == 0, result.Success)
+			assert.Equal(t, tt.wantReason, result.Reason)
+			assert.Len(t, mockExecutor.RecordedPrompts, tt.wantPrompts)
+			assert.Len(t, result.Attempts, tt.wantPrompts, "fix runs are attempts of the step")
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, buf.String(), want)
+			}
+			for _, unwanted := range tt.wantNot {
+				assert.NotContains(t, buf.String(), unwanted)
+			}
+		})
+	}
+}
+
+func TestRunner_RunStep_VerifyFixed(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, SessionStarted: true, SessionID: "session-1"},
+		{Type: claude.EventTypeResult, SessionComplete: true, SessionID: "session-1"},
+	}
+	dir := t.TempDir()
+	runner.SetWorkDir(dir)
+	// Fails on the first run and passes once "fixed".
+	setVerify(runner, config.VerifyConfig{
+		Commands:    []string{"if [ -f fixed ]; then exit 0; fi; touch fixed; echo '--- FAIL: TestSchema'; exit 1"},
+		FixAttempts: 2,
+	})
+
+	result := runner.RunStep(context.Background(), "dev-story", "7-1-schema")
+
+	assert.True(t, result.Success)
+	assert.Empty(t, result.Reason)
+	assert.FileExists(t, filepath.Join(dir, "fixed"), "commands run in the work directory")
+	require.Len(t, mockExecutor.RecordedPrompts, 2)
+	assert.Contains(t, mockExecutor.RecordedPrompts[1], "failed after your work on story 7-1-schema")
+	assert.Contains(t, mockExecutor.RecordedPrompts[1], "--- FAIL: TestSchema")
+	assert.Equal(t, []string{"", "session-1"}, mockExecutor.RecordedSessions, "the fix continues the step's session")
+	assert.Equal(t, 1, result.Retries())
+}
+
+func TestRunner_RunStep_VerifyFixFails(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.ExitCodes = []int{0, 2}
+	setVerify(runner, config.VerifyConfig{Commands: []string{"exit 1"}, FixAttempts: 3})
+
+	result := runner.RunStep(context.Background(), "dev-story", "7-1-schema")
+
+	assert.False(t, result.Success)
+	assert.Equal(t, 2, result.ExitCode, "a failed fix run fails the step")
+	assert.Len(t, mockExecutor.RecordedPrompts, 2)
+}
+
+func TestRunner_RunStep_VerifySkippedOnFailure(t *testing.T) {
+	runner, mockExecutor, buf := setupTestRunner()
+	mockExecutor.ExitCode = 1
+	setVerify(runner, config.VerifyConfig{Commands: []string{"exit 0"}, FixAttempts: 1})
+
+	result := runner.RunStep(context.Background(), "dev-story", "7-1-schema")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Len(t, mockExecutor.RecordedPrompts, 1)
+	assert.NotContains(t, buf.String(), "Verified")
+}
+
+func TestRunner_RunStep_VerifyWorkDir(t *testing.T) {
+	runner, _, _ := setupTestRunner()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example\n"), 0644))
+	runner.SetWorkDir(dir)
+	setVerify(runner, config.VerifyConfig{Commands: []string{"test -f go.mod"}})
+
+	result := runner.RunStep(context.Background(), "dev-story", "7-1-schema")
+
+	assert.True(t, result.Success)
+}
+
+func TestTail(t *testing.T) {
+	assert.Equal(t, "short", tail("short", 10))
+
+	long := strings.Repeat("noise\n", 10) + "FAIL\n"
+	got := tail(long, 12)
+	assert.Equal(t, "... (output truncated)\nnoise\nFAIL\n", got)
+}
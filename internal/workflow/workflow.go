@@ -2,25 +2,45 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"bmad-automate/internal/claude"
 	"bmad-automate/internal/config"
+	"bmad-automate/internal/lifecycle"
 	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
 )
 
+// ExitCodeTimeout is the exit code reported for a workflow run that was stopped
+// because it exceeded its timeout. It matches the exit code of timeout(1).
+const ExitCodeTimeout = 124
+
+// ErrTimeout is the context cause used when a workflow run exceeds its timeout.
+var ErrTimeout = errors.New("workflow timed out")
+
 // Runner orchestrates workflow execution using Claude CLI.
 //
 // Runner is the primary executor for development workflows. It combines a
 // [claude.Executor] for spawning Claude processes, an [output.Printer] for
 // formatted terminal output, and a [config.Config] for prompt templates.
+// Every session counts against the run [Budget] and the step's workflow budget,
+// and the sessions of workflow steps are recorded to the [runlog.Log], if set.
+// Workflows with verification commands only succeed once the commands pass.
 //
 // Use [NewRunner] to create a properly initialized Runner instance.
 type Runner struct {
 	executor claude.Executor
 	printer  output.Printer
 	config   *config.Config
+	budget   *Budget
+	runLog   *runlog.Log
+	workDir  string
+
+	// wait blocks for the retry backoff delay. Tests replace it to avoid sleeping.
+	wait func(ctx context.Context, d time.Duration) error
 }
 
 // NewRunner creates a new workflow runner with the specified dependencies.
@@ -31,31 +51,214 @@ type Runner struct {
 //   - cfg: The configuration containing workflow prompt templates
 //
 // The executor typically uses [claude.NewExecutor] in production or
-// [claude.MockExecutor] for testing.
+// [claude.MockExecutor] for testing. The run budget is created from cfg.Budget
+// and its clock starts now; use [Runner.SetBudget] to share it between runners.
 func NewRunner(executor claude.Executor, printer output.Printer, cfg *config.Config) *Runner {
 	return &Runner{
 		executor: executor,
 		printer:  printer,
 		config:   cfg,
+		budget:   NewBudget("run", cfg.Budget),
+		wait:     sleepContext,
 	}
 }
 
+// SetBudget replaces the run budget.
+//
+// Parallel runs create one runner per story; passing the same [Budget] to each
+// of them makes the run limits apply to all stories together.
+func (r *Runner) SetBudget(b *Budget) {
+	r.budget = b
+}
+
+// SetPrinter replaces the printer, for example to switch to
+// [output.JSONPrinter] once the output format is known.
+func (r *Runner) SetPrinter(p output.Printer) {
+	r.printer = p
+}
+
+// SetRunLog sets the log to which the Claude events of every workflow step are
+// recorded. A nil log disables recording, which is the default.
+func (r *Runner) SetRunLog(l *runlog.Log) {
+	r.runLog = l
+}
+
+// SetWorkDir sets the directory in which verification commands run. It must
+// be the directory Claude works in. Empty, the default, is the working
+// directory of the process.
+func (r *Runner) SetWorkDir(dir string) {
+	r.workDir = dir
+}
+
 // RunSingle executes a single named workflow for a story.
 //
 // The workflowName must match a workflow defined in the configuration (e.g.,
 // "analyze", "implement", "test"). The storyKey is substituted into the
-// workflow's prompt template.
+// workflow's prompt template. Failed runs are retried according to the
+// workflow's retry policy; see [Runner.RunStep].
 //
 // Returns the exit code from Claude CLI (0 for success, non-zero for failure).
 func (r *Runner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
+	return r.RunStep(ctx, workflowName, storyKey).ExitCode
+}
+
+// RunStep executes a single named workflow for a story and reports every attempt.
+//
+// If the workflow fails and its [config.RetryConfig] allows it, RunStep waits for
+// the backoff delay and runs the workflow again, until it succeeds, the failure is
+// not retryable, the attempts are used up, ctx is canceled, or a stop is requested
+// with [lifecycle.WithStopRequest]. Claude's stderr is captured per attempt to
+// match the policy's stderr patterns.
+//
+// Each run is limited by the workflow's timeout, if set. A run that times out is
+// stopped through its context, which terminates Claude's process tree, and is
+// recorded with [ExitCodeTimeout]; whether it is retried is up to the retry
+// policy. If the final attempt timed out, [output.StepResult.Reason] says so.
+//
+// Each step has its own budget from the workflow's [config.BudgetConfig], which
+// covers all of its attempts, and also counts against the run budget. When a
+// limit is crossed, Claude is stopped through its context and the step fails
+// without further retries; the [BudgetError] message is stored in
+// [output.StepResult.Reason].
+//
+// If the workflow has verification commands, they run once Claude succeeded;
+// see [config.VerifyConfig]. A failed verification fails the step with the
+// command's exit code and explains it in [output.StepResult.Reason], unless a
+// fix attempt makes it pass. Fix attempts resume the step's Claude session and
+// are reported as further attempts.
+//
+// Every attempt is recorded to the run log, if set. Failing to write the log
+// prints a warning but does not fail the step.
+//
+// The returned [output.StepResult] holds the final exit code, one
+// [output.Attempt] per run, and the files, commands and final text of Claude's
+// work across all runs.
+func (r *Runner) RunStep(ctx context.Context, workflowName, storyKey string) output.StepResult {
+	result := output.StepResult{Name: workflowName}
+	start := time.Now()
+
 	prompt, err := r.config.GetPrompt(workflowName, storyKey)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return 1
+		r.printer.Error("%v", err)
+		result.ExitCode = 1
+		return result
 	}
 
-	label := fmt.Sprintf("%s: %s", workflowName, storyKey)
-	return r.runClaude(ctx, prompt, label)
+	wf := r.config.Workflows[workflowName]
+	policy := wf.Retry
+	budgets := []*Budget{NewBudget(workflowName, wf.Budget), r.budget}
+
+	var done activity
+	for attempt := 1; ; attempt++ {
+		if err := checkBudgets(budgets, output.Usage{}); err != nil {
+			r.stopStep(&result, err)
+			break
+		}
+
+		attemptStderr, budgetErr := r.runAttempt(ctx, &result, wf, storyKey, prompt, budgets, &done)
+		if budgetErr != nil {
+			r.stopStep(&result, budgetErr)
+			break
+		}
+
+		exitCode := result.ExitCode
+		if exitCode == 0 || attempt >= policy.Attempts() || stopped(ctx) {
+			break
+		}
+
+		if !policy.IsRetryable(exitCode, attemptStderr) {
+			break
+		}
+
+		delay := policy.Delay(attempt)
+		r.printer.StepRetry(workflowName, attempt, policy.Attempts(), exitCode, delay)
+		if err := r.wait(ctx, delay); err != nil {
+			break
+		}
+	}
+
+	if result.ExitCode == 0 && len(wf.Verify.Commands) > 0 {
+		r.verifyStep(ctx, &result, wf, storyKey, budgets, &done)
+	}
+
+	done.apply(&result)
+	result.Duration = time.Since(start)
+	result.Success = result.ExitCode == 0
+	return result
+}
+
+// runAttempt runs Claude once with prompt as the next attempt of the step in
+// result, limited by the workflow's timeout, records the attempt to the run log
+// and adds it to result. It returns the lines Claude wrote to stderr and the
+// [BudgetError] for the first budget limit crossed, or nil.
+func (r *Runner) runAttempt(ctx context.Context, result *output.StepResult, wf config.WorkflowConfig, storyKey, prompt string, budgets []*Budget, done *activity) ([]string, error) {
+	attempt := len(result.Attempts) + 1
+	label := fmt.Sprintf("%s: %s", result.Name, storyKey)
+
+	var (
+		mu     sync.Mutex
+		stderr []string
+	)
+	attemptCtx := claude.WithStderrHandler(ctx, func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		stderr = append(stderr, line)
+	})
+
+	cancelTimeout := context.CancelFunc(func() {})
+	if wf.Timeout > 0 {
+		attemptCtx, cancelTimeout = context.WithTimeoutCause(attemptCtx, wf.Timeout, ErrTimeout)
+	}
+
+	record, err := r.runLog.StartStep(storyKey, result.Name, attempt, prompt)
+	if err != nil {
+		r.printer.Warning("%v", err)
+	}
+
+	attemptStart := time.Now()
+	s, budgetErr := r.runClaude(attemptCtx, prompt, label, budgets, record, done)
+	exitCode, usage, sessionID := s.exitCode, s.usage, s.id
+	timedOut := errors.Is(context.Cause(attemptCtx), ErrTimeout)
+	cancelTimeout()
+
+	result.Reason = s.reason
+	if timedOut && budgetErr == nil {
+		exitCode = ExitCodeTimeout
+		result.Reason = fmt.Sprintf("timed out after %s", wf.Timeout)
+		r.printer.StepAborted(result.Name, result.Reason)
+	}
+
+	reason := result.Reason
+	if budgetErr != nil {
+		reason = budgetErr.Error()
+	}
+	if err := record.End(exitCode, usage, sessionID, reason); err != nil {
+		r.printer.Warning("failed to write run log: %v", err)
+	}
+
+	mu.Lock()
+	attemptStderr := append([]string(nil), stderr...)
+	mu.Unlock()
+	result.Attempts = append(result.Attempts, output.Attempt{
+		ExitCode:  exitCode,
+		Duration:  time.Since(attemptStart),
+		Usage:     usage,
+		SessionID: sessionID,
+		Stderr:    attemptStderr,
+	})
+	result.ExitCode = exitCode
+	result.Usage = result.Usage.Add(usage)
+	if sessionID != "" {
+		result.SessionID = sessionID
+	}
+	return attemptStderr, budgetErr
+}
+
+// ResumeStep executes a workflow like [Runner.RunStep], but continues the Claude
+// session identified by sessionID instead of starting a new one. The workflow's
+// prompt is sent as the next message in the session.
+func (r *Runner) ResumeStep(ctx context.Context, workflowName, storyKey, sessionID string) output.StepResult {
+	return r.RunStep(claude.WithResumeSession(ctx, sessionID), workflowName, storyKey)
 }
 
 // RunRaw executes an arbitrary prompt without template expansion.
@@ -65,7 +268,15 @@ func (r *Runner) RunSingle(ctx context.Context, workflowName, storyKey string) i
 //
 // Returns the exit code from Claude CLI (0 for success, non-zero for failure).
 func (r *Runner) RunRaw(ctx context.Context, prompt string) int {
-	return r.runClaude(ctx, prompt, "raw")
+	s, budgetErr := r.runClaude(ctx, prompt, "raw", []*Budget{r.budget}, nil, nil)
+	exitCode := s.exitCode
+	if budgetErr != nil {
+		r.printer.StepAborted("raw", budgetErr.Error())
+		if exitCode == 0 {
+			exitCode = 1
+		}
+	}
+	return exitCode
 }
 
 // RunFullCycle executes all configured steps in sequence for a story.
@@ -91,7 +302,7 @@ func (r *Runner) RunFullCycle(ctx context.Context, storyKey string) int {
 	for _, name := range stepNames {
 		prompt, err := r.config.GetPrompt(name, storyKey)
 		if err != nil {
-			fmt.Printf("Error building step %s: %v\n", name, err)
+			r.printer.Error("building step %s: %v", name, err)
 			return 1
 		}
 		steps = append(steps, Step{Name: name, Prompt: prompt})
@@ -105,21 +316,28 @@ func (r *Runner) RunFullCycle(ctx context.Context, storyKey string) int {
 		r.printer.StepStart(i+1, len(steps), step.Name)
 
 		stepStart := time.Now()
-		exitCode := r.runClaude(ctx, step.Prompt, fmt.Sprintf("%s: %s", step.Name, storyKey))
+		s, budgetErr := r.runClaude(ctx, step.Prompt, fmt.Sprintf("%s: %s", step.Name, storyKey), []*Budget{r.budget}, nil, nil)
 		duration := time.Since(stepStart)
 
 		results[i] = output.StepResult{
 			Name:     step.Name,
 			Duration: duration,
-			Success:  exitCode == 0,
+			ExitCode: s.exitCode,
+			Usage:    s.usage,
+			Reason:   s.reason,
 		}
+		if budgetErr != nil {
+			r.stopStep(&results[i], budgetErr)
+		}
+		exitCode := results[i].ExitCode
+		results[i].Success = exitCode == 0
 
 		if exitCode != 0 {
-			r.printer.CycleFailed(storyKey, step.Name, time.Since(totalStart))
+			r.printer.CycleFailed(storyKey, step.Name, results[:i+1], time.Since(totalStart))
 			return exitCode
 		}
 
-		fmt.Println() // Add spacing between steps
+		r.printer.Newline() // Add spacing between steps
 	}
 
 	r.printer.CycleSummary(storyKey, results, time.Since(totalStart))
@@ -130,26 +348,137 @@ func (r *Runner) RunFullCycle(ctx context.Context, storyKey string) int {
 //
 // This is the core execution method used by all public Runner methods.
 // It displays a command header, streams events to the printer via handleEvent,
-// and displays a footer with timing and exit status.
-func (r *Runner) runClaude(ctx context.Context, prompt, label string) int {
+// and displays a footer with timing and exit status. It returns the outcome of
+// the session; a session whose result event reports an error fails with exit
+// code 1 even if Claude exited with 0. Every event is also written to record and
+// collected in done, both of which may be nil.
+//
+// The session is recorded against each of the budgets. Claude is stopped through
+// its context as soon as a turn or wall time limit is crossed; the cost limit is
+// checked once Claude reports the cost. The returned error is the [BudgetError]
+// for the first limit crossed, or nil.
+func (r *Runner) runClaude(ctx context.Context, prompt, label string, budgets []*Budget, record *runlog.Step, done *activity) (session, error) {
 	r.printer.CommandHeader(label, prompt, r.config.Output.TruncateLength)
 
 	startTime := time.Now()
 
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	for _, b := range budgets {
+		if remaining, ok := b.Remaining(); ok {
+			timer := time.AfterFunc(remaining, func() {
+				cancel(b.Check(output.Usage{}))
+			})
+			defer timer.Stop()
+		}
+	}
+
+	var (
+		usage     output.Usage
+		sessionID string
+		isError   bool
+	)
+	// Claude emits an assistant event per content block; count each message
+	// once. Events without a message ID count as a turn each.
+	turns := 0
+	messages := make(map[string]bool)
 	handler := func(event claude.Event) {
+		record.Event(event)
+		done.add(event)
+		if event.SessionID != "" {
+			sessionID = event.SessionID
+		}
+		switch {
+		case event.SessionComplete:
+			usage = usage.Add(usageFromEvent(event))
+			isError = isError || event.IsError
+		case event.Type == claude.EventTypeAssistant:
+			if event.MessageID != "" {
+				if messages[event.MessageID] {
+					break
+				}
+				messages[event.MessageID] = true
+			}
+			turns++
+			if err := checkBudgets(budgets, output.Usage{Turns: turns}); err != nil {
+				cancel(err)
+			}
+		}
 		r.handleEvent(event)
 	}
 
 	exitCode, err := r.executor.ExecuteWithResult(ctx, prompt, handler)
 	if err != nil {
-		fmt.Printf("Error executing claude: %v\n", err)
+		r.printer.Error("executing claude: %v", err)
+		exitCode = 1
+	}
+	var reason string
+	if isError && exitCode == 0 {
+		reason = "claude reported an error"
+		r.printer.Error("%s", reason)
 		exitCode = 1
 	}
 
 	duration := time.Since(startTime)
 	r.printer.CommandFooter(duration, exitCode == 0, exitCode)
 
-	return exitCode
+	// A stopped session never reports its usage; count the turns seen instead.
+	if usage.Turns == 0 {
+		usage.Turns = turns
+	}
+	for _, b := range budgets {
+		b.Add(usage)
+	}
+
+	s := session{exitCode: exitCode, usage: usage, id: sessionID, reason: reason}
+	if cause := context.Cause(ctx); errors.Is(cause, ErrBudgetExceeded) {
+		return s, cause
+	}
+	return s, checkBudgets(budgets, output.Usage{})
+}
+
+// session is the outcome of one Claude session run by [Runner.runClaude].
+type session struct {
+	exitCode int
+	usage    output.Usage
+	id       string
+	// reason explains a failure that the exit code of Claude does not, or is
+	// empty.
+	reason string
+}
+
+// checkBudgets returns the error for the first budget that pending usage
+// would exceed, or nil.
+func checkBudgets(budgets []*Budget, pending output.Usage) error {
+	for _, b := range budgets {
+		if err := b.Check(pending); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopStep marks a step as failed because a budget limit was exceeded.
+func (r *Runner) stopStep(result *output.StepResult, err error) {
+	result.Reason = err.Error()
+	if result.ExitCode == 0 {
+		result.ExitCode = 1
+	}
+	r.printer.StepAborted(result.Name, result.Reason)
+}
+
+// usageFromEvent converts the usage reported in a result event.
+func usageFromEvent(event claude.Event) output.Usage {
+	return output.Usage{
+		CostUSD:             event.CostUSD,
+		InputTokens:         event.Usage.InputTokens,
+		OutputTokens:        event.Usage.OutputTokens,
+		CacheCreationTokens: event.Usage.CacheCreationInputTokens,
+		CacheReadTokens:     event.Usage.CacheReadInputTokens,
+		DurationMS:          event.Duration.Milliseconds(),
+		Turns:               event.NumTurns,
+	}
 }
 
 // handleEvent routes a Claude streaming event to the appropriate printer method.
@@ -175,3 +504,24 @@ func (r *Runner) handleEvent(event claude.Event) {
 		r.printer.SessionEnd(0, true) // Duration handled elsewhere
 	}
 }
+
+// sleepContext waits for d, until ctx is canceled or until a stop is requested,
+// whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-lifecycle.StopRequest(ctx):
+		return lifecycle.ErrInterrupted
+	case <-timer.C:
+		return nil
+	}
+}
+
+// stopped reports whether a step should not start another run of Claude.
+func stopped(ctx context.Context) bool {
+	return ctx.Err() != nil || lifecycle.StopRequested(ctx)
+}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"bmad-automate/internal/claude"
 	"bmad-automate/internal/config"
+	"bmad-automate/internal/lifecycle"
 	"bmad-automate/internal/output"
+	"bmad-automate/internal/runlog"
 )
 
 func setupTestRunner() (*Runner, *claude.MockExecutor, *bytes.Buffer) {
@@ -59,6 +62,462 @@ func TestRunner_RunSingle_UnknownWorkflow(t *testing.T) {
 	assert.Equal(t, 1, exitCode)
 }
 
+func TestRunner_RunStep_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		retry        config.RetryConfig
+		exitCodes    []int
+		stderr       []string
+		wantExitCode int
+		wantAttempts []int
+		wantDelays   []time.Duration
+	}{
+		{
+			name:         "no retry policy runs once",
+			exitCodes:    []int{1, 0},
+			wantExitCode: 1,
+			wantAttempts: []int{1},
+		},
+		{
+			name:         "retries until success with exponential backoff",
+			retry:        config.RetryConfig{MaxAttempts: 4, InitialDelay: time.Second, Multiplier: 3},
+			exitCodes:    []int{1, 1, 0},
+			wantExitCode: 0,
+			wantAttempts: []int{1, 1, 0},
+			wantDelays:   []time.Duration{time.Second, 3 * time.Second},
+		},
+		{
+			name:         "stops after max attempts",
+			retry:        config.RetryConfig{MaxAttempts: 2, InitialDelay: time.Second},
+			exitCodes:    []int{1},
+			wantExitCode: 1,
+			wantAttempts: []int{1, 1},
+			wantDelays:   []time.Duration{time.Second},
+		},
+		{
+			name:         "exit code not listed is not retried",
+			retry:        config.RetryConfig{MaxAttempts: 3, ExitCodes: []int{75}},
+			exitCodes:    []int{1, 0},
+			wantExitCode: 1,
+			wantAttempts: []int{1},
+		},
+		{
+			name:         "listed exit code is retried",
+			retry:        config.RetryConfig{MaxAttempts: 3, InitialDelay: time.Second, ExitCodes: []int{75}},
+			exitCodes:    []int{75, 0},
+			wantExitCode: 0,
+			wantAttempts: []int{75, 0},
+			wantDelays:   []time.Duration{time.Second},
+		},
+		{
+			name:         "stderr pattern makes failure retryable",
+			retry:        config.RetryConfig{MaxAttempts: 3, InitialDelay: time.Second, StderrPatterns: []string{"(?i)rate limit"}},
+			exitCodes:    []int{1, 0},
+			stderr:       []string{"Error: Rate limit reached"},
+			wantExitCode: 0,
+			wantAttempts: []int{1, 0},
+			wantDelays:   []time.Duration{time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			cfg := config.DefaultConfig()
+			wf := cfg.Workflows["dev-story"]
+			wf.Retry = tt.retry
+			cfg.Workflows["dev-story"] = wf
+
+			mockExecutor := &claude.MockExecutor{ExitCodes: tt.exitCodes, Stderr: tt.stderr}
+			runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(buf), cfg)
+
+			var delays []time.Duration
+			runner.wait = func(ctx context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+			assert.Equal(t, "dev-story", result.Name)
+			assert.Equal(t, tt.wantExitCode, result.ExitCode)
+			assert.Equal(t, tt.wantExitCode == 0, result.Success)
+			var gotAttempts []int
+			for _, a := range result.Attempts {
+				gotAttempts = append(gotAttempts, a.ExitCode)
+			}
+			assert.Equal(t, tt.wantAttempts, gotAttempts)
+			assert.Equal(t, tt.wantDelays, delays)
+			assert.Len(t, mockExecutor.RecordedPrompts, len(tt.wantAttempts))
+			for _, a := range result.Attempts {
+				assert.Equal(t, tt.stderr, a.Stderr, "each attempt should keep its stderr")
+			}
+			if len(tt.wantDelays) > 0 {
+				assert.Contains(t, buf.String(), "retrying in")
+			}
+		})
+	}
+}
+
+func TestRunner_RunStep_Usage(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Retry = config.RetryConfig{MaxAttempts: 2}
+	cfg.Workflows["dev-story"] = wf
+
+	mockExecutor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeAssistant, Text: "Working on it..."},
+			{
+				Type:            claude.EventTypeResult,
+				SessionComplete: true,
+				CostUSD:         0.25,
+				NumTurns:        3,
+				Duration:        1500 * time.Millisecond,
+				Usage:           claude.Usage{InputTokens: 100, OutputTokens: 50, CacheCreationInputTokens: 10, CacheReadInputTokens: 1000},
+			},
+		},
+		ExitCodes: []int{1, 0},
+	}
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+	runner.wait = func(ctx context.Context, d time.Duration) error { return nil }
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	require.Len(t, result.Attempts, 2)
+	want := output.Usage{CostUSD: 0.25, InputTokens: 100, OutputTokens: 50, CacheCreationTokens: 10, CacheReadTokens: 1000, Turns: 3, DurationMS: 1500}
+	assert.Equal(t, want, result.Attempts[0].Usage)
+	assert.Equal(t, want, result.Attempts[1].Usage)
+	assert.Equal(t, want.Add(want), result.Usage, "step usage should include every attempt")
+}
+
+func TestRunner_RunStep_Activity(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeAssistant, Text: "Reading the story..."},
+		{Type: claude.EventTypeAssistant, ToolName: "Read", ToolFilePath: "story.md"},
+		{Type: claude.EventTypeAssistant, ToolName: "Edit", ToolFilePath: "main.go"},
+		{Type: claude.EventTypeAssistant, ToolName: "Edit", ToolFilePath: "story.md"},
+		{Type: claude.EventTypeAssistant, ToolName: "Bash", ToolCommand: "go test ./..."},
+		{Type: claude.EventTypeAssistant, Text: "Story implemented."},
+		{Type: claude.EventTypeResult, SessionComplete: true},
+	}
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	assert.Equal(t, []string{"story.md", "main.go"}, result.Files)
+	assert.Equal(t, []string{"go test ./..."}, result.Commands)
+	assert.Equal(t, "Story implemented.", result.FinalText)
+}
+
+func TestRunner_RunStep_SessionID(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, SessionStarted: true, SessionID: "session-1"},
+		{Type: claude.EventTypeResult, SessionComplete: true, SessionID: "session-1"},
+	}
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	assert.Equal(t, "session-1", result.SessionID)
+	require.Len(t, result.Attempts, 1)
+	assert.Equal(t, "session-1", result.Attempts[0].SessionID)
+	assert.Equal(t, []string{""}, mockExecutor.RecordedSessions, "RunStep should start a new session")
+}
+
+func TestRunner_ResumeStep(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+
+	result := runner.ResumeStep(context.Background(), "dev-story", "test-123", "session-1")
+
+	assert.True(t, result.Success)
+	assert.Equal(t, []string{"session-1"}, mockExecutor.RecordedSessions)
+	require.Len(t, mockExecutor.RecordedPrompts, 1)
+	assert.Contains(t, mockExecutor.RecordedPrompts[0], "test-123")
+}
+
+func TestRunner_RunStep_RunLog(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Retry = config.RetryConfig{MaxAttempts: 2}
+	cfg.Workflows["dev-story"] = wf
+
+	mockExecutor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeSystem, Subtype: claude.SubtypeInit, SessionStarted: true, SessionID: "session-1"},
+			{Type: claude.EventTypeResult, SessionComplete: true, NumTurns: 2},
+		},
+		ExitCodes: []int{1, 0},
+	}
+	dir := t.TempDir()
+	log := runlog.New(dir, "run-1", cfg.Hash())
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+	runner.SetRunLog(log)
+	runner.wait = func(ctx context.Context, d time.Duration) error { return nil }
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+	require.True(t, result.Success)
+
+	records, err := runlog.ReadFile(log.StepPath("test-123", "dev-story"))
+	require.NoError(t, err)
+	kinds := make([]string, len(records))
+	for i, rec := range records {
+		kinds[i] = rec.Kind
+	}
+	assert.Equal(t, []string{"start", "event", "event", "end", "start", "event", "event", "end"}, kinds,
+		"every attempt should be recorded")
+	assert.Equal(t, mockExecutor.RecordedPrompts[0], records[0].Prompt)
+	assert.Equal(t, cfg.Hash(), records[0].ConfigHash)
+	assert.Equal(t, 1, *records[3].ExitCode)
+	assert.Equal(t, "session-1", records[3].SessionID)
+	assert.Equal(t, 2, records[4].Attempt)
+	assert.Equal(t, 0, *records[7].ExitCode)
+}
+
+func TestRunner_RunStep_ErrorResult(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, SessionStarted: true},
+		{Type: claude.EventTypeResult, SessionComplete: true, IsError: true},
+	}
+	log := runlog.New(t.TempDir(), "run-1", "")
+	runner.SetRunLog(log)
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	assert.False(t, result.Success)
+	assert.Equal(t, 1, result.ExitCode, "an error result should fail the step although Claude exited with 0")
+	assert.Equal(t, "claude reported an error", result.Reason)
+	require.Len(t, result.Attempts, 1)
+	assert.Equal(t, 1, result.Attempts[0].ExitCode)
+
+	records, err := runlog.ReadFile(log.StepPath("test-123", "dev-story"))
+	require.NoError(t, err)
+	end := records[len(records)-1]
+	assert.Equal(t, runlog.KindEnd, end.Kind)
+	assert.Equal(t, 1, *end.ExitCode)
+	assert.Equal(t, "claude reported an error", end.Reason)
+}
+
+func TestRunner_RunStep_TurnBudgetStopsClaude(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Retry = config.RetryConfig{MaxAttempts: 3}
+	wf.Budget = config.BudgetConfig{MaxTurns: 2}
+	cfg.Workflows["dev-story"] = wf
+
+	mockExecutor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeAssistant, Text: "one"},
+			{Type: claude.EventTypeAssistant, Text: "two"},
+			{Type: claude.EventTypeAssistant, Text: "three"},
+			{Type: claude.EventTypeAssistant, Text: "four"},
+			{Type: claude.EventTypeResult, SessionComplete: true},
+		},
+	}
+	buf := &bytes.Buffer{}
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(buf), cfg)
+	runner.wait = func(ctx context.Context, d time.Duration) error { return nil }
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	assert.False(t, result.Success)
+	assert.NotEqual(t, 0, result.ExitCode)
+	assert.Equal(t, "dev-story budget exceeded: 3 turns (limit 2 turns)", result.Reason)
+	assert.Len(t, result.Attempts, 1, "budget failures should not be retried")
+	assert.NotContains(t, buf.String(), "four", "claude should be stopped once the limit is crossed")
+	assert.Contains(t, buf.String(), "dev-story stopped: dev-story budget exceeded")
+}
+
+func TestRunner_RunStep_TurnBudgetCountsMessages(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Budget = config.BudgetConfig{MaxTurns: 2}
+	cfg.Workflows["dev-story"] = wf
+
+	// Claude emits an event per content block; blocks of one message share its ID.
+	mockExecutor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeAssistant, MessageID: "msg_1", Text: "reading"},
+			{Type: claude.EventTypeAssistant, MessageID: "msg_1", ToolName: "Read"},
+			{Type: claude.EventTypeAssistant, MessageID: "msg_1", ToolName: "Read"},
+			{Type: claude.EventTypeAssistant, MessageID: "msg_2", Text: "editing"},
+			{Type: claude.EventTypeAssistant, MessageID: "msg_2", ToolName: "Edit"},
+			{Type: claude.EventTypeResult, SessionComplete: true},
+		},
+	}
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	assert.True(t, result.Success, result.Reason)
+	assert.Equal(t, 2, result.Usage.Turns)
+}
+
+func TestRunner_RunStep_RunCostBudget(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Budget = config.BudgetConfig{MaxCostUSD: 0.3}
+
+	mockExecutor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeResult, SessionComplete: true, CostUSD: 0.25, NumTurns: 1},
+		},
+	}
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+
+	first := runner.RunStep(context.Background(), "dev-story", "test-123")
+	second := runner.RunStep(context.Background(), "code-review", "test-123")
+	third := runner.RunStep(context.Background(), "git-commit", "test-123")
+
+	assert.True(t, first.Success)
+	assert.False(t, second.Success, "the step that crosses the limit fails")
+	assert.Equal(t, "run budget exceeded: $0.50 spent (limit $0.30)", second.Reason)
+	assert.False(t, third.Success)
+	assert.Empty(t, third.Attempts, "no session should start once the run budget is spent")
+	assert.Len(t, mockExecutor.RecordedPrompts, 2)
+}
+
+func TestRunner_RunStep_SharedRunBudget(t *testing.T) {
+	cfg := config.DefaultConfig()
+	budget := NewBudget("run", config.BudgetConfig{MaxCostUSD: 1})
+	budget.Add(output.Usage{CostUSD: 2})
+
+	mockExecutor := &claude.MockExecutor{}
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+	runner.SetBudget(budget)
+
+	result := runner.RunStep(context.Background(), "dev-story", "test-123")
+
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Reason, "run budget exceeded")
+	assert.Empty(t, mockExecutor.RecordedPrompts)
+}
+
+// hangingExecutor simulates a Claude process that runs until it is stopped.
+type hangingExecutor struct {
+	claude.MockExecutor
+	hangs int // number of calls that hang before calls succeed
+}
+
+func (e *hangingExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler claude.EventHandler) (int, error) {
+	e.RecordedPrompts = append(e.RecordedPrompts, prompt)
+	if len(e.RecordedPrompts) > e.hangs {
+		return 0, nil
+	}
+	<-ctx.Done()
+	return -1, nil
+}
+
+func TestRunner_RunStep_Timeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		retry        config.RetryConfig
+		hangs        int
+		wantExitCode int
+		wantAttempts []int
+		wantReason   string
+	}{
+		{
+			name:         "timeout fails the step",
+			hangs:        1,
+			wantExitCode: ExitCodeTimeout,
+			wantAttempts: []int{ExitCodeTimeout},
+			wantReason:   "timed out after 20ms",
+		},
+		{
+			name:         "timeout can be retried",
+			retry:        config.RetryConfig{MaxAttempts: 2, ExitCodes: []int{ExitCodeTimeout}},
+			hangs:        1,
+			wantExitCode: 0,
+			wantAttempts: []int{ExitCodeTimeout, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			wf := cfg.Workflows["git-commit"]
+			wf.Timeout = 20 * time.Millisecond
+			wf.Retry = tt.retry
+			cfg.Workflows["git-commit"] = wf
+
+			buf := &bytes.Buffer{}
+			runner := NewRunner(&hangingExecutor{hangs: tt.hangs}, output.NewPrinterWithWriter(buf), cfg)
+			runner.wait = func(ctx context.Context, d time.Duration) error { return nil }
+
+			result := runner.RunStep(context.Background(), "git-commit", "test-123")
+
+			assert.Equal(t, tt.wantExitCode, result.ExitCode)
+			var gotAttempts []int
+			for _, a := range result.Attempts {
+				gotAttempts = append(gotAttempts, a.ExitCode)
+			}
+			assert.Equal(t, tt.wantAttempts, gotAttempts)
+			assert.Equal(t, tt.wantReason, result.Reason)
+			assert.Contains(t, buf.String(), "git-commit stopped: timed out after 20ms")
+		})
+	}
+}
+
+func TestRunner_RunStep_CanceledDuringBackoff(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Retry = config.RetryConfig{MaxAttempts: 3, InitialDelay: time.Hour}
+	cfg.Workflows["dev-story"] = wf
+
+	mockExecutor := &claude.MockExecutor{ExitCode: 1}
+	runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	result := runner.RunStep(ctx, "dev-story", "test-123")
+
+	assert.Equal(t, 1, result.ExitCode)
+	assert.Len(t, result.Attempts, 1, "no further attempt after cancellation")
+}
+
+func TestRunner_RunStep_StopRequested(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Retry = config.RetryConfig{MaxAttempts: 3, InitialDelay: time.Hour}
+	cfg.Workflows["dev-story"] = wf
+
+	t.Run("during backoff", func(t *testing.T) {
+		runner := NewRunner(&claude.MockExecutor{ExitCode: 1}, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+
+		stop := make(chan struct{})
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(stop)
+		}()
+
+		result := runner.RunStep(lifecycle.WithStopRequest(context.Background(), stop), "dev-story", "test-123")
+
+		assert.Equal(t, 1, result.ExitCode)
+		assert.Len(t, result.Attempts, 1, "no further attempt after the stop request")
+	})
+
+	t.Run("before the next attempt", func(t *testing.T) {
+		runner := NewRunner(&claude.MockExecutor{ExitCode: 1}, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+		waited := false
+		runner.wait = func(ctx context.Context, d time.Duration) error {
+			waited = true
+			return nil
+		}
+
+		stop := make(chan struct{})
+		close(stop)
+		result := runner.RunStep(lifecycle.WithStopRequest(context.Background(), stop), "dev-story", "test-123")
+
+		assert.Len(t, result.Attempts, 1, "no further attempt after the stop request")
+		assert.False(t, waited, "no backoff after the stop request")
+	})
+}
+
 func TestRunner_RunRaw(t *testing.T) {
 	runner, mockExecutor, _ := setupTestRunner()
 